@@ -0,0 +1,119 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	"sort"
+
+	"github.com/hyperledger-labs/mirbft/pkg/logger"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+	"github.com/hyperledger-labs/mirbft/pkg/statemachine"
+)
+
+// ApplyFunc applies a committed batch to the application.  A successful
+// return acknowledges the batch as applied.
+type ApplyFunc func(commit *state.ActionCommit) error
+
+// CommitApplier offers consumers of the state machine a push model for
+// committed batches, as an alternative to handling Commit actions: it invokes
+// an ApplyFunc for each committed batch, in sequence order, and reports the
+// batches applied back to the state machine as EventCommitsApplied, like any
+// other action result.  The application runs outside the state machine, so
+// its side effects and errors never become part of the state machine's state,
+// and a recorded event log still reproduces it.
+//
+// If the ApplyFunc returns an error, the batch, and every batch committed
+// after it, is held back, and offered again by the next call to Apply, so
+// that commit delivery pauses until the application recovers.  A batch
+// delivered past a gap is held back until the batches of the gap are
+// delivered, so that the ApplyFunc always sees batches in sequence order.
+type CommitApplier struct {
+	apply  ApplyFunc
+	logger logger.Logger
+
+	held    []*state.ActionCommit // Batches not yet applied, by sequence.
+	missing map[uint64]struct{}   // Gaps of delivered batches, not yet delivered themselves.
+}
+
+// NewCommitApplier returns a CommitApplier applying batches with apply.
+func NewCommitApplier(apply ApplyFunc, logger logger.Logger) *CommitApplier {
+	return &CommitApplier{
+		apply:   apply,
+		logger:  logger,
+		missing: map[uint64]struct{}{},
+	}
+}
+
+// Apply applies the batches of the Commit actions of actions, along with
+// those held back by previous calls.  It returns the remaining actions, for
+// the consumer to handle as usual, and the events acknowledging the batches
+// applied, for the consumer to apply to the state machine.
+func (ca *CommitApplier) Apply(actions *statemachine.ActionList) (*statemachine.ActionList, *statemachine.EventList) {
+	remaining := &statemachine.ActionList{}
+	iter := actions.Iterator()
+	for action := iter.Next(); action != nil; action = iter.Next() {
+		if commit, ok := action.Type.(*state.Action_Commit); ok {
+			ca.held = append(ca.held, commit.Commit)
+			delete(ca.missing, commit.Commit.Batch.SeqNo)
+			for _, gap := range commit.Commit.Gaps {
+				ca.missing[gap] = struct{}{}
+			}
+			continue
+		}
+		remaining.PushBack(action)
+	}
+
+	sort.SliceStable(ca.held, func(i, j int) bool {
+		return ca.held[i].Batch.SeqNo < ca.held[j].Batch.SeqNo
+	})
+
+	results := &statemachine.EventList{}
+	var from, to uint64
+	acknowledge := func() {
+		if from != 0 {
+			results.CommitsApplied(from, to)
+		}
+		from, to = 0, 0
+	}
+
+	for len(ca.held) > 0 {
+		commit := ca.held[0]
+		if ca.awaitsGap(commit.Batch.SeqNo) {
+			break
+		}
+
+		if err := ca.apply(commit); err != nil {
+			ca.logger.Log(logger.LevelWarn, "application failed to apply commit, pausing commit delivery", "seq_no", commit.Batch.SeqNo, "error", err)
+			break
+		}
+
+		seqNo := commit.Batch.SeqNo
+		ca.held = ca.held[1:]
+
+		if from != 0 && seqNo != to+1 {
+			acknowledge()
+		}
+		if from == 0 {
+			from = seqNo
+		}
+		to = seqNo
+	}
+	acknowledge()
+
+	return remaining, results
+}
+
+// awaitsGap returns whether a batch below seqNo was skipped by a batch
+// delivered past a gap, and has yet to be delivered itself.
+func (ca *CommitApplier) awaitsGap(seqNo uint64) bool {
+	for gap := range ca.missing {
+		if gap < seqNo {
+			return true
+		}
+	}
+	return false
+}
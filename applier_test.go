@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft_test
+
+import (
+	"fmt"
+
+	"github.com/hyperledger-labs/mirbft"
+	"github.com/hyperledger-labs/mirbft/pkg/logger"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+	"github.com/hyperledger-labs/mirbft/pkg/statemachine"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CommitApplier", func() {
+	var (
+		applier  *mirbft.CommitApplier
+		applied  []uint64
+		applyErr error
+	)
+
+	BeforeEach(func() {
+		applied = nil
+		applyErr = nil
+		applier = mirbft.NewCommitApplier(func(commit *state.ActionCommit) error {
+			if applyErr != nil {
+				return applyErr
+			}
+			applied = append(applied, commit.Batch.SeqNo)
+			return nil
+		}, logger.ConsoleWarnLogger)
+	})
+
+	commit := func(actions *statemachine.ActionList, seqNo uint64, gaps ...uint64) *statemachine.ActionList {
+		return actions.Commit(&msgs.QEntry{SeqNo: seqNo}, nil, gaps...)
+	}
+
+	acks := func(events *statemachine.EventList) []*state.EventCommitsApplied {
+		result := []*state.EventCommitsApplied{}
+		iter := events.Iterator()
+		for event := iter.Next(); event != nil; event = iter.Next() {
+			result = append(result, event.Type.(*state.Event_CommitsApplied).CommitsApplied)
+		}
+		return result
+	}
+
+	It("applies commits in place of the consumer and acknowledges them", func() {
+		actions := &statemachine.ActionList{}
+		commit(actions, 1)
+		actions.Send([]uint64{1}, &msgs.Msg{})
+		commit(actions, 2)
+
+		remaining, results := applier.Apply(actions)
+		Expect(applied).To(Equal([]uint64{1, 2}))
+		Expect(remaining.Len()).To(Equal(1))
+		Expect(remaining.Iterator().Next().Type).To(BeAssignableToTypeOf(&state.Action_Send{}))
+		Expect(acks(results)).To(Equal([]*state.EventCommitsApplied{{From: 1, To: 2}}))
+	})
+
+	It("pauses while the application returns an error, and retries on the next call", func() {
+		applyErr = fmt.Errorf("application unavailable")
+		_, results := applier.Apply(commit(commit(&statemachine.ActionList{}, 1), 2))
+		Expect(applied).To(BeEmpty())
+		Expect(results.Len()).To(Equal(0))
+
+		applyErr = nil
+		_, results = applier.Apply(commit(&statemachine.ActionList{}, 3))
+		Expect(applied).To(Equal([]uint64{1, 2, 3}))
+		Expect(acks(results)).To(Equal([]*state.EventCommitsApplied{{From: 1, To: 3}}))
+	})
+
+	It("holds a commit delivered past a gap until the gap is delivered", func() {
+		_, results := applier.Apply(commit(commit(&statemachine.ActionList{}, 1), 4, 2, 3))
+		Expect(applied).To(Equal([]uint64{1}))
+		Expect(acks(results)).To(Equal([]*state.EventCommitsApplied{{From: 1, To: 1}}))
+
+		_, results = applier.Apply(commit(&statemachine.ActionList{}, 2))
+		Expect(applied).To(Equal([]uint64{1, 2}))
+		Expect(acks(results)).To(Equal([]*state.EventCommitsApplied{{From: 2, To: 2}}))
+
+		_, results = applier.Apply(commit(&statemachine.ActionList{}, 3))
+		Expect(applied).To(Equal([]uint64{1, 2, 3, 4}))
+		Expect(acks(results)).To(Equal([]*state.EventCommitsApplied{{From: 3, To: 4}}))
+	})
+})
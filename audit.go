@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ErrUncommittedSeqNo is reported to the callers of AuditDigest by the
+// application, through AuditDigestResult, when the state machine flags the
+// audited sequence as not yet committed.
+var ErrUncommittedSeqNo = fmt.Errorf("sequence has not committed")
+
+type auditResult struct {
+	digest []byte
+	err    error
+}
+
+// auditWaiters tracks the callers of AuditDigest awaiting the digest of a
+// sequence, until the application reports it with AuditDigestResult.
+type auditWaiters struct {
+	mutex   sync.Mutex
+	waiters map[uint64][]chan auditResult
+}
+
+func newAuditWaiters() *auditWaiters {
+	return &auditWaiters{
+		waiters: map[uint64][]chan auditResult{},
+	}
+}
+
+// wait registers a caller awaiting the digest of seqNo.
+func (aw *auditWaiters) wait(seqNo uint64) chan auditResult {
+	aw.mutex.Lock()
+	defer aw.mutex.Unlock()
+
+	resultC := make(chan auditResult, 1)
+	aw.waiters[seqNo] = append(aw.waiters[seqNo], resultC)
+	return resultC
+}
+
+// cancel unregisters a caller which gave up waiting.  It has no effect once
+// the result has been delivered.
+func (aw *auditWaiters) cancel(seqNo uint64, resultC chan auditResult) {
+	aw.mutex.Lock()
+	defer aw.mutex.Unlock()
+
+	waiters := aw.waiters[seqNo]
+	for i, waiter := range waiters {
+		if waiter == resultC {
+			waiters = append(waiters[:i:i], waiters[i+1:]...)
+			break
+		}
+	}
+
+	if len(waiters) == 0 {
+		delete(aw.waiters, seqNo)
+		return
+	}
+	aw.waiters[seqNo] = waiters
+}
+
+// deliver hands the result for seqNo to every caller awaiting it.
+func (aw *auditWaiters) deliver(seqNo uint64, digest []byte, err error) {
+	aw.mutex.Lock()
+	defer aw.mutex.Unlock()
+
+	for _, resultC := range aw.waiters[seqNo] {
+		resultC <- auditResult{digest: digest, err: err}
+	}
+	delete(aw.waiters, seqNo)
+}
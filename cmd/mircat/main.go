@@ -59,6 +59,8 @@ var (
 		"FetchRequest",
 		"RequestAck",
 		"ForwardRequest",
+		"Hello",
+		"ForwardRequests",
 	}
 )
 
@@ -306,6 +308,10 @@ func (a *arguments) shouldPrint(event *recording.Event) bool {
 			stepTypeText = "ForwardRequest"
 		case *msgs.Msg_RequestAck:
 			stepTypeText = "RequestAck"
+		case *msgs.Msg_Hello:
+			stepTypeText = "Hello"
+		case *msgs.Msg_ForwardRequests:
+			stepTypeText = "ForwardRequests"
 		default:
 			panic("unknown message type")
 		}
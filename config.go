@@ -8,7 +8,11 @@ Refactored: 1
 
 package mirbft
 
-import "github.com/hyperledger-labs/mirbft/pkg/logger"
+import (
+	"time"
+
+	"github.com/hyperledger-labs/mirbft/pkg/logger"
+)
 
 // The NodeConfig struct represents configuration parameters of the node
 // that are independent of the protocol the Node is executing.
@@ -27,6 +31,52 @@ type NodeConfig struct {
 	// to a minimum of a few MB.
 	BufferSize uint32
 
+	// ClientRateLimit, if positive, bounds the rate, in requests per second,
+	// at which SubmitRequest accepts requests from any single client.
+	// Requests exceeding the rate are rejected with ErrClientRateLimited.
+	// This complements the client window size, which bounds the number of
+	// outstanding requests, with a bound on throughput, so that a single
+	// client cannot monopolize a bucket.
+	ClientRateLimit float64
+
+	// ClientRateBurst is the number of requests a client may submit at once
+	// before ClientRateLimit applies.  Values smaller than 1 are treated as 1.
+	ClientRateBurst int
+
+	// Clock, if set, is the time source of the time-dependent features of the
+	// node, that is the client rate limit, the expiry of forwarded requests
+	// (see ForwardCacheTTL) and the preprocessing latency (see
+	// Node.PreprocessLatency), in place of time.Now, so that all of them share
+	// one clock which tests may control.  The state machine does
+	// not read the clock, it measures time in the ticks passed to Run.
+	Clock func() time.Time
+
+	// ClientAuthorizer, if set, restricts the clients whose requests the node
+	// admits to those for which it returns true.  SubmitRequest rejects the
+	// requests of other clients with ErrClientUnauthorized, and requests of
+	// other clients forwarded by other nodes are dropped.
+	ClientAuthorizer func(clientID uint64) bool
+
+	// MaxPendingPreprocess, if positive, bounds the number of requests
+	// submitted to SubmitRequest which have not yet been preprocessed, that is,
+	// whose digests have not yet been computed.  Once the bound is reached,
+	// SubmitRequest rejects further requests with ErrPreprocessBacklog rather
+	// than queueing them, so that memory stays bounded when hashing cannot
+	// keep up with the intake of requests.
+	MaxPendingPreprocess int
+
+	// ForwardCacheSize, if positive, is the number of recently forwarded
+	// requests, identified by client, request number and digest, which the
+	// node remembers.  A request forwarded again while remembered, typically
+	// by another node, is dropped rather than preprocessed once more.
+	ForwardCacheSize int
+
+	// ForwardCacheTTL, if positive, is how long the node remembers a
+	// forwarded request it has not seen since, so that a request forwarded
+	// again much later, e.g. as it did not commit, is preprocessed anew.
+	// Only relevant if ForwardCacheSize is positive.
+	ForwardCacheTTL time.Duration
+
 	//// BatchSize determines how large a batch may grow (in number of request)
 	//// before it is cut. (Note, batches may be cut earlier, so this is a max size).
 	//BatchSize uint32
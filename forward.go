@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	"github.com/hyperledger-labs/mirbft/pkg/modules"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+	"github.com/pkg/errors"
+)
+
+// ForwardMsg returns the message satisfying a Forward action, to be sent to
+// its targets: the data of the acknowledged requests, read from store, in a
+// single ForwardRequests message, or in a ForwardRequest message if the
+// action forwards one request only.
+func ForwardMsg(forward *state.ActionForward, store modules.RequestStore) (*msgs.Msg, error) {
+	requests := make([]*msgs.ForwardRequest, len(forward.Acks))
+	for i, ack := range forward.Acks {
+		data, err := store.GetRequest(&msgs.RequestRef{
+			ClientId: ack.ClientId,
+			ReqNo:    ack.ReqNo,
+			Digest:   ack.Digest,
+		})
+		if err != nil {
+			return nil, errors.WithMessagef(err, "could not read data of client_id=%d req_no=%d to forward", ack.ClientId, ack.ReqNo)
+		}
+
+		requests[i] = &msgs.ForwardRequest{
+			RequestAck:  ack,
+			RequestData: data,
+		}
+	}
+
+	if len(requests) == 1 {
+		return &msgs.Msg{
+			Type: &msgs.Msg_ForwardRequest{
+				ForwardRequest: requests[0],
+			},
+		}, nil
+	}
+
+	return &msgs.Msg{
+		Type: &msgs.Msg_ForwardRequests{
+			ForwardRequests: &msgs.ForwardRequests{
+				Requests: requests,
+			},
+		},
+	}, nil
+}
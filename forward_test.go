@@ -0,0 +1,86 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft_test
+
+import (
+	"fmt"
+
+	"github.com/hyperledger-labs/mirbft"
+	"github.com/hyperledger-labs/mirbft/pkg/modules"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/statemachine"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// mapRequestStore serves the data of requests from a map, by client ID.
+type mapRequestStore struct {
+	modules.RequestStore
+	data map[uint64][]byte
+}
+
+func (rs *mapRequestStore) GetRequest(reqRef *msgs.RequestRef) ([]byte, error) {
+	data, ok := rs.data[reqRef.ClientId]
+	if !ok {
+		return nil, fmt.Errorf("no data for client_id=%d", reqRef.ClientId)
+	}
+	return data, nil
+}
+
+var _ = Describe("ForwardMsg", func() {
+	var (
+		store *mapRequestStore
+	)
+
+	BeforeEach(func() {
+		store = &mapRequestStore{
+			data: map[uint64][]byte{
+				0: []byte("data-0"),
+				4: []byte("data-4"),
+				8: []byte("data-8"),
+			},
+		}
+	})
+
+	ack := func(clientID uint64) *msgs.RequestAck {
+		return &msgs.RequestAck{
+			ClientId: clientID,
+			Digest:   []byte(fmt.Sprintf("request-digest-%d", clientID)),
+		}
+	}
+
+	It("forwards the requests of a single action in a single message", func() {
+		action := statemachine.ActionForwardRequest([]uint64{1}, ack(0), ack(4), ack(8))
+
+		msg, err := mirbft.ForwardMsg(action.GetForwardRequest(), store)
+		Expect(err).NotTo(HaveOccurred())
+
+		forwards := msg.GetForwardRequests()
+		Expect(forwards).NotTo(BeNil())
+		Expect(forwards.Requests).To(HaveLen(3))
+		for i, clientID := range []uint64{0, 4, 8} {
+			Expect(forwards.Requests[i].RequestAck).To(Equal(ack(clientID)))
+			Expect(forwards.Requests[i].RequestData).To(Equal(store.data[clientID]))
+		}
+	})
+
+	It("forwards a single request in a message of its own", func() {
+		action := statemachine.ActionForwardRequest([]uint64{1}, ack(4))
+
+		msg, err := mirbft.ForwardMsg(action.GetForwardRequest(), store)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(msg.GetForwardRequest().RequestData).To(Equal([]byte("data-4")))
+	})
+
+	It("fails if the data of a request is missing", func() {
+		action := statemachine.ActionForwardRequest([]uint64{1}, ack(0), ack(2))
+
+		_, err := mirbft.ForwardMsg(action.GetForwardRequest(), store)
+		Expect(err).To(MatchError("could not read data of client_id=2 req_no=0 to forward: no data for client_id=2"))
+	})
+})
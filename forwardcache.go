@@ -0,0 +1,124 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/hyperledger-labs/mirbft/pkg/modules"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+)
+
+// forwardCache remembers the most recently seen forwarded requests, so that a
+// request forwarded by several nodes is only preprocessed once.
+// Once size requests are remembered, the least recently seen one is forgotten,
+// and if ttl is positive, a request not seen for ttl, as measured by now, is
+// forgotten as well.
+type forwardCache struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mutex   sync.Mutex
+	order   *list.List // Of *forwardEntry, least recently seen first.
+	entries map[forwardKey]*list.Element
+}
+
+type forwardEntry struct {
+	key    forwardKey
+	seenAt time.Time
+}
+
+type forwardKey struct {
+	clientID uint64
+	reqNo    uint64
+	digest   string
+	dataHash string
+}
+
+func newForwardCache(size int, ttl time.Duration, now func() time.Time) *forwardCache {
+	return &forwardCache{
+		size:    size,
+		ttl:     ttl,
+		now:     now,
+		order:   list.New(),
+		entries: map[forwardKey]*list.Element{},
+	}
+}
+
+// forwardKeyOf identifies a forwarded request by its ack, along with a hash of
+// its data.  The ack is the forwarder's own claim, so were the data not part
+// of the key, a faulty node could forward bad data under the ack of a request
+// first, and have the honest forwards of that request dropped.
+func forwardKeyOf(forward *msgs.ForwardRequest, hasher modules.Hasher) forwardKey {
+	h := hasher.New()
+	h.Write(forward.GetRequestData())
+
+	ack := forward.GetRequestAck()
+	return forwardKey{
+		clientID: ack.GetClientId(),
+		reqNo:    ack.GetReqNo(),
+		digest:   string(ack.GetDigest()),
+		dataHash: string(h.Sum(nil)),
+	}
+}
+
+// seen records the forwarded request identified by key, and returns whether
+// it was already recorded.
+func (fc *forwardCache) seen(key forwardKey) bool {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	now := fc.now()
+	fc.expire(now)
+
+	if el, ok := fc.entries[key]; ok {
+		el.Value.(*forwardEntry).seenAt = now
+		fc.order.MoveToBack(el)
+		return true
+	}
+
+	fc.entries[key] = fc.order.PushBack(&forwardEntry{key: key, seenAt: now})
+	if fc.order.Len() > fc.size {
+		fc.remove(fc.order.Front())
+	}
+
+	return false
+}
+
+// expire forgets the requests not seen for ttl.  As seeing a request moves it
+// to the back of order, these are at its front.
+func (fc *forwardCache) expire(now time.Time) {
+	if fc.ttl <= 0 {
+		return
+	}
+
+	for el := fc.order.Front(); el != nil; el = fc.order.Front() {
+		if now.Sub(el.Value.(*forwardEntry).seenAt) < fc.ttl {
+			return
+		}
+		fc.remove(el)
+	}
+}
+
+func (fc *forwardCache) remove(el *list.Element) {
+	fc.order.Remove(el)
+	delete(fc.entries, el.Value.(*forwardEntry).key)
+}
+
+// forget removes the forwarded request identified by key, so that it is
+// processed when forwarded again, e.g. as it could not be enqueued.
+func (fc *forwardCache) forget(key forwardKey) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	if el, ok := fc.entries[key]; ok {
+		fc.remove(el)
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("forwardCache", func() {
+	var (
+		now time.Time
+		fc  *forwardCache
+	)
+
+	BeforeEach(func() {
+		now = time.Unix(0, 0)
+		fc = newForwardCache(2, 10*time.Second, func() time.Time { return now })
+	})
+
+	key := func(reqNo uint64) forwardKey {
+		return forwardKey{reqNo: reqNo}
+	}
+
+	It("forgets the requests not seen for the ttl", func() {
+		Expect(fc.seen(key(0))).To(BeFalse())
+
+		now = now.Add(5 * time.Second)
+		Expect(fc.seen(key(1))).To(BeFalse())
+		Expect(fc.seen(key(0))).To(BeTrue())
+
+		// Seeing request 0 again renewed it, request 1 expires first.
+		now = now.Add(10 * time.Second)
+		Expect(fc.seen(key(0))).To(BeFalse())
+		Expect(fc.entries).To(HaveLen(1))
+		Expect(fc.seen(key(1))).To(BeFalse())
+	})
+
+	It("forgets the least recently seen request once full, regardless of the ttl", func() {
+		Expect(fc.seen(key(0))).To(BeFalse())
+		Expect(fc.seen(key(1))).To(BeFalse())
+		Expect(fc.seen(key(2))).To(BeFalse())
+
+		Expect(fc.seen(key(0))).To(BeFalse())
+		Expect(fc.seen(key(2))).To(BeTrue())
+	})
+})
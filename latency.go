@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	"sync"
+	"time"
+)
+
+// LatencyStats summarizes the latencies measured by a Node.
+type LatencyStats struct {
+	// Count is the number of latencies measured.
+	Count uint64
+
+	// Mean is the mean of the latencies measured.
+	Mean time.Duration
+
+	// Max is the largest of the latencies measured.
+	Max time.Duration
+}
+
+type latencyKey struct {
+	clientID uint64
+	reqNo    uint64
+}
+
+// latencyTracker measures how long requests take from their submission until
+// they are preprocessed, as measured by now.
+type latencyTracker struct {
+	now func() time.Time
+
+	mutex     sync.Mutex
+	submitted map[latencyKey]time.Time
+	total     time.Duration
+	stats     LatencyStats
+}
+
+func newLatencyTracker(now func() time.Time) *latencyTracker {
+	return &latencyTracker{
+		now:       now,
+		submitted: map[latencyKey]time.Time{},
+	}
+}
+
+// start records the submission of request reqNo of clientID.
+func (lt *latencyTracker) start(clientID, reqNo uint64) {
+	lt.mutex.Lock()
+	defer lt.mutex.Unlock()
+
+	lt.submitted[latencyKey{clientID: clientID, reqNo: reqNo}] = lt.now()
+}
+
+// cancel forgets the submission of a request which was not enqueued.
+func (lt *latencyTracker) cancel(clientID, reqNo uint64) {
+	lt.mutex.Lock()
+	defer lt.mutex.Unlock()
+
+	delete(lt.submitted, latencyKey{clientID: clientID, reqNo: reqNo})
+}
+
+// stop measures the latency of a request once preprocessed.  It has no effect
+// if the submission of the request was not recorded.
+func (lt *latencyTracker) stop(clientID, reqNo uint64) {
+	lt.mutex.Lock()
+	defer lt.mutex.Unlock()
+
+	key := latencyKey{clientID: clientID, reqNo: reqNo}
+	submittedAt, ok := lt.submitted[key]
+	if !ok {
+		return
+	}
+	delete(lt.submitted, key)
+
+	latency := lt.now().Sub(submittedAt)
+	lt.total += latency
+	lt.stats.Count++
+	lt.stats.Mean = lt.total / time.Duration(lt.stats.Count)
+	if latency > lt.stats.Max {
+		lt.stats.Max = latency
+	}
+}
+
+func (lt *latencyTracker) summary() LatencyStats {
+	lt.mutex.Lock()
+	defer lt.mutex.Unlock()
+
+	return lt.stats
+}
@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("latencyTracker", func() {
+	var (
+		now time.Time
+		lt  *latencyTracker
+	)
+
+	BeforeEach(func() {
+		now = time.Unix(0, 0)
+		lt = newLatencyTracker(func() time.Time { return now })
+	})
+
+	It("measures the latency of each request on the clock", func() {
+		lt.start(0, 0)
+		now = now.Add(time.Second)
+		lt.start(0, 1)
+		now = now.Add(2 * time.Second)
+		lt.stop(0, 0)
+		lt.stop(0, 1)
+
+		Expect(lt.summary()).To(Equal(LatencyStats{
+			Count: 2,
+			Mean:  2500 * time.Millisecond,
+			Max:   3 * time.Second,
+		}))
+	})
+
+	It("ignores requests whose submission was canceled or not recorded", func() {
+		lt.start(0, 0)
+		lt.cancel(0, 0)
+		now = now.Add(time.Second)
+		lt.stop(0, 0)
+		lt.stop(1, 0)
+
+		Expect(lt.summary()).To(Equal(LatencyStats{}))
+	})
+})
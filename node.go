@@ -14,14 +14,41 @@ import (
 	"github.com/hyperledger-labs/mirbft/pkg/clients"
 	"github.com/hyperledger-labs/mirbft/pkg/modules"
 	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
 	"github.com/hyperledger-labs/mirbft/pkg/statemachine"
 	"github.com/hyperledger-labs/mirbft/pkg/status"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 var ErrStopped = fmt.Errorf("stopped at caller request")
 
+// ErrNoLeadershipView is returned by ReadyNode.LeadershipView when the state
+// machine does not implement modules.LeadershipViewer.
+var ErrNoLeadershipView = fmt.Errorf("state machine does not serve a leadership view")
+
+// ErrUnsupportedQuery is returned by the accessors of ReadyNode when the state
+// machine does not implement the optional interface of modules they rely on.
+var ErrUnsupportedQuery = fmt.Errorf("state machine does not support the query")
+
+// ErrProposalsPaused is returned by SubmitRequest while the intake of new requests is paused.
+var ErrProposalsPaused = fmt.Errorf("proposals are paused")
+
+// ErrClientRateLimited is returned by SubmitRequest when the client submits requests
+// faster than the configured NodeConfig.ClientRateLimit.
+var ErrClientRateLimited = fmt.Errorf("client exceeded its request rate")
+
+// ErrClientUnauthorized is returned by SubmitRequest when the configured
+// NodeConfig.ClientAuthorizer does not admit the client.
+var ErrClientUnauthorized = fmt.Errorf("client is not authorized to submit requests")
+
+// ErrPreprocessBacklog is returned by SubmitRequest when the number of requests awaiting
+// preprocessing has reached the configured NodeConfig.MaxPendingPreprocess.
+var ErrPreprocessBacklog = fmt.Errorf("too many requests awaiting preprocessing")
+
 // Node is the local instance of MirBFT and the application's interface to the mirbft library.
 type Node struct {
 	ID     uint64      // Protocol-level node ID
@@ -54,6 +81,28 @@ type Node struct {
 	// to which the state machine status needs to be written once the status is obtained.
 	// TODO: Implement obtaining and writing the status (Currently no one reads from this channel).
 	statusC chan chan *status.StateMachine
+
+	// Set to 1 while the intake of new requests is paused (see PauseProposals).
+	// Accessed atomically, as SubmitRequest may be called concurrently.
+	proposalsPaused uint32
+
+	// Admits client requests at the rate configured by NodeConfig.ClientRateLimit.
+	// Nil if requests are not rate limited.
+	clientRateLimiter *clientRateLimiter
+
+	// Remembers the requests recently forwarded to the node, see NodeConfig.ForwardCacheSize.
+	// Nil if forwarded requests are not deduplicated.
+	forwardCache *forwardCache
+
+	// Measures the latency of preprocessing submitted requests, see PreprocessLatency.
+	preprocessLatency *latencyTracker
+
+	// Callers of AuditDigest awaiting the digest reported by AuditDigestResult.
+	audits *auditWaiters
+
+	// Number of requests accepted by SubmitRequest which have not yet been preprocessed.
+	// Accessed atomically, as SubmitRequest may be called concurrently.
+	pendingPreprocess int64
 }
 
 // NewNode creates a new node with numeric ID id.
@@ -64,6 +113,21 @@ func NewNode(
 	config *NodeConfig,
 	modules *modules.Modules,
 ) (*Node, error) {
+	clock := config.Clock
+	if clock == nil {
+		clock = time.Now
+	}
+
+	var rateLimiter *clientRateLimiter
+	if config.ClientRateLimit > 0 {
+		rateLimiter = newClientRateLimiter(config.ClientRateLimit, config.ClientRateBurst, clock)
+	}
+
+	var forwards *forwardCache
+	if config.ForwardCacheSize > 0 {
+		forwards = newForwardCache(config.ForwardCacheSize, config.ForwardCacheTTL, clock)
+	}
+
 	return &Node{
 		ID:     id,
 		Config: config,
@@ -81,6 +145,11 @@ func NewNode(
 		workErrNotifier: newWorkErrNotifier(),
 
 		statusC: make(chan chan *status.StateMachine),
+
+		clientRateLimiter: rateLimiter,
+		forwardCache:      forwards,
+		preprocessLatency: newLatencyTracker(clock),
+		audits:            newAuditWaiters(),
 	}, nil
 }
 
@@ -120,6 +189,38 @@ func (n *Node) Status(ctx context.Context) (*status.StateMachine, error) {
 // for example by using an authenticated communication channel (e.g. TLS) with the source node.
 func (n *Node) Step(ctx context.Context, source uint64, msg *msgs.Msg) error {
 
+	// Step the requests forwarded together one by one, so that each is
+	// authorized and deduplicated on its own.
+	if forwards, ok := msg.Type.(*msgs.Msg_ForwardRequests); ok {
+		for _, forward := range forwards.ForwardRequests.Requests {
+			err := n.Step(ctx, source, &msgs.Msg{
+				Type: &msgs.Msg_ForwardRequest{
+					ForwardRequest: forward,
+				},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Drop requests forwarded on behalf of clients this node does not admit.
+	if forward, ok := msg.Type.(*msgs.Msg_ForwardRequest); ok && !n.clientAuthorized(forward.ForwardRequest.GetRequestAck().GetClientId()) {
+		return nil
+	}
+
+	// Drop requests which were recently forwarded already, likely by another node.
+	// A request is only remembered once its Step event is enqueued.
+	var forwarded *forwardKey
+	if forward, ok := msg.Type.(*msgs.Msg_ForwardRequest); ok && n.forwardCache != nil {
+		key := forwardKeyOf(forward.ForwardRequest, n.modules.Hasher)
+		if n.forwardCache.seen(key) {
+			return nil
+		}
+		forwarded = &key
+	}
+
 	// Pre-process the incoming message and return an error if pre-processing fails.
 	// TODO: Re-enable pre-processing.
 	//err := preProcess(msg)
@@ -131,29 +232,215 @@ func (n *Node) Step(ctx context.Context, source uint64, msg *msgs.Msg) error {
 	e := (&statemachine.EventList{}).Step(source, msg)
 
 	// Enqueue event in a work channel to be handled by the processing thread.
+	var err error
 	select {
 	case n.workChans.externalEvents <- e:
 		return nil
 	case <-n.workErrNotifier.ExitStatusC():
-		return n.workErrNotifier.Err()
+		err = n.workErrNotifier.Err()
 	case <-ctx.Done():
-		return ctx.Err()
+		err = ctx.Err()
+	}
+
+	if forwarded != nil {
+		n.forwardCache.forget(*forwarded)
 	}
+	return err
 }
 
 // SubmitRequest submits a new client request to the Node.
 // clientID and reqNo uniquely identify the request.
 // data constitutes the (opaque) payload of the request.
-func (n *Node) SubmitRequest(ctx context.Context, clientID uint64, reqNo uint64, data []byte) error {
+// metadata is opaque application data, such as a trace ID, kept along with the payload
+// for the application to read back once the request commits. It is not covered by the
+// request digest, so it affects neither ordering nor deduplication.
+// If proposals are paused (see PauseProposals), SubmitRequest returns ErrProposalsPaused.
+// If the client exceeds its rate (see NodeConfig.ClientRateLimit), SubmitRequest returns ErrClientRateLimited.
+// If the client is not admitted (see NodeConfig.ClientAuthorizer), SubmitRequest returns ErrClientUnauthorized.
+// If too many requests await preprocessing (see NodeConfig.MaxPendingPreprocess),
+// SubmitRequest returns ErrPreprocessBacklog.
+func (n *Node) SubmitRequest(ctx context.Context, clientID uint64, reqNo uint64, data, metadata []byte) error {
+
+	// Reject the request if the intake of new requests is paused.
+	if atomic.LoadUint32(&n.proposalsPaused) == 1 {
+		return ErrProposalsPaused
+	}
+
+	// Reject the request if the client is not admitted.
+	if !n.clientAuthorized(clientID) {
+		return ErrClientUnauthorized
+	}
+
+	// Reject the request if too many requests are already awaiting preprocessing.
+	pending := atomic.AddInt64(&n.pendingPreprocess, 1)
+	if limit := n.Config.MaxPendingPreprocess; limit > 0 && pending > int64(limit) {
+		atomic.AddInt64(&n.pendingPreprocess, -1)
+		return ErrPreprocessBacklog
+	}
+
+	// Reject the request if the client is submitting requests faster than allowed.
+	if n.clientRateLimiter != nil && !n.clientRateLimiter.allow(clientID) {
+		atomic.AddInt64(&n.pendingPreprocess, -1)
+		return ErrClientRateLimited
+	}
 
 	// Enqueue the generated events in a work channel to be handled by the processing thread.
+	// Once enqueued, the request remains pending until the client worker has preprocessed it.
+	n.preprocessLatency.start(clientID, reqNo)
 	select {
-	case n.workChans.clientIn <- (&statemachine.EventList{}).ClientRequest(clientID, reqNo, data):
+	case n.workChans.clientIn <- (&statemachine.EventList{}).ClientRequest(clientID, reqNo, data, metadata):
 		return nil
 	case <-ctx.Done():
+		n.preprocessLatency.cancel(clientID, reqNo)
+		atomic.AddInt64(&n.pendingPreprocess, -1)
 		return ctx.Err()
 	case <-n.workErrNotifier.ExitC():
+		n.preprocessLatency.cancel(clientID, reqNo)
+		atomic.AddInt64(&n.pendingPreprocess, -1)
+		return n.workErrNotifier.Err()
+	}
+}
+
+// PreprocessLatency returns the latencies of the requests submitted to
+// SubmitRequest, from their submission until their digests are computed, as
+// measured by NodeConfig.Clock.
+func (n *Node) PreprocessLatency() LatencyStats {
+	return n.preprocessLatency.summary()
+}
+
+// clientAuthorized returns whether the node admits the requests of the client,
+// according to NodeConfig.ClientAuthorizer.
+func (n *Node) clientAuthorized(clientID uint64) bool {
+	return n.Config.ClientAuthorizer == nil || n.Config.ClientAuthorizer(clientID)
+}
+
+// PauseProposals makes the Node stop accepting new requests,
+// e.g. while the operator performs maintenance such as an online backup.
+// Until ResumeProposals is called, SubmitRequest returns ErrProposalsPaused.
+// The Node keeps processing incoming messages and ordering requests it has already accepted.
+func (n *Node) PauseProposals() {
+	atomic.StoreUint32(&n.proposalsPaused, 1)
+}
+
+// ResumeProposals makes the Node accept new requests again after a call to PauseProposals.
+func (n *Node) ResumeProposals() {
+	atomic.StoreUint32(&n.proposalsPaused, 0)
+}
+
+// Compact makes the Node truncate its write-ahead log to the latest stable checkpoint,
+// without waiting for the next checkpoint to stabilize, e.g. when under memory pressure.
+// Nothing above the stable checkpoint is truncated. If the log is already truncated
+// to the stable checkpoint, Compact has no effect.
+func (n *Node) Compact(ctx context.Context) error {
+	select {
+	case n.workChans.externalEvents <- (&statemachine.EventList{}).Compact():
+		return nil
+	case <-n.workErrNotifier.ExitStatusC():
+		return n.workErrNotifier.Err()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// StepDown makes the Node relinquish the leadership of its buckets,
+// e.g. when it is overloaded or before it is taken down for maintenance.
+// The Node stops proposing, and once the batches it has already proposed commit,
+// it triggers an epoch change which hands its buckets to other leaders.
+// Requests not yet proposed are ordered by the new leaders.
+// As buckets are only assigned to leaders per epoch, this is an epoch change
+// like any other: ordering pauses in every bucket until the next epoch starts,
+// and its buckets are distributed anew among the remaining leaders, so those
+// of the other leaders may move as well.
+func (n *Node) StepDown(ctx context.Context) error {
+	select {
+	case n.workChans.externalEvents <- (&statemachine.EventList{}).StepDown():
+		return nil
+	case <-n.workErrNotifier.ExitStatusC():
 		return n.workErrNotifier.Err()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// AuditDigest returns the digest of the application state at the already
+// committed sequence number seqNo, e.g. to periodically compare it across
+// nodes and detect divergence.  The request is serialized with the other
+// events of the Node, which then asks the application for the digest with an
+// AuditDigest action, and waits for the application to report it with
+// AuditDigestResult.  For a sequence which has not yet committed, the action
+// is flagged as uncommitted, and the application is expected to report
+// ErrUncommittedSeqNo.
+func (n *Node) AuditDigest(ctx context.Context, seqNo uint64) ([]byte, error) {
+	resultC := n.audits.wait(seqNo)
+	defer n.audits.cancel(seqNo, resultC)
+
+	select {
+	case n.workChans.externalEvents <- (&statemachine.EventList{}).AuditDigest(seqNo):
+	case <-n.workErrNotifier.ExitStatusC():
+		return nil, n.workErrNotifier.Err()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case result := <-resultC:
+		return result.digest, result.err
+	case <-n.workErrNotifier.ExitStatusC():
+		return nil, n.workErrNotifier.Err()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// AuditDigestResult reports the digest of the application state at seqNo,
+// as asked for by an AuditDigest action, to the callers of AuditDigest
+// awaiting it.  If err is not nil, it is returned to them instead, e.g.
+// ErrUncommittedSeqNo for an action flagged as uncommitted.
+func (n *Node) AuditDigestResult(seqNo uint64, digest []byte, err error) {
+	n.audits.deliver(seqNo, digest, err)
+}
+
+// ImportClientState imports into the Node the window state of a client, as
+// serialized by StateMachine.ExportClientState on another node, e.g. to move
+// a client between deployments without losing its pending requests.  The
+// import is serialized with the other events of the Node.  The data of the
+// pending requests the state carries must have been persisted beforehand.
+// A client state conflicting with the commits of the Node is ignored.
+func (n *Node) ImportClientState(ctx context.Context, data []byte) error {
+	clientState := &state.ClientState{}
+	if err := proto.Unmarshal(data, clientState); err != nil {
+		return errors.WithMessage(err, "could not unmarshal client state")
+	}
+
+	select {
+	case n.workChans.externalEvents <- (&statemachine.EventList{}).ImportClientState(clientState):
+		return nil
+	case <-n.workErrNotifier.ExitStatusC():
+		return n.workErrNotifier.Err()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RequestCheckpoint proposes a coordinated checkpoint, e.g. for a backup of
+// every node at the same point.  The Node acknowledges the next request of
+// the first of the CheckpointClients of the network configuration, which
+// carries no data and is ordered like any other request, and once it
+// commits, every Node asks its application for a checkpoint with a
+// CoordinatedCheckpoint action at the same sequence number.  As for any
+// request, a weak quorum of nodes must acknowledge it, so the checkpoint
+// should be requested of every Node.  Unlike AuditDigest, which only
+// concerns the local Node, the sequence number is agreed on by the network,
+// and unlike the periodic checkpoints, it follows the request rather than a
+// fixed interval.
+func (n *Node) RequestCheckpoint(ctx context.Context) error {
+	select {
+	case n.workChans.externalEvents <- (&statemachine.EventList{}).RequestCheckpoint():
+		return nil
+	case <-n.workErrNotifier.ExitStatusC():
+		return n.workErrNotifier.Err()
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
 
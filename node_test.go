@@ -0,0 +1,626 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft_test
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"hash"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyperledger-labs/mirbft"
+	"github.com/hyperledger-labs/mirbft/pkg/deploytest"
+	"github.com/hyperledger-labs/mirbft/pkg/logger"
+	"github.com/hyperledger-labs/mirbft/pkg/modules"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+	"github.com/hyperledger-labs/mirbft/pkg/statemachine"
+	"google.golang.org/protobuf/proto"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// stallingHasher blocks the creation of hashes until release is closed.
+type stallingHasher struct {
+	release chan struct{}
+}
+
+func (sh *stallingHasher) New() hash.Hash {
+	<-sh.release
+	return crypto.SHA256.New()
+}
+
+// recordingHasher records the data covered by each hash created.
+type recordingHasher struct {
+	mutex  sync.Mutex
+	hashed [][]byte
+}
+
+func (rh *recordingHasher) New() hash.Hash {
+	return &recordingHash{Hash: crypto.SHA256.New(), hasher: rh}
+}
+
+// recorded returns the data covered by the hashes computed so far.
+func (rh *recordingHasher) recorded() [][]byte {
+	rh.mutex.Lock()
+	defer rh.mutex.Unlock()
+	return append([][]byte{}, rh.hashed...)
+}
+
+type recordingHash struct {
+	hash.Hash
+	hasher  *recordingHasher
+	written []byte
+}
+
+func (h *recordingHash) Write(p []byte) (int, error) {
+	h.written = append(h.written, p...)
+	return h.Hash.Write(p)
+}
+
+func (h *recordingHash) Sum(b []byte) []byte {
+	h.hasher.mutex.Lock()
+	defer h.hasher.mutex.Unlock()
+	h.hasher.hashed = append(h.hasher.hashed, h.written)
+	return h.Hash.Sum(b)
+}
+
+// forwardCountingSM counts the forwarded requests stepped into it, and
+// records the clients they were forwarded for.
+type forwardCountingSM struct {
+	*deploytest.DummySM
+	forwards int64
+
+	mutex   sync.Mutex
+	clients []uint64
+}
+
+func (fsm *forwardCountingSM) ApplyEvent(event *state.Event) *statemachine.EventList {
+	if step, ok := event.Type.(*state.Event_Step); ok && step.Step.Msg.GetForwardRequest() != nil {
+		fsm.mutex.Lock()
+		fsm.clients = append(fsm.clients, step.Step.Msg.GetForwardRequest().RequestAck.ClientId)
+		fsm.mutex.Unlock()
+		atomic.AddInt64(&fsm.forwards, 1)
+		return &statemachine.EventList{}
+	}
+	return fsm.DummySM.ApplyEvent(event)
+}
+
+// forwardedClients returns the clients of the forwarded requests so far.
+func (fsm *forwardCountingSM) forwardedClients() []uint64 {
+	fsm.mutex.Lock()
+	defer fsm.mutex.Unlock()
+	return append([]uint64{}, fsm.clients...)
+}
+
+// eventRecordingSM records the events the application submits to the node
+// for the state machine, which the DummySM does not handle.
+type eventRecordingSM struct {
+	*deploytest.DummySM
+	mutex  sync.Mutex
+	events []*state.Event
+}
+
+func (rsm *eventRecordingSM) ApplyEvent(event *state.Event) *statemachine.EventList {
+	switch event.Type.(type) {
+	case *state.Event_AuditDigest, *state.Event_StepDown, *state.Event_Compact, *state.Event_ImportClientState, *state.Event_RequestCheckpoint:
+		rsm.mutex.Lock()
+		defer rsm.mutex.Unlock()
+		rsm.events = append(rsm.events, event)
+		return &statemachine.EventList{}
+	}
+	return rsm.DummySM.ApplyEvent(event)
+}
+
+// recorded returns the events recorded so far.
+func (rsm *eventRecordingSM) recorded() []*state.Event {
+	rsm.mutex.Lock()
+	defer rsm.mutex.Unlock()
+	return append([]*state.Event{}, rsm.events...)
+}
+
+var _ = Describe("Node", func() {
+	var (
+		config *mirbft.NodeConfig
+		hasher modules.Hasher
+		sm     modules.StateMachine
+		node   *mirbft.Node
+		stopC  chan struct{}
+		wg     sync.WaitGroup
+	)
+
+	BeforeEach(func() {
+		config = &mirbft.NodeConfig{
+			BufferSize: deploytest.TestMsgBufSize,
+			Logger:     logger.ConsoleWarnLogger,
+		}
+		hasher = crypto.SHA256
+		sm = deploytest.NewDummySM(logger.ConsoleWarnLogger)
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		node, err = mirbft.NewNode(
+			0,
+			config,
+			&modules.Modules{
+				Hasher:       hasher,
+				StateMachine: sm,
+			},
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		stopC = make(chan struct{})
+		wg.Add(1)
+		go func() {
+			defer GinkgoRecover()
+			defer wg.Done()
+			Expect(node.Run(stopC, nil)).To(Equal(mirbft.ErrStopped))
+		}()
+	})
+
+	AfterEach(func() {
+		close(stopC)
+		wg.Wait()
+	})
+
+	It("rejects requests while proposals are paused", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		defer cancel()
+
+		node.PauseProposals()
+		err := node.SubmitRequest(ctx, 0, 0, []byte("request"), nil)
+		Expect(err).To(Equal(mirbft.ErrProposalsPaused))
+
+		node.ResumeProposals()
+		err = node.SubmitRequest(ctx, 0, 0, []byte("request"), nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	When("clients are rate limited", func() {
+		BeforeEach(func() {
+			config.ClientRateLimit = 0.001
+			config.ClientRateBurst = 5
+		})
+
+		It("rejects requests of a client beyond its rate while other clients proceed", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			for reqNo := uint64(0); reqNo < 5; reqNo++ {
+				Expect(node.SubmitRequest(ctx, 0, reqNo, []byte("request"), nil)).To(Succeed())
+			}
+
+			for reqNo := uint64(5); reqNo < 10; reqNo++ {
+				err := node.SubmitRequest(ctx, 0, reqNo, []byte("request"), nil)
+				Expect(err).To(Equal(mirbft.ErrClientRateLimited))
+			}
+
+			Expect(node.SubmitRequest(ctx, 1, 0, []byte("request"), nil)).To(Succeed())
+		})
+
+		When("the clock is injected", func() {
+			var (
+				mutex sync.Mutex
+				now   time.Time
+			)
+
+			advance := func(d time.Duration) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				now = now.Add(d)
+			}
+
+			BeforeEach(func() {
+				now = time.Unix(0, 0)
+				config.ClientRateLimit = 1
+				config.ClientRateBurst = 1
+				config.Clock = func() time.Time {
+					mutex.Lock()
+					defer mutex.Unlock()
+					return now
+				}
+			})
+
+			It("admits requests as the injected clock advances", func() {
+				ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+				defer cancel()
+
+				Expect(node.SubmitRequest(ctx, 0, 0, []byte("request"), nil)).To(Succeed())
+				Expect(node.SubmitRequest(ctx, 0, 1, []byte("request"), nil)).To(Equal(mirbft.ErrClientRateLimited))
+
+				advance(500 * time.Millisecond)
+				Expect(node.SubmitRequest(ctx, 0, 1, []byte("request"), nil)).To(Equal(mirbft.ErrClientRateLimited))
+
+				advance(500 * time.Millisecond)
+				Expect(node.SubmitRequest(ctx, 0, 1, []byte("request"), nil)).To(Succeed())
+			})
+		})
+	})
+
+	When("clients are authorized", func() {
+		var (
+			counting *forwardCountingSM
+		)
+
+		BeforeEach(func() {
+			config.ClientAuthorizer = func(clientID uint64) bool {
+				return clientID == 1
+			}
+			counting = &forwardCountingSM{
+				DummySM: deploytest.NewDummySM(logger.ConsoleWarnLogger),
+			}
+			sm = counting
+		})
+
+		forward := func(clientID uint64) *msgs.Msg {
+			return &msgs.Msg{
+				Type: &msgs.Msg_ForwardRequest{
+					ForwardRequest: &msgs.ForwardRequest{
+						RequestAck: &msgs.RequestAck{
+							ClientId: clientID,
+							ReqNo:    0,
+							Digest:   []byte("request-digest"),
+						},
+						RequestData: []byte("request"),
+					},
+				},
+			}
+		}
+
+		It("rejects requests of other clients", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			Expect(node.SubmitRequest(ctx, 1, 0, []byte("request"), nil)).To(Succeed())
+			err := node.SubmitRequest(ctx, 2, 0, []byte("request"), nil)
+			Expect(err).To(Equal(mirbft.ErrClientUnauthorized))
+		})
+
+		It("drops requests of other clients when forwarded", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			Expect(node.Step(ctx, 1, forward(2))).To(Succeed())
+
+			// Stepped messages reach the state machine in order, so once
+			// the forward of the authorized client arrives, the one before
+			// it would have arrived too, had it not been dropped.
+			Expect(node.Step(ctx, 1, forward(1))).To(Succeed())
+			Eventually(counting.forwardedClients, testTimeout).Should(ContainElement(uint64(1)))
+			Expect(counting.forwardedClients()).To(Equal([]uint64{1}))
+			Expect(atomic.LoadInt64(&counting.forwards)).To(Equal(int64(1)))
+		})
+	})
+
+	When("preprocessing stalls", func() {
+		var (
+			stalling *stallingHasher
+		)
+
+		BeforeEach(func() {
+			config.MaxPendingPreprocess = 1
+			stalling = &stallingHasher{
+				release: make(chan struct{}),
+			}
+			hasher = stalling
+		})
+
+		It("rejects requests once the backlog is full, and admits them once it drains", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			// The request is picked up by the client worker, which stalls hashing it.
+			Expect(node.SubmitRequest(ctx, 0, 0, []byte("request"), nil)).To(Succeed())
+
+			err := node.SubmitRequest(ctx, 0, 1, []byte("request"), nil)
+			Expect(err).To(Equal(mirbft.ErrPreprocessBacklog))
+			err = node.SubmitRequest(ctx, 1, 0, []byte("request"), nil)
+			Expect(err).To(Equal(mirbft.ErrPreprocessBacklog))
+
+			close(stalling.release)
+			Eventually(func() error {
+				return node.SubmitRequest(ctx, 1, 0, []byte("request"), nil)
+			}, testTimeout).Should(Succeed())
+		})
+	})
+
+	When("forwarded requests are deduplicated", func() {
+		var (
+			counting *forwardCountingSM
+		)
+
+		BeforeEach(func() {
+			config.ForwardCacheSize = 2
+			counting = &forwardCountingSM{
+				DummySM: deploytest.NewDummySM(logger.ConsoleWarnLogger),
+			}
+			sm = counting
+		})
+
+		// barrier is a forward of another client, stepped after those under
+		// test, so that once it arrives, they have arrived too, or were dropped.
+		barrier := &msgs.Msg{
+			Type: &msgs.Msg_ForwardRequest{
+				ForwardRequest: &msgs.ForwardRequest{
+					RequestAck: &msgs.RequestAck{
+						ClientId: 1,
+						ReqNo:    0,
+						Digest:   []byte("barrier-digest"),
+					},
+					RequestData: []byte("barrier"),
+				},
+			},
+		}
+
+		It("preprocesses a request forwarded by several nodes only once", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			forward := &msgs.Msg{
+				Type: &msgs.Msg_ForwardRequest{
+					ForwardRequest: &msgs.ForwardRequest{
+						RequestAck: &msgs.RequestAck{
+							ClientId: 0,
+							ReqNo:    0,
+							Digest:   []byte("request-digest"),
+						},
+						RequestData: []byte("request"),
+					},
+				},
+			}
+			for _, source := range []uint64{1, 2, 3} {
+				Expect(node.Step(ctx, source, forward)).To(Succeed())
+			}
+
+			Expect(node.Step(ctx, 1, barrier)).To(Succeed())
+			Eventually(counting.forwardedClients, testTimeout).Should(ContainElement(uint64(1)))
+			Expect(counting.forwardedClients()).To(Equal([]uint64{0, 1}))
+		})
+
+		It("processes a forward carrying other data under the same ack", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			forward := func(data string) *msgs.Msg {
+				return &msgs.Msg{
+					Type: &msgs.Msg_ForwardRequest{
+						ForwardRequest: &msgs.ForwardRequest{
+							RequestAck: &msgs.RequestAck{
+								ClientId: 0,
+								ReqNo:    0,
+								Digest:   []byte("request-digest"),
+							},
+							RequestData: []byte(data),
+						},
+					},
+				}
+			}
+			Expect(node.Step(ctx, 1, forward("forged"))).To(Succeed())
+			Expect(node.Step(ctx, 2, forward("request"))).To(Succeed())
+
+			Eventually(func() int64 {
+				return atomic.LoadInt64(&counting.forwards)
+			}, testTimeout).Should(Equal(int64(2)))
+		})
+
+		It("steps each request forwarded together on its own", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			forward := func(reqNo uint64) *msgs.ForwardRequest {
+				return &msgs.ForwardRequest{
+					RequestAck: &msgs.RequestAck{
+						ClientId: 0,
+						ReqNo:    reqNo,
+						Digest:   []byte(fmt.Sprintf("request-digest-%d", reqNo)),
+					},
+					RequestData: []byte("request"),
+				}
+			}
+			Expect(node.Step(ctx, 1, &msgs.Msg{
+				Type: &msgs.Msg_ForwardRequests{
+					ForwardRequests: &msgs.ForwardRequests{
+						Requests: []*msgs.ForwardRequest{forward(0), forward(1), forward(0)},
+					},
+				},
+			})).To(Succeed())
+
+			Expect(node.Step(ctx, 1, barrier)).To(Succeed())
+			Eventually(counting.forwardedClients, testTimeout).Should(ContainElement(uint64(1)))
+			Expect(counting.forwardedClients()).To(Equal([]uint64{0, 0, 1}))
+		})
+	})
+
+	When("the clock is shared by the expiry of forwards and the preprocessing latency", func() {
+		var (
+			counting *forwardCountingSM
+			stalling *stallingHasher
+			mutex    sync.Mutex
+			now      time.Time
+		)
+
+		advance := func(d time.Duration) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			now = now.Add(d)
+		}
+
+		BeforeEach(func() {
+			now = time.Unix(0, 0)
+			config.ForwardCacheSize = 2
+			config.ForwardCacheTTL = 10 * time.Second
+			config.Clock = func() time.Time {
+				mutex.Lock()
+				defer mutex.Unlock()
+				return now
+			}
+			counting = &forwardCountingSM{
+				DummySM: deploytest.NewDummySM(logger.ConsoleWarnLogger),
+			}
+			sm = counting
+			stalling = &stallingHasher{
+				release: make(chan struct{}),
+			}
+			hasher = stalling
+		})
+
+		forward := func(clientID uint64) *msgs.Msg {
+			return &msgs.Msg{
+				Type: &msgs.Msg_ForwardRequest{
+					ForwardRequest: &msgs.ForwardRequest{
+						RequestAck: &msgs.RequestAck{
+							ClientId: clientID,
+							ReqNo:    0,
+							Digest:   []byte("request-digest"),
+						},
+						RequestData: []byte("request"),
+					},
+				},
+			}
+		}
+
+		It("measures the latency of preprocessing and expires forwards as the clock advances", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			// The request is picked up by the client worker, which stalls hashing it.
+			Expect(node.SubmitRequest(ctx, 0, 0, []byte("request"), nil)).To(Succeed())
+			advance(3 * time.Second)
+			close(stalling.release)
+			Eventually(node.PreprocessLatency, testTimeout).Should(Equal(mirbft.LatencyStats{
+				Count: 1,
+				Mean:  3 * time.Second,
+				Max:   3 * time.Second,
+			}))
+
+			Expect(node.Step(ctx, 1, forward(0))).To(Succeed())
+			advance(5 * time.Second)
+			Expect(node.Step(ctx, 2, forward(0))).To(Succeed())
+			advance(10 * time.Second)
+			Expect(node.Step(ctx, 3, forward(0))).To(Succeed())
+
+			// Stepped messages reach the state machine in order, so once
+			// the forward of client 1 arrives, those before it have too.
+			Expect(node.Step(ctx, 1, forward(1))).To(Succeed())
+			Eventually(counting.forwardedClients, testTimeout).Should(ContainElement(uint64(1)))
+			Expect(counting.forwardedClients()).To(Equal([]uint64{0, 0, 1}))
+		})
+	})
+
+	When("a request carries metadata", func() {
+		var (
+			recording *recordingHasher
+		)
+
+		BeforeEach(func() {
+			recording = &recordingHasher{}
+			hasher = recording
+		})
+
+		It("does not cover the metadata by the request digest", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			Expect(node.SubmitRequest(ctx, 0, 0, []byte("request"), []byte("trace-id"))).To(Succeed())
+
+			// The client ID and request number, then the request data.
+			Eventually(recording.recorded, testTimeout).Should(HaveLen(1))
+			Expect(recording.recorded()[0]).To(Equal(append(make([]byte, 16), []byte("request")...)))
+		})
+	})
+
+	When("the application submits events for the state machine", func() {
+		var (
+			recording *eventRecordingSM
+		)
+
+		BeforeEach(func() {
+			recording = &eventRecordingSM{
+				DummySM: deploytest.NewDummySM(logger.ConsoleWarnLogger),
+			}
+			sm = recording
+		})
+
+		It("returns the audit digest the application reports for the request handed to the state machine", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			type result struct {
+				digest []byte
+				err    error
+			}
+			resultC := make(chan result, 2)
+			for _, seqNo := range []uint64{5, 50} {
+				go func(seqNo uint64) {
+					digest, err := node.AuditDigest(ctx, seqNo)
+					resultC <- result{digest: digest, err: err}
+				}(seqNo)
+			}
+
+			// Were the events not routed, the node would fail processing
+			// them, and not stop with ErrStopped.
+			Eventually(recording.recorded, testTimeout).Should(HaveLen(2))
+			Expect(resultC).NotTo(Receive())
+
+			node.AuditDigestResult(5, []byte("digest"), nil)
+			Eventually(resultC, testTimeout).Should(Receive(Equal(result{digest: []byte("digest")})))
+
+			node.AuditDigestResult(50, nil, mirbft.ErrUncommittedSeqNo)
+			Eventually(resultC, testTimeout).Should(Receive(Equal(result{err: mirbft.ErrUncommittedSeqNo})))
+		})
+
+		It("hands a step down to the state machine", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			Expect(node.StepDown(ctx)).To(Succeed())
+
+			Eventually(recording.recorded, testTimeout).Should(HaveLen(1))
+			Expect(recording.recorded()[0].GetStepDown()).NotTo(BeNil())
+		})
+
+		It("hands a compaction to the state machine", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			Expect(node.Compact(ctx)).To(Succeed())
+
+			Eventually(recording.recorded, testTimeout).Should(HaveLen(1))
+			Expect(recording.recorded()[0].GetCompact()).NotTo(BeNil())
+		})
+
+		It("hands a checkpoint request to the state machine", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			Expect(node.RequestCheckpoint(ctx)).To(Succeed())
+
+			Eventually(recording.recorded, testTimeout).Should(HaveLen(1))
+			Expect(recording.recorded()[0].GetRequestCheckpoint()).NotTo(BeNil())
+		})
+
+		It("hands an imported client state to the state machine", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			data, err := proto.Marshal(&state.ClientState{
+				Client: &msgs.NetworkState_Client{Id: 3, Width: 100},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(node.ImportClientState(ctx, data)).To(Succeed())
+			Expect(node.ImportClientState(ctx, []byte("garbage"))).NotTo(Succeed())
+
+			Eventually(recording.recorded, testTimeout).Should(HaveLen(1))
+			Expect(recording.recorded()[0].GetImportClientState().ClientState.Client.Id).To(Equal(uint64(3)))
+		})
+	})
+})
@@ -30,6 +30,9 @@ func (ct *ClientTracker) Status() (s *status.StateMachine, err error) {
 	return nil, nil
 }
 
+// computeReqHash computes the digest identifying a request.  The metadata of
+// the request is not covered, so that it affects neither ordering nor
+// deduplication.
 func (ct *ClientTracker) computeReqHash(clientID uint64, reqNo uint64, data []byte) []byte {
 	// Initialize auxiliary data structures
 	h := ct.Hasher.New()
@@ -227,7 +227,8 @@ func (tr *TestReplica) Run(tickInterval time.Duration) (*status.StateMachine, er
 					context.Background(),
 					0,
 					uint64(i),
-					clientReq(0, uint64(i))); err != nil {
+					clientReq(0, uint64(i)),
+					nil); err != nil {
 
 					// TODO (Jason), failing on err causes flakes in the teardown,
 					// so just returning for now, we should address later
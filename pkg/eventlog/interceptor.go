@@ -78,6 +78,8 @@ func BufferSizeOpt(size int) RecorderOpt {
 	return bufferSizeOpt(size)
 }
 
+type traceOpt struct{}
+
 // Recorder is intended to be used as an imlementation of the
 // mirbft.EventInterceptor interface.  It receives state events,
 // serializes them, compresses them, and writes them to a stream.
@@ -86,6 +88,7 @@ type Recorder struct {
 	timeSource        func() int64
 	compressionLevel  int
 	retainRequestData bool
+	traceNodeID       bool
 	eventC            chan eventTime
 	doneC             chan struct{}
 	exitC             chan struct{}
@@ -118,6 +121,8 @@ func NewRecorder(nodeID uint64, dest io.Writer, opts ...RecorderOpt) *Recorder {
 			i.compressionLevel = int(v)
 		case bufferSizeOpt:
 			i.eventC = make(chan eventTime, v)
+		case traceOpt:
+			i.traceNodeID = true
 		}
 	}
 
@@ -126,6 +131,16 @@ func NewRecorder(nodeID uint64, dest io.Writer, opts ...RecorderOpt) *Recorder {
 	return i
 }
 
+// RecordTrace returns a Recorder writing a trace of the events applied to a
+// state machine to dest, for ReplayTrace to reproduce the actions of the
+// node.  The trace holds every event, including the messages the node steps
+// and the results of its actions, and the node is identified by the
+// Initialize event it begins with.  As for NewRecorder, the Recorder must be
+// stopped once the node has exited.
+func RecordTrace(dest io.Writer, opts ...RecorderOpt) *Recorder {
+	return NewRecorder(0, dest, append(opts, traceOpt{})...)
+}
+
 type eventTime struct {
 	event *state.Event
 	time  int64
@@ -180,6 +195,9 @@ func (i *Recorder) run(dest io.Writer) (exitErr error) {
 	defer gzWriter.Close()
 
 	write := func(eventTime eventTime) error {
+		if initialize, ok := eventTime.event.Type.(*state.Event_Initialize); ok && i.traceNodeID {
+			i.nodeID = initialize.Initialize.Id
+		}
 		return WriteRecordedEvent(gzWriter, &recording.Event{
 			NodeId:     i.nodeID,
 			Time:       eventTime.time,
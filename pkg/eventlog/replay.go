@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventlog
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/hyperledger-labs/mirbft/pkg/logger"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+	"github.com/hyperledger-labs/mirbft/pkg/statemachine"
+)
+
+// ReplayConfig configures the state machine ReplayTrace replays a trace into.
+type ReplayConfig struct {
+	// Logger is the logger of the replaying state machine.  If nil, errors
+	// are logged to the console.
+	Logger logger.Logger
+
+	// Hooks, if set, is invoked on the replaying state machine before any
+	// event is replayed, to set the hooks of the recorded node, such as a
+	// BatchOrderer.  The replay is only faithful if the hooks are the same.
+	Hooks func(sm *statemachine.StateMachine)
+}
+
+// ReplayTrace reads a trace written by RecordTrace from source and applies
+// each recorded event, in order, to a fresh state machine configured by cfg,
+// which may be nil.  Because the state machine is deterministic, the
+// returned actions, one list per recorded event, are exactly those the
+// recorded node produced.  The trace must hold the events of a single node.
+func ReplayTrace(source io.Reader, cfg *ReplayConfig) (actions []*statemachine.ActionList, err error) {
+	if cfg == nil {
+		cfg = &ReplayConfig{}
+	}
+
+	sm := &statemachine.StateMachine{
+		Logger: cfg.Logger,
+	}
+	if sm.Logger == nil {
+		sm.Logger = logger.ConsoleErrorLogger
+	}
+	if cfg.Hooks != nil {
+		cfg.Hooks(sm)
+	}
+
+	reader, err := NewReader(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodeID uint64
+	for {
+		event, err := reader.ReadEvent()
+		if err == io.EOF {
+			return actions, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(actions) == 0 {
+			nodeID = event.NodeId
+		} else if event.NodeId != nodeID {
+			return nil, errors.Errorf("event %d was recorded by node %d, but the trace is of node %d", len(actions), event.NodeId, nodeID)
+		}
+
+		result, err := safeApplyEvent(sm, event.StateEvent)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "could not replay event %d", len(actions))
+		}
+
+		actions = append(actions, result)
+	}
+}
+
+func safeApplyEvent(sm *statemachine.StateMachine, event *state.Event) (result *statemachine.ActionList, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if rErr, ok := r.(error); ok {
+				err = errors.WithMessage(rErr, "panic in state machine")
+			} else {
+				err = errors.Errorf("panic in state machine: %v", r)
+			}
+		}
+	}()
+
+	return sm.ApplyEvent(event), nil
+}
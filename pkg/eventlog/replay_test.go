@@ -0,0 +1,268 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventlog_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hyperledger-labs/mirbft/pkg/eventlog"
+	"github.com/hyperledger-labs/mirbft/pkg/logger"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/recording"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+	"github.com/hyperledger-labs/mirbft/pkg/statemachine"
+)
+
+// serializeActions marshals each action deterministically, so that actions
+// may be compared independent of any internal protobuf state.
+func serializeActions(actions *statemachine.ActionList) [][]byte {
+	result := [][]byte{}
+	iter := actions.Iterator()
+	for action := iter.Next(); action != nil; action = iter.Next() {
+		data, err := proto.MarshalOptions{Deterministic: true}.Marshal(action)
+		Expect(err).NotTo(HaveOccurred())
+		result = append(result, data)
+	}
+	return result
+}
+
+// initialEvents returns the events initializing node id of a fresh network
+// of four nodes tolerating a single fault.
+func initialEvents(id uint64) []*state.Event {
+	nodes := []uint64{0, 1, 2, 3}
+
+	return []*state.Event{
+		statemachine.EventInitialize(&state.EventInitialParameters{
+			Id:                   id,
+			BatchSize:            1,
+			HeartbeatTicks:       2,
+			SuspectTicks:         4,
+			NewEpochTimeoutTicks: 8,
+			BufferSize:           4 * 1024 * 1024,
+		}),
+		statemachine.EventLoadPersistedEntry(1, &msgs.Persistent{
+			Type: &msgs.Persistent_CEntry{
+				CEntry: &msgs.CEntry{
+					SeqNo:           0,
+					CheckpointValue: []byte("fake-initial-value"),
+					NetworkState: &msgs.NetworkState{
+						Config: &msgs.NetworkState_Config{
+							Nodes:              nodes,
+							F:                  1,
+							NumberOfBuckets:    4,
+							CheckpointInterval: 20,
+							MaxEpochLength:     200,
+						},
+						Clients: []*msgs.NetworkState_Client{
+							{
+								Id:    0,
+								Width: 100,
+							},
+						},
+					},
+				},
+			},
+		}),
+		statemachine.EventLoadPersistedEntry(2, &msgs.Persistent{
+			Type: &msgs.Persistent_FEntry{
+				FEntry: &msgs.FEntry{
+					EndsEpochConfig: &msgs.EpochConfig{
+						Number:  0,
+						Leaders: nodes,
+					},
+				},
+			},
+		}),
+		statemachine.EventCompleteInitialization(),
+	}
+}
+
+// recordedNetwork delivers the messages among a network of state machines,
+// and satisfies their hash and checkpoint actions, recording the trace of
+// each node along with the actions it produced.
+type recordedNetwork struct {
+	nodes     []*statemachine.StateMachine
+	recorders []*eventlog.Recorder
+	traces    []*bytes.Buffer
+	pending   []*statemachine.EventList
+	recorded  [][][][]byte
+	commits   []int
+}
+
+func newRecordedNetwork(nodeCount int) *recordedNetwork {
+	rn := &recordedNetwork{
+		nodes:     make([]*statemachine.StateMachine, nodeCount),
+		recorders: make([]*eventlog.Recorder, nodeCount),
+		traces:    make([]*bytes.Buffer, nodeCount),
+		pending:   make([]*statemachine.EventList, nodeCount),
+		recorded:  make([][][][]byte, nodeCount),
+		commits:   make([]int, nodeCount),
+	}
+
+	for i := range rn.nodes {
+		rn.nodes[i] = &statemachine.StateMachine{
+			Logger: logger.ConsoleErrorLogger,
+		}
+		rn.traces[i] = &bytes.Buffer{}
+		rn.recorders[i] = eventlog.RecordTrace(rn.traces[i])
+		rn.pending[i] = &statemachine.EventList{}
+		for _, event := range initialEvents(uint64(i)) {
+			rn.pending[i].PushBack(event)
+		}
+	}
+
+	return rn
+}
+
+// apply records event in the trace of node i and applies it, queueing the
+// events which result from its actions.
+func (rn *recordedNetwork) apply(i int, event *state.Event) {
+	Expect(rn.recorders[i].Intercept(event)).To(Succeed())
+	actions := rn.nodes[i].ApplyEvent(event)
+	rn.recorded[i] = append(rn.recorded[i], serializeActions(actions))
+
+	iter := actions.Iterator()
+	for action := iter.Next(); action != nil; action = iter.Next() {
+		switch t := action.Type.(type) {
+		case *state.Action_Send:
+			for _, target := range t.Send.Targets {
+				rn.pending[target].Step(uint64(i), t.Send.Msg)
+			}
+		case *state.Action_Hash:
+			h := sha256.New()
+			for _, data := range t.Hash.Data {
+				h.Write(data)
+			}
+			rn.pending[i].HashResult(h.Sum(nil), t.Hash.Origin)
+		case *state.Action_Checkpoint:
+			value := sha256.Sum256([]byte(fmt.Sprintf("checkpoint-%d", t.Checkpoint.SeqNo)))
+			rn.pending[i].PushBack(statemachine.EventCheckpointResult(value[:], nil, t.Checkpoint))
+		case *state.Action_Commit:
+			if len(t.Commit.Batch.Requests) > 0 {
+				rn.commits[i]++
+			}
+		}
+	}
+}
+
+// broadcast applies event to every node, then applies the resulting events
+// until the network is quiescent.
+func (rn *recordedNetwork) broadcast(event *state.Event) {
+	for i := range rn.nodes {
+		rn.pending[i].PushBack(event)
+	}
+	rn.settle()
+}
+
+func (rn *recordedNetwork) settle() {
+	for quiescent := false; !quiescent; {
+		quiescent = true
+		for i := range rn.nodes {
+			events := rn.pending[i]
+			rn.pending[i] = &statemachine.EventList{}
+			iter := events.Iterator()
+			for event := iter.Next(); event != nil; event = iter.Next() {
+				quiescent = false
+				rn.apply(i, event)
+			}
+		}
+	}
+}
+
+var _ = Describe("ReplayTrace", func() {
+	replay := func(trace *bytes.Buffer) [][][]byte {
+		replayedActions, err := eventlog.ReplayTrace(trace, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		replayed := [][][]byte{}
+		for _, actions := range replayedActions {
+			replayed = append(replayed, serializeActions(actions))
+		}
+		return replayed
+	}
+
+	It("reproduces the actions of the recorded node", func() {
+		events := initialEvents(0)
+		for i := 0; i < 10; i++ {
+			events = append(events, statemachine.EventTickElapsed(), statemachine.EventActionsReceived())
+		}
+		for _, source := range []uint64{1, 2} {
+			events = append(events, statemachine.EventStep(source, &msgs.Msg{
+				Type: &msgs.Msg_Suspect{
+					Suspect: &msgs.Suspect{
+						Epoch: 1,
+					},
+				},
+			}), statemachine.EventActionsReceived())
+		}
+
+		trace := &bytes.Buffer{}
+		recorder := eventlog.RecordTrace(trace)
+
+		sm := &statemachine.StateMachine{
+			Logger: logger.ConsoleErrorLogger,
+		}
+		recorded := [][][]byte{}
+		for _, event := range events {
+			Expect(recorder.Intercept(event)).To(Succeed())
+			recorded = append(recorded, serializeActions(sm.ApplyEvent(event)))
+		}
+		Expect(recorder.Stop()).To(Succeed())
+
+		replayed := replay(trace)
+		Expect(replayed).To(Equal(recorded))
+		Expect(replayed).To(ContainElement(Not(BeEmpty())))
+	})
+
+	It("reproduces the actions of each node of a network committing requests", func() {
+		rn := newRecordedNetwork(4)
+		rn.settle()
+		for i := 0; i < 10; i++ {
+			rn.broadcast(statemachine.EventTickElapsed())
+		}
+		for reqNo := uint64(0); reqNo < 5; reqNo++ {
+			rn.broadcast(statemachine.EventRequestPersisted(&msgs.RequestAck{
+				ClientId: 0,
+				ReqNo:    reqNo,
+				Digest:   []byte(fmt.Sprintf("request-digest-%d", reqNo)),
+			}))
+			rn.broadcast(statemachine.EventTickElapsed())
+		}
+		for i := 0; i < 10; i++ {
+			rn.broadcast(statemachine.EventTickElapsed())
+		}
+
+		for i, recorder := range rn.recorders {
+			Expect(recorder.Stop()).To(Succeed())
+			Expect(rn.commits[i]).To(Equal(5))
+			Expect(replay(rn.traces[i])).To(Equal(rn.recorded[i]))
+		}
+	})
+
+	It("rejects a trace interleaving the events of several nodes", func() {
+		trace := &bytes.Buffer{}
+		gzWriter := gzip.NewWriter(trace)
+		for _, nodeID := range []uint64{0, 1} {
+			Expect(eventlog.WriteRecordedEvent(gzWriter, &recording.Event{
+				NodeId:     nodeID,
+				StateEvent: initialEvents(nodeID)[0],
+			})).To(Succeed())
+		}
+		Expect(gzWriter.Close()).To(Succeed())
+
+		_, err := eventlog.ReplayTrace(trace, nil)
+		Expect(err).To(MatchError("event 1 was recorded by node 1, but the trace is of node 0"))
+	})
+})
@@ -133,6 +133,38 @@ type StateMachine interface {
 	Status() (s *status.StateMachine, err error)
 }
 
+// LeadershipViewer may optionally be implemented by a StateMachine whose
+// protocol has a notion of epoch and bucket leaders.
+type LeadershipViewer interface {
+	// LeadershipView returns the leaders of the active epoch, along with the
+	// leader of each bucket, as a single consistent snapshot.
+	LeadershipView() (*statemachine.LeadershipView, error)
+}
+
+// MisbehaviorCounter may optionally be implemented by a StateMachine which
+// flags misbehaving nodes.
+type MisbehaviorCounter interface {
+	// MisbehaviorCounts returns the number of misbehaviors flagged for each
+	// node since the state machine was initialized, or last reset for the node.
+	MisbehaviorCounts() map[uint64]int
+}
+
+// QuorumSourcer may optionally be implemented by a StateMachine which
+// retains the sources of the votes for the sequences between its watermarks.
+type QuorumSourcer interface {
+	// QuorumSources returns the ids of the nodes whose prepares and commits
+	// for sequence seqNo were counted towards its quorums, in ascending order.
+	QuorumSources(seqNo uint64) (prepares, commits []uint64, err error)
+}
+
+// CommitReader may optionally be implemented by a StateMachine which retains
+// the batches committed above its low watermark.
+type CommitReader interface {
+	// CommittedSince returns an iterator over the committed batches, in
+	// order, from seqNo through the highest sequence committed so far.
+	CommittedSince(seqNo uint64) (*statemachine.CommitIterator, error)
+}
+
 // EventInterceptor provides a way for a consumer to gain insight into
 // the internal operation of the state machine.  And is usually not
 // interesting outside of debugging or testing scenarios.  Note, this
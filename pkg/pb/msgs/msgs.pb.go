@@ -210,6 +210,7 @@ type Persistent struct {
 	//	*Persistent_ECEntry
 	//	*Persistent_TEntry
 	//	*Persistent_Suspect
+	//	*Persistent_EAEntry
 	Type isPersistent_Type `protobuf_oneof:"type"`
 }
 
@@ -308,6 +309,13 @@ func (x *Persistent) GetSuspect() *Suspect {
 	return nil
 }
 
+func (x *Persistent) GetEAEntry() *EAEntry {
+	if x, ok := x.GetType().(*Persistent_EAEntry); ok {
+		return x.EAEntry
+	}
+	return nil
+}
+
 type isPersistent_Type interface {
 	isPersistent_Type()
 }
@@ -341,7 +349,11 @@ type Persistent_TEntry struct {
 }
 
 type Persistent_Suspect struct {
-	Suspect *Suspect `protobuf:"bytes,8,opt,name=suspect,proto3,oneof"` // TODO, suspect_ready?
+	Suspect *Suspect `protobuf:"bytes,8,opt,name=suspect,proto3,oneof"`
+}
+
+type Persistent_EAEntry struct {
+	EAEntry *EAEntry `protobuf:"bytes,9,opt,name=e_a_entry,json=eAEntry,proto3,oneof"` // TODO, suspect_ready?
 }
 
 func (*Persistent_QEntry) isPersistent_Type() {}
@@ -360,6 +372,8 @@ func (*Persistent_TEntry) isPersistent_Type() {}
 
 func (*Persistent_Suspect) isPersistent_Type() {}
 
+func (*Persistent_EAEntry) isPersistent_Type() {}
+
 // NEntry indicates that a new set of sequences are being allocated, and
 // will be persisted immediately before log truncation occurs.
 type NEntry struct {
@@ -513,6 +527,90 @@ func (x *ECEntry) GetEpochNumber() uint64 {
 	return 0
 }
 
+// EAEntry records an acknowledgement of an EpochChange which was processed
+// during an epoch change, so that a node restarting mid epoch change may
+// resume collecting acknowledgements rather than starting over.  The
+// EpochChange itself is only stored with the first acknowledgement of it,
+// later ones reference it by its origin and digest.
+type EAEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source      uint64       `protobuf:"varint,1,opt,name=source,proto3" json:"source,omitempty"`
+	Origin      uint64       `protobuf:"varint,2,opt,name=origin,proto3" json:"origin,omitempty"`
+	EpochChange *EpochChange `protobuf:"bytes,3,opt,name=epoch_change,json=epochChange,proto3" json:"epoch_change,omitempty"`
+	Digest      []byte       `protobuf:"bytes,4,opt,name=digest,proto3" json:"digest,omitempty"`
+	Epoch       uint64       `protobuf:"varint,5,opt,name=epoch,proto3" json:"epoch,omitempty"`
+}
+
+func (x *EAEntry) Reset() {
+	*x = EAEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgs_msgs_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EAEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EAEntry) ProtoMessage() {}
+
+func (x *EAEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_msgs_msgs_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EAEntry.ProtoReflect.Descriptor instead.
+func (*EAEntry) Descriptor() ([]byte, []int) {
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EAEntry) GetSource() uint64 {
+	if x != nil {
+		return x.Source
+	}
+	return 0
+}
+
+func (x *EAEntry) GetOrigin() uint64 {
+	if x != nil {
+		return x.Origin
+	}
+	return 0
+}
+
+func (x *EAEntry) GetEpochChange() *EpochChange {
+	if x != nil {
+		return x.EpochChange
+	}
+	return nil
+}
+
+func (x *EAEntry) GetDigest() []byte {
+	if x != nil {
+		return x.Digest
+	}
+	return nil
+}
+
+func (x *EAEntry) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
 // TEntry indicates that a state transfer has been requested.
 type TEntry struct {
 	state         protoimpl.MessageState
@@ -526,7 +624,7 @@ type TEntry struct {
 func (x *TEntry) Reset() {
 	*x = TEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[6]
+		mi := &file_msgs_msgs_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -539,7 +637,7 @@ func (x *TEntry) String() string {
 func (*TEntry) ProtoMessage() {}
 
 func (x *TEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[6]
+	mi := &file_msgs_msgs_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -552,7 +650,7 @@ func (x *TEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TEntry.ProtoReflect.Descriptor instead.
 func (*TEntry) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{6}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *TEntry) GetSeqNo() uint64 {
@@ -585,7 +683,7 @@ type QEntry struct {
 func (x *QEntry) Reset() {
 	*x = QEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[7]
+		mi := &file_msgs_msgs_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -598,7 +696,7 @@ func (x *QEntry) String() string {
 func (*QEntry) ProtoMessage() {}
 
 func (x *QEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[7]
+	mi := &file_msgs_msgs_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -611,7 +709,7 @@ func (x *QEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QEntry.ProtoReflect.Descriptor instead.
 func (*QEntry) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{7}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *QEntry) GetSeqNo() uint64 {
@@ -649,7 +747,7 @@ type PEntry struct {
 func (x *PEntry) Reset() {
 	*x = PEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[8]
+		mi := &file_msgs_msgs_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -662,7 +760,7 @@ func (x *PEntry) String() string {
 func (*PEntry) ProtoMessage() {}
 
 func (x *PEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[8]
+	mi := &file_msgs_msgs_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -675,7 +773,7 @@ func (x *PEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PEntry.ProtoReflect.Descriptor instead.
 func (*PEntry) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{8}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *PEntry) GetSeqNo() uint64 {
@@ -706,7 +804,7 @@ type CEntry struct {
 func (x *CEntry) Reset() {
 	*x = CEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[9]
+		mi := &file_msgs_msgs_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -719,7 +817,7 @@ func (x *CEntry) String() string {
 func (*CEntry) ProtoMessage() {}
 
 func (x *CEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[9]
+	mi := &file_msgs_msgs_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -732,7 +830,7 @@ func (x *CEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CEntry.ProtoReflect.Descriptor instead.
 func (*CEntry) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{9}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CEntry) GetSeqNo() uint64 {
@@ -777,13 +875,15 @@ type Msg struct {
 	//	*Msg_FetchRequest
 	//	*Msg_ForwardRequest
 	//	*Msg_RequestAck
+	//	*Msg_Hello
+	//	*Msg_ForwardRequests
 	Type isMsg_Type `protobuf_oneof:"type"`
 }
 
 func (x *Msg) Reset() {
 	*x = Msg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[10]
+		mi := &file_msgs_msgs_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -796,7 +896,7 @@ func (x *Msg) String() string {
 func (*Msg) ProtoMessage() {}
 
 func (x *Msg) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[10]
+	mi := &file_msgs_msgs_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -809,7 +909,7 @@ func (x *Msg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Msg.ProtoReflect.Descriptor instead.
 func (*Msg) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{10}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{11}
 }
 
 func (m *Msg) GetType() isMsg_Type {
@@ -924,6 +1024,20 @@ func (x *Msg) GetRequestAck() *RequestAck {
 	return nil
 }
 
+func (x *Msg) GetHello() *Hello {
+	if x, ok := x.GetType().(*Msg_Hello); ok {
+		return x.Hello
+	}
+	return nil
+}
+
+func (x *Msg) GetForwardRequests() *ForwardRequests {
+	if x, ok := x.GetType().(*Msg_ForwardRequests); ok {
+		return x.ForwardRequests
+	}
+	return nil
+}
+
 type isMsg_Type interface {
 	isMsg_Type()
 }
@@ -988,6 +1102,14 @@ type Msg_RequestAck struct {
 	RequestAck *RequestAck `protobuf:"bytes,15,opt,name=request_ack,json=requestAck,proto3,oneof"`
 }
 
+type Msg_Hello struct {
+	Hello *Hello `protobuf:"bytes,16,opt,name=hello,proto3,oneof"`
+}
+
+type Msg_ForwardRequests struct {
+	ForwardRequests *ForwardRequests `protobuf:"bytes,17,opt,name=forward_requests,json=forwardRequests,proto3,oneof"`
+}
+
 func (*Msg_Preprepare) isMsg_Type() {}
 
 func (*Msg_Prepare) isMsg_Type() {}
@@ -1018,6 +1140,68 @@ func (*Msg_ForwardRequest) isMsg_Type() {}
 
 func (*Msg_RequestAck) isMsg_Type() {}
 
+func (*Msg_Hello) isMsg_Type() {}
+
+func (*Msg_ForwardRequests) isMsg_Type() {}
+
+// Hello is broadcast by a node once it has initialized.  It announces the
+// digest of the network configuration of the node's stable checkpoint, so
+// that nodes started from differing configurations notice on first contact.
+type Hello struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SeqNo        uint64 `protobuf:"varint,1,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"`
+	ConfigDigest []byte `protobuf:"bytes,2,opt,name=config_digest,json=configDigest,proto3" json:"config_digest,omitempty"`
+}
+
+func (x *Hello) Reset() {
+	*x = Hello{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgs_msgs_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Hello) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hello) ProtoMessage() {}
+
+func (x *Hello) ProtoReflect() protoreflect.Message {
+	mi := &file_msgs_msgs_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hello.ProtoReflect.Descriptor instead.
+func (*Hello) Descriptor() ([]byte, []int) {
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Hello) GetSeqNo() uint64 {
+	if x != nil {
+		return x.SeqNo
+	}
+	return 0
+}
+
+func (x *Hello) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
+	}
+	return nil
+}
+
 type FetchBatch struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1030,7 +1214,7 @@ type FetchBatch struct {
 func (x *FetchBatch) Reset() {
 	*x = FetchBatch{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[11]
+		mi := &file_msgs_msgs_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1043,7 +1227,7 @@ func (x *FetchBatch) String() string {
 func (*FetchBatch) ProtoMessage() {}
 
 func (x *FetchBatch) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[11]
+	mi := &file_msgs_msgs_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1056,7 +1240,7 @@ func (x *FetchBatch) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FetchBatch.ProtoReflect.Descriptor instead.
 func (*FetchBatch) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{11}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *FetchBatch) GetSeqNo() uint64 {
@@ -1086,7 +1270,7 @@ type ForwardBatch struct {
 func (x *ForwardBatch) Reset() {
 	*x = ForwardBatch{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[12]
+		mi := &file_msgs_msgs_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1099,7 +1283,7 @@ func (x *ForwardBatch) String() string {
 func (*ForwardBatch) ProtoMessage() {}
 
 func (x *ForwardBatch) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[12]
+	mi := &file_msgs_msgs_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1112,7 +1296,7 @@ func (x *ForwardBatch) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ForwardBatch.ProtoReflect.Descriptor instead.
 func (*ForwardBatch) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{12}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ForwardBatch) GetSeqNo() uint64 {
@@ -1148,7 +1332,7 @@ type ForwardRequest struct {
 func (x *ForwardRequest) Reset() {
 	*x = ForwardRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[13]
+		mi := &file_msgs_msgs_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1161,7 +1345,7 @@ func (x *ForwardRequest) String() string {
 func (*ForwardRequest) ProtoMessage() {}
 
 func (x *ForwardRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[13]
+	mi := &file_msgs_msgs_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1174,7 +1358,7 @@ func (x *ForwardRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ForwardRequest.ProtoReflect.Descriptor instead.
 func (*ForwardRequest) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{13}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ForwardRequest) GetRequestAck() *RequestAck {
@@ -1191,6 +1375,56 @@ func (x *ForwardRequest) GetRequestData() []byte {
 	return nil
 }
 
+// ForwardRequests carries several forwarded requests in a single message,
+// see state.ActionForward.  It is handled as if each of its requests had
+// been received in a ForwardRequest of its own.
+type ForwardRequests struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Requests []*ForwardRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+}
+
+func (x *ForwardRequests) Reset() {
+	*x = ForwardRequests{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgs_msgs_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForwardRequests) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardRequests) ProtoMessage() {}
+
+func (x *ForwardRequests) ProtoReflect() protoreflect.Message {
+	mi := &file_msgs_msgs_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardRequests.ProtoReflect.Descriptor instead.
+func (*ForwardRequests) Descriptor() ([]byte, []int) {
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ForwardRequests) GetRequests() []*ForwardRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
 type Request struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1199,12 +1433,17 @@ type Request struct {
 	ClientId uint64 `protobuf:"varint,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
 	ReqNo    uint64 `protobuf:"varint,2,opt,name=req_no,json=reqNo,proto3" json:"req_no,omitempty"`
 	Data     []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// metadata is opaque application data, such as a trace ID, which is
+	// stored along with the request data, for the application to read back
+	// once the request commits.  It is not covered by the request digest, so
+	// it affects neither ordering nor deduplication.
+	Metadata []byte `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
 }
 
 func (x *Request) Reset() {
 	*x = Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[14]
+		mi := &file_msgs_msgs_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1217,7 +1456,7 @@ func (x *Request) String() string {
 func (*Request) ProtoMessage() {}
 
 func (x *Request) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[14]
+	mi := &file_msgs_msgs_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1230,7 +1469,7 @@ func (x *Request) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Request.ProtoReflect.Descriptor instead.
 func (*Request) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{14}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *Request) GetClientId() uint64 {
@@ -1254,6 +1493,13 @@ func (x *Request) GetData() []byte {
 	return nil
 }
 
+func (x *Request) GetMetadata() []byte {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
 type RequestRef struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1267,7 +1513,7 @@ type RequestRef struct {
 func (x *RequestRef) Reset() {
 	*x = RequestRef{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[15]
+		mi := &file_msgs_msgs_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1280,7 +1526,7 @@ func (x *RequestRef) String() string {
 func (*RequestRef) ProtoMessage() {}
 
 func (x *RequestRef) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[15]
+	mi := &file_msgs_msgs_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1293,7 +1539,7 @@ func (x *RequestRef) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestRef.ProtoReflect.Descriptor instead.
 func (*RequestRef) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{15}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *RequestRef) GetClientId() uint64 {
@@ -1330,7 +1576,7 @@ type RequestAck struct {
 func (x *RequestAck) Reset() {
 	*x = RequestAck{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[16]
+		mi := &file_msgs_msgs_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1343,7 +1589,7 @@ func (x *RequestAck) String() string {
 func (*RequestAck) ProtoMessage() {}
 
 func (x *RequestAck) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[16]
+	mi := &file_msgs_msgs_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1356,7 +1602,7 @@ func (x *RequestAck) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestAck.ProtoReflect.Descriptor instead.
 func (*RequestAck) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{16}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *RequestAck) GetClientId() uint64 {
@@ -1388,12 +1634,15 @@ type Preprepare struct {
 	SeqNo uint64        `protobuf:"varint,1,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"`
 	Epoch uint64        `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
 	Batch []*RequestAck `protobuf:"bytes,3,rep,name=batch,proto3" json:"batch,omitempty"`
+	// CompressedBatch, if set, holds the batch in place of the batch field,
+	// compressed with the codec named by the network configuration.
+	CompressedBatch []byte `protobuf:"bytes,4,opt,name=compressed_batch,json=compressedBatch,proto3" json:"compressed_batch,omitempty"`
 }
 
 func (x *Preprepare) Reset() {
 	*x = Preprepare{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[17]
+		mi := &file_msgs_msgs_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1406,7 +1655,7 @@ func (x *Preprepare) String() string {
 func (*Preprepare) ProtoMessage() {}
 
 func (x *Preprepare) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[17]
+	mi := &file_msgs_msgs_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1419,7 +1668,7 @@ func (x *Preprepare) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Preprepare.ProtoReflect.Descriptor instead.
 func (*Preprepare) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{17}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *Preprepare) GetSeqNo() uint64 {
@@ -1443,6 +1692,13 @@ func (x *Preprepare) GetBatch() []*RequestAck {
 	return nil
 }
 
+func (x *Preprepare) GetCompressedBatch() []byte {
+	if x != nil {
+		return x.CompressedBatch
+	}
+	return nil
+}
+
 type Prepare struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1456,7 +1712,7 @@ type Prepare struct {
 func (x *Prepare) Reset() {
 	*x = Prepare{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[18]
+		mi := &file_msgs_msgs_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1469,7 +1725,7 @@ func (x *Prepare) String() string {
 func (*Prepare) ProtoMessage() {}
 
 func (x *Prepare) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[18]
+	mi := &file_msgs_msgs_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1482,7 +1738,7 @@ func (x *Prepare) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Prepare.ProtoReflect.Descriptor instead.
 func (*Prepare) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{18}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *Prepare) GetSeqNo() uint64 {
@@ -1519,7 +1775,7 @@ type Commit struct {
 func (x *Commit) Reset() {
 	*x = Commit{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[19]
+		mi := &file_msgs_msgs_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1532,7 +1788,7 @@ func (x *Commit) String() string {
 func (*Commit) ProtoMessage() {}
 
 func (x *Commit) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[19]
+	mi := &file_msgs_msgs_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1545,7 +1801,7 @@ func (x *Commit) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Commit.ProtoReflect.Descriptor instead.
 func (*Commit) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{19}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *Commit) GetSeqNo() uint64 {
@@ -1581,7 +1837,7 @@ type Checkpoint struct {
 func (x *Checkpoint) Reset() {
 	*x = Checkpoint{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[20]
+		mi := &file_msgs_msgs_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1594,7 +1850,7 @@ func (x *Checkpoint) String() string {
 func (*Checkpoint) ProtoMessage() {}
 
 func (x *Checkpoint) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[20]
+	mi := &file_msgs_msgs_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1607,7 +1863,7 @@ func (x *Checkpoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Checkpoint.ProtoReflect.Descriptor instead.
 func (*Checkpoint) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{20}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *Checkpoint) GetSeqNo() uint64 {
@@ -1630,12 +1886,16 @@ type Suspect struct {
 	unknownFields protoimpl.UnknownFields
 
 	Epoch uint64 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// step_down is set when a leader of the epoch suspects it voluntarily,
+	// having completed its in-flight sequences, so that the other nodes may
+	// join the suspicion rather than waiting to time out.
+	StepDown bool `protobuf:"varint,2,opt,name=step_down,json=stepDown,proto3" json:"step_down,omitempty"`
 }
 
 func (x *Suspect) Reset() {
 	*x = Suspect{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[21]
+		mi := &file_msgs_msgs_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1648,7 +1908,7 @@ func (x *Suspect) String() string {
 func (*Suspect) ProtoMessage() {}
 
 func (x *Suspect) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[21]
+	mi := &file_msgs_msgs_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1661,7 +1921,7 @@ func (x *Suspect) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Suspect.ProtoReflect.Descriptor instead.
 func (*Suspect) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{21}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *Suspect) GetEpoch() uint64 {
@@ -1671,6 +1931,13 @@ func (x *Suspect) GetEpoch() uint64 {
 	return 0
 }
 
+func (x *Suspect) GetStepDown() bool {
+	if x != nil {
+		return x.StepDown
+	}
+	return false
+}
+
 // EpochChange messages are used to implement the classical PBFT view-change
 // protocol, (very) slightly modified to adapt to Mir.  The assorted sets
 // are encoded as repeated fields, rather than as maps for ease of serialization
@@ -1691,12 +1958,17 @@ type EpochChange struct {
 	// q_set contains the entries for the Q-set as defined by the classical
 	// PBFT view-change protocol.
 	QSet []*EpochChange_SetEntry `protobuf:"bytes,4,rep,name=q_set,json=qSet,proto3" json:"q_set,omitempty"`
+	// genesis is set only in epoch changes for the first epoch after the
+	// genesis epoch, to the configuration of the genesis epoch the sender was
+	// bootstrapped with, so that the nodes of a new network may verify they
+	// were all bootstrapped identically.
+	Genesis *EpochConfig `protobuf:"bytes,5,opt,name=genesis,proto3" json:"genesis,omitempty"`
 }
 
 func (x *EpochChange) Reset() {
 	*x = EpochChange{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[22]
+		mi := &file_msgs_msgs_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1709,7 +1981,7 @@ func (x *EpochChange) String() string {
 func (*EpochChange) ProtoMessage() {}
 
 func (x *EpochChange) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[22]
+	mi := &file_msgs_msgs_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1722,7 +1994,7 @@ func (x *EpochChange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EpochChange.ProtoReflect.Descriptor instead.
 func (*EpochChange) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{22}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *EpochChange) GetNewEpoch() uint64 {
@@ -1753,6 +2025,13 @@ func (x *EpochChange) GetQSet() []*EpochChange_SetEntry {
 	return nil
 }
 
+func (x *EpochChange) GetGenesis() *EpochConfig {
+	if x != nil {
+		return x.Genesis
+	}
+	return nil
+}
+
 // EpochChangeAck messages are broadcast in response to receiving a valid epoch change
 // from a replica.  Replicas collect these epoch change ack messages, and when there are 2f+1
 // such messages begin to count that epoch change as appropriately broadcast for purposes of
@@ -1773,7 +2052,7 @@ type EpochChangeAck struct {
 func (x *EpochChangeAck) Reset() {
 	*x = EpochChangeAck{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[23]
+		mi := &file_msgs_msgs_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1786,7 +2065,7 @@ func (x *EpochChangeAck) String() string {
 func (*EpochChangeAck) ProtoMessage() {}
 
 func (x *EpochChangeAck) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[23]
+	mi := &file_msgs_msgs_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1799,7 +2078,7 @@ func (x *EpochChangeAck) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EpochChangeAck.ProtoReflect.Descriptor instead.
 func (*EpochChangeAck) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{23}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *EpochChangeAck) GetOriginator() uint64 {
@@ -1830,7 +2109,7 @@ type EpochConfig struct {
 func (x *EpochConfig) Reset() {
 	*x = EpochConfig{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[24]
+		mi := &file_msgs_msgs_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1843,7 +2122,7 @@ func (x *EpochConfig) String() string {
 func (*EpochConfig) ProtoMessage() {}
 
 func (x *EpochConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[24]
+	mi := &file_msgs_msgs_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1856,7 +2135,7 @@ func (x *EpochConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EpochConfig.ProtoReflect.Descriptor instead.
 func (*EpochConfig) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{24}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *EpochConfig) GetNumber() uint64 {
@@ -1897,7 +2176,7 @@ type NewEpochConfig struct {
 func (x *NewEpochConfig) Reset() {
 	*x = NewEpochConfig{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[25]
+		mi := &file_msgs_msgs_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1910,7 +2189,7 @@ func (x *NewEpochConfig) String() string {
 func (*NewEpochConfig) ProtoMessage() {}
 
 func (x *NewEpochConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[25]
+	mi := &file_msgs_msgs_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1923,7 +2202,7 @@ func (x *NewEpochConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewEpochConfig.ProtoReflect.Descriptor instead.
 func (*NewEpochConfig) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{25}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *NewEpochConfig) GetConfig() *EpochConfig {
@@ -1971,7 +2250,7 @@ type NewEpoch struct {
 func (x *NewEpoch) Reset() {
 	*x = NewEpoch{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[26]
+		mi := &file_msgs_msgs_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1984,7 +2263,7 @@ func (x *NewEpoch) String() string {
 func (*NewEpoch) ProtoMessage() {}
 
 func (x *NewEpoch) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[26]
+	mi := &file_msgs_msgs_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1997,7 +2276,7 @@ func (x *NewEpoch) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewEpoch.ProtoReflect.Descriptor instead.
 func (*NewEpoch) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{26}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *NewEpoch) GetNewConfig() *NewEpochConfig {
@@ -2068,12 +2347,58 @@ type NetworkState_Config struct {
 	// 16 | 7 | 7 | 6 | 6 | 5 | 5 |
 	// 17 | 8 | 7 | 7 | 6 | 6 | 5 |
 	F int32 `protobuf:"varint,5,opt,name=f,proto3" json:"f,omitempty"`
+	// ConfigClients are the IDs of clients whose requests are configuration
+	// changes rather than data.  Such requests are ordered like any other
+	// request, but are surfaced distinctly when committed, so that the
+	// application may apply them at exactly the sequence they commit at.
+	ConfigClients []uint64 `protobuf:"varint,6,rep,packed,name=config_clients,json=configClients,proto3" json:"config_clients,omitempty"`
+	// BatchCompression names the codec with which the batches of preprepares
+	// are compressed on the wire.  It is empty if batches are sent uncompressed.
+	// Every node must be able to decode it, so it is agreed on as part of
+	// the network configuration, rather than configured per node.
+	BatchCompression string `protobuf:"bytes,7,opt,name=batch_compression,json=batchCompression,proto3" json:"batch_compression,omitempty"`
+	// MaxBucketsPerNode, if non-zero, is the maximum number of buckets a
+	// single node may lead in an epoch.  The number of nodes times this
+	// limit must cover the number of buckets.  Should the leaders of an
+	// epoch be too few to honor it, the buckets beyond their combined
+	// limit stay with the leaders they were first assigned to.
+	MaxBucketsPerNode uint32 `protobuf:"varint,8,opt,name=max_buckets_per_node,json=maxBucketsPerNode,proto3" json:"max_buckets_per_node,omitempty"`
+	// BarrierClients are the IDs of clients whose requests are barriers.
+	// Once a batch containing a barrier is delivered, the batches which
+	// commit after it are withheld until the application acknowledges
+	// applying every batch through the barrier, for instance so that a
+	// schema migration completes before any later request is applied.
+	BarrierClients []uint64 `protobuf:"varint,9,rep,packed,name=barrier_clients,json=barrierClients,proto3" json:"barrier_clients,omitempty"`
+	// Learners are the IDs of nodes being added to the network, which do
+	// not vote, lead buckets, or count towards any quorum.  The checkpoint
+	// messages of the network are sent to them as well, so that they may
+	// catch up by state transfer, after which they report the checkpoint
+	// they caught up to, see the learners of the state machine status.
+	// A node is added in two reconfigurations, first as a learner, then,
+	// once it reported catching up, by moving it to the nodes, so that the
+	// quorums only grow once the node can participate.  A configuration
+	// adding a node which was not a learner is rejected.
+	Learners []uint64 `protobuf:"varint,10,rep,packed,name=learners,proto3" json:"learners,omitempty"`
+	// CheckpointClients are the IDs of clients whose requests are
+	// coordinated checkpoint requests, for instance for backups.  Once a
+	// batch containing one is delivered, every node asks its application
+	// for a checkpoint at the sequence of that batch, which is therefore
+	// the same on every node, and the request acts as a barrier, see
+	// BarrierClients, so that no later batch is applied first.
+	CheckpointClients []uint64 `protobuf:"varint,11,rep,packed,name=checkpoint_clients,json=checkpointClients,proto3" json:"checkpoint_clients,omitempty"`
+	// BucketLookahead is the number of checkpoint intervals beyond the
+	// commit window into which each bucket may preprepare independently
+	// of the others, so that a bucket is not held up by one which lags.
+	// Sequences beyond the commit window neither prepare nor commit until
+	// the checkpoints move the window over them.  It is zero if buckets
+	// may only preprepare within the commit window.
+	BucketLookahead uint32 `protobuf:"varint,12,opt,name=bucket_lookahead,json=bucketLookahead,proto3" json:"bucket_lookahead,omitempty"`
 }
 
 func (x *NetworkState_Config) Reset() {
 	*x = NetworkState_Config{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[27]
+		mi := &file_msgs_msgs_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2086,7 +2411,7 @@ func (x *NetworkState_Config) String() string {
 func (*NetworkState_Config) ProtoMessage() {}
 
 func (x *NetworkState_Config) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[27]
+	mi := &file_msgs_msgs_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2137,6 +2462,55 @@ func (x *NetworkState_Config) GetF() int32 {
 	return 0
 }
 
+func (x *NetworkState_Config) GetConfigClients() []uint64 {
+	if x != nil {
+		return x.ConfigClients
+	}
+	return nil
+}
+
+func (x *NetworkState_Config) GetBatchCompression() string {
+	if x != nil {
+		return x.BatchCompression
+	}
+	return ""
+}
+
+func (x *NetworkState_Config) GetMaxBucketsPerNode() uint32 {
+	if x != nil {
+		return x.MaxBucketsPerNode
+	}
+	return 0
+}
+
+func (x *NetworkState_Config) GetBarrierClients() []uint64 {
+	if x != nil {
+		return x.BarrierClients
+	}
+	return nil
+}
+
+func (x *NetworkState_Config) GetLearners() []uint64 {
+	if x != nil {
+		return x.Learners
+	}
+	return nil
+}
+
+func (x *NetworkState_Config) GetCheckpointClients() []uint64 {
+	if x != nil {
+		return x.CheckpointClients
+	}
+	return nil
+}
+
+func (x *NetworkState_Config) GetBucketLookahead() uint32 {
+	if x != nil {
+		return x.BucketLookahead
+	}
+	return 0
+}
+
 type NetworkState_Client struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2169,7 +2543,7 @@ type NetworkState_Client struct {
 func (x *NetworkState_Client) Reset() {
 	*x = NetworkState_Client{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[28]
+		mi := &file_msgs_msgs_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2182,7 +2556,7 @@ func (x *NetworkState_Client) String() string {
 func (*NetworkState_Client) ProtoMessage() {}
 
 func (x *NetworkState_Client) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[28]
+	mi := &file_msgs_msgs_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2245,7 +2619,7 @@ type Reconfiguration_NewClient struct {
 func (x *Reconfiguration_NewClient) Reset() {
 	*x = Reconfiguration_NewClient{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[29]
+		mi := &file_msgs_msgs_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2258,7 +2632,7 @@ func (x *Reconfiguration_NewClient) String() string {
 func (*Reconfiguration_NewClient) ProtoMessage() {}
 
 func (x *Reconfiguration_NewClient) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[29]
+	mi := &file_msgs_msgs_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2288,6 +2662,9 @@ func (x *Reconfiguration_NewClient) GetWidth() uint32 {
 	return 0
 }
 
+// SetEntry references a batch by its digest alone, never embedding the
+// batch itself.  A node lacking a batch selected for the new epoch
+// fetches it from the nodes whose q_set references it.
 type EpochChange_SetEntry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2301,7 +2678,7 @@ type EpochChange_SetEntry struct {
 func (x *EpochChange_SetEntry) Reset() {
 	*x = EpochChange_SetEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[30]
+		mi := &file_msgs_msgs_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2314,7 +2691,7 @@ func (x *EpochChange_SetEntry) String() string {
 func (*EpochChange_SetEntry) ProtoMessage() {}
 
 func (x *EpochChange_SetEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[30]
+	mi := &file_msgs_msgs_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2327,7 +2704,7 @@ func (x *EpochChange_SetEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EpochChange_SetEntry.ProtoReflect.Descriptor instead.
 func (*EpochChange_SetEntry) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{22, 0}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{25, 0}
 }
 
 func (x *EpochChange_SetEntry) GetEpoch() uint64 {
@@ -2363,7 +2740,7 @@ type NewEpoch_RemoteEpochChange struct {
 func (x *NewEpoch_RemoteEpochChange) Reset() {
 	*x = NewEpoch_RemoteEpochChange{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msgs_msgs_proto_msgTypes[31]
+		mi := &file_msgs_msgs_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2376,7 +2753,7 @@ func (x *NewEpoch_RemoteEpochChange) String() string {
 func (*NewEpoch_RemoteEpochChange) ProtoMessage() {}
 
 func (x *NewEpoch_RemoteEpochChange) ProtoReflect() protoreflect.Message {
-	mi := &file_msgs_msgs_proto_msgTypes[31]
+	mi := &file_msgs_msgs_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2389,7 +2766,7 @@ func (x *NewEpoch_RemoteEpochChange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewEpoch_RemoteEpochChange.ProtoReflect.Descriptor instead.
 func (*NewEpoch_RemoteEpochChange) Descriptor() ([]byte, []int) {
-	return file_msgs_msgs_proto_rawDescGZIP(), []int{26, 0}
+	return file_msgs_msgs_proto_rawDescGZIP(), []int{29, 0}
 }
 
 func (x *NewEpoch_RemoteEpochChange) GetNodeId() uint64 {
@@ -2410,7 +2787,7 @@ var File_msgs_msgs_proto protoreflect.FileDescriptor
 
 var file_msgs_msgs_proto_rawDesc = []byte{
 	0x0a, 0x0f, 0x6d, 0x73, 0x67, 0x73, 0x2f, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x12, 0x04, 0x6d, 0x73, 0x67, 0x73, 0x22, 0xe4, 0x04, 0x0a, 0x0c, 0x4e, 0x65, 0x74, 0x77,
+	0x6f, 0x12, 0x04, 0x6d, 0x73, 0x67, 0x73, 0x22, 0x88, 0x07, 0x0a, 0x0c, 0x4e, 0x65, 0x74, 0x77,
 	0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66,
 	0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e,
 	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x43, 0x6f, 0x6e,
@@ -2425,7 +2802,7 @@ var file_msgs_msgs_proto_rawDesc = []byte{
 	0x6e, 0x67, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
 	0x6e, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
 	0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x1a, 0xb3, 0x01, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x1a, 0xd7, 0x03, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69,
 	0x67, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04,
 	0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x13, 0x63, 0x68, 0x65, 0x63, 0x6b,
 	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02,
@@ -2436,166 +2813,214 @@ var file_msgs_msgs_proto_rawDesc = []byte{
 	0x74, 0x68, 0x12, 0x2a, 0x0a, 0x11, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f, 0x66, 0x5f,
 	0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x6e,
 	0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x0c,
-	0x0a, 0x01, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x66, 0x1a, 0xbf, 0x01, 0x0a,
-	0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x43, 0x0a,
-	0x1e, 0x77, 0x69, 0x64, 0x74, 0x68, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x5f,
-	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x1b, 0x77, 0x69, 0x64, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x73,
-	0x75, 0x6d, 0x65, 0x64, 0x4c, 0x61, 0x73, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69,
-	0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x6f, 0x77, 0x5f, 0x77, 0x61, 0x74, 0x65, 0x72, 0x6d,
-	0x61, 0x72, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x6f, 0x77, 0x57, 0x61,
-	0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x74, 0x65, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0xf1,
-	0x01, 0x0a, 0x0f, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x40, 0x0a, 0x0a, 0x6e, 0x65, 0x77, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x52, 0x65,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4e, 0x65,
-	0x77, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x09, 0x6e, 0x65, 0x77, 0x43, 0x6c,
-	0x69, 0x65, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x63,
-	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x0c, 0x72,
-	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x3a, 0x0a, 0x0a, 0x6e,
-	0x65, 0x77, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x19, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74,
-	0x61, 0x74, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x48, 0x00, 0x52, 0x09, 0x6e, 0x65,
-	0x77, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x1a, 0x31, 0x0a, 0x09, 0x4e, 0x65, 0x77, 0x43, 0x6c,
-	0x69, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79,
-	0x70, 0x65, 0x22, 0xe2, 0x02, 0x0a, 0x0a, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e,
-	0x74, 0x12, 0x27, 0x0a, 0x07, 0x71, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x51, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x48, 0x00, 0x52, 0x06, 0x71, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x27, 0x0a, 0x07, 0x70, 0x5f,
-	0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x73,
-	0x67, 0x73, 0x2e, 0x50, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x06, 0x70, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x27, 0x0a, 0x07, 0x63, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x43, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x48, 0x00, 0x52, 0x06, 0x63, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x27, 0x0a, 0x07,
-	0x6e, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
-	0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x06, 0x6e,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x27, 0x0a, 0x07, 0x66, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x46, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x06, 0x66, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x2b,
-	0x0a, 0x09, 0x65, 0x5f, 0x63, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0d, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x45, 0x43, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x48, 0x00, 0x52, 0x07, 0x65, 0x43, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x27, 0x0a, 0x07, 0x74,
-	0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d,
-	0x73, 0x67, 0x73, 0x2e, 0x54, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x06, 0x74, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x12, 0x29, 0x0a, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x53, 0x75, 0x73,
-	0x70, 0x65, 0x63, 0x74, 0x48, 0x00, 0x52, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x42,
-	0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x55, 0x0a, 0x06, 0x4e, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x34, 0x0a, 0x0c, 0x65, 0x70, 0x6f, 0x63,
-	0x68, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
-	0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x52, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x47,
-	0x0a, 0x06, 0x46, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x3d, 0x0a, 0x11, 0x65, 0x6e, 0x64, 0x73,
-	0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0f, 0x65, 0x6e, 0x64, 0x73, 0x45, 0x70, 0x6f, 0x63,
-	0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x2c, 0x0a, 0x07, 0x45, 0x43, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x4e,
-	0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x35, 0x0a, 0x06, 0x54, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x65, 0x0a, 0x06,
-	0x51, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x16, 0x0a,
-	0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64,
-	0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x73, 0x22, 0x37, 0x0a, 0x06, 0x50, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x15, 0x0a,
-	0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73,
-	0x65, 0x71, 0x4e, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x83, 0x01, 0x0a,
-	0x06, 0x43, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e,
-	0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x29,
-	0x0a, 0x10, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70,
-	0x6f, 0x69, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x37, 0x0a, 0x0d, 0x6e, 0x65, 0x74,
-	0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x12, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53,
-	0x74, 0x61, 0x74, 0x65, 0x52, 0x0c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x22, 0xb9, 0x06, 0x0a, 0x03, 0x4d, 0x73, 0x67, 0x12, 0x32, 0x0a, 0x0a, 0x70, 0x72,
-	0x65, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
-	0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x50, 0x72, 0x65, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65,
-	0x48, 0x00, 0x52, 0x0a, 0x70, 0x72, 0x65, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x29,
-	0x0a, 0x07, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0d, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x48, 0x00,
-	0x52, 0x07, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x73, 0x67, 0x73,
-	0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x48, 0x00, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x12, 0x32, 0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x43, 0x68, 0x65,
-	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b,
-	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x53, 0x75,
-	0x73, 0x70, 0x65, 0x63, 0x74, 0x48, 0x00, 0x52, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74,
-	0x12, 0x36, 0x0a, 0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x45, 0x70,
-	0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x65, 0x70, 0x6f,
-	0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x40, 0x0a, 0x10, 0x65, 0x70, 0x6f, 0x63,
-	0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x61, 0x63, 0x6b, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43,
-	0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x0e, 0x65, 0x70, 0x6f, 0x63,
-	0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x63, 0x6b, 0x12, 0x2d, 0x0a, 0x09, 0x6e, 0x65,
-	0x77, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
-	0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x48, 0x00, 0x52,
-	0x08, 0x6e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x3c, 0x0a, 0x0e, 0x6e, 0x65, 0x77,
-	0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x65, 0x63, 0x68, 0x6f, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0a, 0x01, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x66, 0x12, 0x25, 0x0a, 0x0e,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x04, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x63, 0x6f, 0x6d,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
+	0x62, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x2f, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x5f,
+	0x70, 0x65, 0x72, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11,
+	0x6d, 0x61, 0x78, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x50, 0x65, 0x72, 0x4e, 0x6f, 0x64,
+	0x65, 0x12, 0x27, 0x0a, 0x0f, 0x62, 0x61, 0x72, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x04, 0x52, 0x0e, 0x62, 0x61, 0x72, 0x72,
+	0x69, 0x65, 0x72, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x65,
+	0x61, 0x72, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x04, 0x52, 0x08, 0x6c, 0x65,
+	0x61, 0x72, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x03,
+	0x28, 0x04, 0x52, 0x11, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x5f,
+	0x6c, 0x6f, 0x6f, 0x6b, 0x61, 0x68, 0x65, 0x61, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0f, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x4c, 0x6f, 0x6f, 0x6b, 0x61, 0x68, 0x65, 0x61, 0x64,
+	0x1a, 0xbf, 0x01, 0x0a, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x77,
+	0x69, 0x64, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74,
+	0x68, 0x12, 0x43, 0x0a, 0x1e, 0x77, 0x69, 0x64, 0x74, 0x68, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x75,
+	0x6d, 0x65, 0x64, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x1b, 0x77, 0x69, 0x64, 0x74, 0x68,
+	0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x4c, 0x61, 0x73, 0x74, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x6f, 0x77, 0x5f, 0x77, 0x61,
+	0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c,
+	0x6f, 0x77, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x25, 0x0a, 0x0e, 0x63,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x4d, 0x61,
+	0x73, 0x6b, 0x22, 0xf1, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x40, 0x0a, 0x0a, 0x6e, 0x65, 0x77, 0x5f, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x73, 0x67,
+	0x73, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x4e, 0x65, 0x77, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x09, 0x6e,
+	0x65, 0x77, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x48,
+	0x00, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12,
+	0x3a, 0x0a, 0x0a, 0x6e, 0x65, 0x77, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x48, 0x00,
+	0x52, 0x09, 0x6e, 0x65, 0x77, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x1a, 0x31, 0x0a, 0x09, 0x4e,
+	0x65, 0x77, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x42, 0x06,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x8f, 0x03, 0x0a, 0x0a, 0x50, 0x65, 0x72, 0x73, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x07, 0x71, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x51, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x06, 0x71, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x27,
+	0x0a, 0x07, 0x70, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x50, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52,
+	0x06, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x27, 0x0a, 0x07, 0x63, 0x5f, 0x65, 0x6e, 0x74,
+	0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e,
+	0x43, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x06, 0x63, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x27, 0x0a, 0x07, 0x6e, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48,
+	0x00, 0x52, 0x06, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x27, 0x0a, 0x07, 0x66, 0x5f, 0x65,
+	0x6e, 0x74, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x73, 0x67,
+	0x73, 0x2e, 0x46, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x06, 0x66, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x2b, 0x0a, 0x09, 0x65, 0x5f, 0x63, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x45, 0x43, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x07, 0x65, 0x43, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x27, 0x0a, 0x07, 0x74, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x54, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00,
+	0x52, 0x06, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x29, 0x0a, 0x07, 0x73, 0x75, 0x73, 0x70,
+	0x65, 0x63, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6d, 0x73, 0x67, 0x73,
+	0x2e, 0x53, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x48, 0x00, 0x52, 0x07, 0x73, 0x75, 0x73, 0x70,
+	0x65, 0x63, 0x74, 0x12, 0x2b, 0x0a, 0x09, 0x65, 0x5f, 0x61, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x45, 0x41,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x07, 0x65, 0x41, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x55, 0x0a, 0x06, 0x4e, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x34, 0x0a, 0x0c, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x52, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22,
+	0x47, 0x0a, 0x06, 0x46, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x3d, 0x0a, 0x11, 0x65, 0x6e, 0x64,
+	0x73, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x45, 0x70, 0x6f, 0x63,
+	0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0f, 0x65, 0x6e, 0x64, 0x73, 0x45, 0x70, 0x6f,
+	0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x2c, 0x0a, 0x07, 0x45, 0x43, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x9d, 0x01, 0x0a, 0x07, 0x45, 0x41, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x72,
+	0x69, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x12, 0x34, 0x0a, 0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e,
+	0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0b, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x22, 0x35, 0x0a, 0x06, 0x54, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x65, 0x0a,
+	0x06, 0x51, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e,
+	0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x16,
+	0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x73, 0x22, 0x37, 0x0a, 0x06, 0x50, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x15,
+	0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x83, 0x01,
+	0x0a, 0x06, 0x43, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f,
+	0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12,
+	0x29, 0x0a, 0x10, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x37, 0x0a, 0x0d, 0x6e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x22, 0xa2, 0x07, 0x0a, 0x03, 0x4d, 0x73, 0x67, 0x12, 0x32, 0x0a, 0x0a, 0x70,
+	0x72, 0x65, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x50, 0x72, 0x65, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72,
+	0x65, 0x48, 0x00, 0x52, 0x0a, 0x70, 0x72, 0x65, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12,
+	0x29, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x48,
+	0x00, 0x52, 0x07, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x73, 0x67,
+	0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x48, 0x00, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x12, 0x32, 0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x63,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x53,
+	0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x48, 0x00, 0x52, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x63,
+	0x74, 0x12, 0x36, 0x0a, 0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x45,
+	0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x40, 0x0a, 0x10, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x61, 0x63, 0x6b, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x0e, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x63, 0x6b, 0x12, 0x2d, 0x0a, 0x09, 0x6e,
+	0x65, 0x77, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x48, 0x00,
+	0x52, 0x08, 0x6e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x3c, 0x0a, 0x0e, 0x6e, 0x65,
+	0x77, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x65, 0x63, 0x68, 0x6f, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f,
+	0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x48, 0x00, 0x52, 0x0c, 0x6e, 0x65, 0x77, 0x45,
+	0x70, 0x6f, 0x63, 0x68, 0x45, 0x63, 0x68, 0x6f, 0x12, 0x3e, 0x0a, 0x0f, 0x6e, 0x65, 0x77, 0x5f,
+	0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63,
-	0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x48, 0x00, 0x52, 0x0c, 0x6e, 0x65, 0x77, 0x45, 0x70,
-	0x6f, 0x63, 0x68, 0x45, 0x63, 0x68, 0x6f, 0x12, 0x3e, 0x0a, 0x0f, 0x6e, 0x65, 0x77, 0x5f, 0x65,
-	0x70, 0x6f, 0x63, 0x68, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x14, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x48, 0x00, 0x52, 0x0d, 0x6e, 0x65, 0x77, 0x45, 0x70, 0x6f,
-	0x63, 0x68, 0x52, 0x65, 0x61, 0x64, 0x79, 0x12, 0x33, 0x0a, 0x0b, 0x66, 0x65, 0x74, 0x63, 0x68,
-	0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d,
-	0x73, 0x67, 0x73, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00,
-	0x52, 0x0a, 0x66, 0x65, 0x74, 0x63, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x39, 0x0a, 0x0d,
-	0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x0c, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x46, 0x6f, 0x72, 0x77, 0x61,
-	0x72, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x0c, 0x66, 0x6f, 0x72, 0x77, 0x61,
-	0x72, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x37, 0x0a, 0x0d, 0x66, 0x65, 0x74, 0x63, 0x68,
-	0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
-	0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b,
-	0x48, 0x00, 0x52, 0x0c, 0x66, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x3f, 0x0a, 0x0f, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x73, 0x67, 0x73,
-	0x2e, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
-	0x00, 0x52, 0x0e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x33, 0x0a, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x6b,
-	0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x3b,
-	0x0a, 0x0a, 0x46, 0x65, 0x74, 0x63, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x15, 0x0a, 0x06,
-	0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65,
-	0x71, 0x4e, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x72, 0x0a, 0x0c, 0x46,
-	0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x15, 0x0a, 0x06, 0x73,
+	0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x48, 0x00, 0x52, 0x0d, 0x6e, 0x65, 0x77, 0x45, 0x70,
+	0x6f, 0x63, 0x68, 0x52, 0x65, 0x61, 0x64, 0x79, 0x12, 0x33, 0x0a, 0x0b, 0x66, 0x65, 0x74, 0x63,
+	0x68, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x6d, 0x73, 0x67, 0x73, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48,
+	0x00, 0x52, 0x0a, 0x66, 0x65, 0x74, 0x63, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x39, 0x0a,
+	0x0d, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x46, 0x6f, 0x72, 0x77,
+	0x61, 0x72, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x0c, 0x66, 0x6f, 0x72, 0x77,
+	0x61, 0x72, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x37, 0x0a, 0x0d, 0x66, 0x65, 0x74, 0x63,
+	0x68, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63,
+	0x6b, 0x48, 0x00, 0x52, 0x0c, 0x66, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x3f, 0x0a, 0x0f, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x73, 0x67,
+	0x73, 0x2e, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x48, 0x00, 0x52, 0x0e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x33, 0x0a, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63,
+	0x6b, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x12, 0x23, 0x0a, 0x05, 0x68, 0x65, 0x6c, 0x6c, 0x6f,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x48, 0x65,
+	0x6c, 0x6c, 0x6f, 0x48, 0x00, 0x52, 0x05, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x12, 0x42, 0x0a, 0x10,
+	0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73,
+	0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x46, 0x6f,
+	0x72, 0x77, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x48, 0x00, 0x52,
+	0x0f, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73,
+	0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x43, 0x0a, 0x05, 0x48, 0x65, 0x6c, 0x6c,
+	0x6f, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x3b, 0x0a,
+	0x0a, 0x46, 0x65, 0x74, 0x63, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x15, 0x0a, 0x06, 0x73,
 	0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71,
-	0x4e, 0x6f, 0x12, 0x33, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63,
-	0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73,
-	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22,
-	0x66, 0x0a, 0x0e, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x31, 0x0a, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x6b,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x41, 0x63, 0x6b, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f,
-	0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x22, 0x51, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12,
-	0x15, 0x0a, 0x06, 0x72, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x05, 0x72, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x58, 0x0a, 0x0a, 0x52, 0x65,
+	0x4e, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x72, 0x0a, 0x0c, 0x46, 0x6f,
+	0x72, 0x77, 0x61, 0x72, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65,
+	0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e,
+	0x6f, 0x12, 0x33, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x6b,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x41, 0x63, 0x6b, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x66,
+	0x0a, 0x0e, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x31, 0x0a, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x6b, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x41, 0x63, 0x6b, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x22, 0x43, 0x0a, 0x0f, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x30, 0x0a, 0x08, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x73,
+	0x67, 0x73, 0x2e, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x22, 0x6d, 0x0a, 0x07, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x72, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a,
+	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x58, 0x0a, 0x0a, 0x52, 0x65,
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65,
 	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x63, 0x6c, 0x69,
 	0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x72, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18,
@@ -2606,87 +3031,95 @@ var file_msgs_msgs_proto_rawDesc = []byte{
 	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12,
 	0x15, 0x0a, 0x06, 0x72, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
 	0x05, 0x72, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x61,
-	0x0a, 0x0a, 0x50, 0x72, 0x65, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x15, 0x0a, 0x06,
-	0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65,
-	0x71, 0x4e, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x26, 0x0a, 0x05, 0x62, 0x61, 0x74,
-	0x63, 0x68, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x05, 0x62, 0x61, 0x74, 0x63,
-	0x68, 0x22, 0x4e, 0x0a, 0x07, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x15, 0x0a, 0x06,
-	0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65,
-	0x71, 0x4e, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67,
-	0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73,
-	0x74, 0x22, 0x4d, 0x0a, 0x06, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x73,
-	0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71,
-	0x4e, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65,
-	0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
-	0x22, 0x39, 0x0a, 0x0a, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x15,
-	0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
-	0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x1f, 0x0a, 0x07, 0x53,
-	0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x22, 0x91, 0x02, 0x0a,
-	0x0b, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09,
-	0x6e, 0x65, 0x77, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x08, 0x6e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x32, 0x0a, 0x0b, 0x63, 0x68, 0x65,
-	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
-	0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74,
-	0x52, 0x0b, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x2f, 0x0a,
-	0x05, 0x70, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d,
-	0x73, 0x67, 0x73, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e,
-	0x53, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x70, 0x53, 0x65, 0x74, 0x12, 0x2f,
-	0x0a, 0x05, 0x71, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
-	0x6d, 0x73, 0x67, 0x73, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x2e, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x71, 0x53, 0x65, 0x74, 0x1a,
-	0x4f, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x65,
-	0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63,
-	0x68, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65,
-	0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
-	0x22, 0x66, 0x0a, 0x0e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41,
-	0x63, 0x6b, 0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74,
-	0x6f, 0x72, 0x12, 0x34, 0x0a, 0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e,
-	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e,
-	0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0b, 0x65, 0x70, 0x6f,
-	0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x22, 0x6e, 0x0a, 0x0b, 0x45, 0x70, 0x6f, 0x63,
-	0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65,
-	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
-	0x18, 0x0a, 0x07, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x04,
-	0x52, 0x07, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x6c, 0x61,
-	0x6e, 0x6e, 0x65, 0x64, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x70, 0x6c, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x45, 0x78,
-	0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xab, 0x01, 0x0a, 0x0e, 0x4e, 0x65, 0x77,
-	0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x29, 0x0a, 0x06, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x73,
-	0x67, 0x73, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x41, 0x0a, 0x13, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69,
-	0x6e, 0x67, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b,
-	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x12, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x43,
-	0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x66, 0x69, 0x6e,
-	0x61, 0x6c, 0x5f, 0x70, 0x72, 0x65, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x73, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x0c, 0x52, 0x10, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x50, 0x72, 0x65, 0x70, 0x72,
-	0x65, 0x70, 0x61, 0x72, 0x65, 0x73, 0x22, 0xcc, 0x01, 0x0a, 0x08, 0x4e, 0x65, 0x77, 0x45, 0x70,
-	0x6f, 0x63, 0x68, 0x12, 0x33, 0x0a, 0x0a, 0x6e, 0x65, 0x77, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e,
-	0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x09, 0x6e,
-	0x65, 0x77, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x45, 0x0a, 0x0d, 0x65, 0x70, 0x6f, 0x63,
-	0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x20, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x2e,
-	0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x52, 0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x1a,
-	0x44, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68,
-	0x61, 0x6e, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x16, 0x0a,
-	0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64,
-	0x69, 0x67, 0x65, 0x73, 0x74, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70, 0x65, 0x72, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2d,
-	0x6c, 0x61, 0x62, 0x73, 0x2f, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
-	0x70, 0x62, 0x2f, 0x6d, 0x73, 0x67, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x8c,
+	0x01, 0x0a, 0x0a, 0x50, 0x72, 0x65, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x15, 0x0a,
+	0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73,
+	0x65, 0x71, 0x4e, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x26, 0x0a, 0x05, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73,
+	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x05, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64,
+	0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x63, 0x6f,
+	0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x22, 0x4e, 0x0a,
+	0x07, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f,
+	0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x4d, 0x0a,
+	0x06, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e,
+	0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65,
+	0x70, 0x6f, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x39, 0x0a, 0x0a,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65,
+	0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e,
+	0x6f, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3c, 0x0a, 0x07, 0x53, 0x75, 0x73, 0x70, 0x65,
+	0x63, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x74, 0x65, 0x70,
+	0x5f, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73, 0x74, 0x65,
+	0x70, 0x44, 0x6f, 0x77, 0x6e, 0x22, 0xbe, 0x02, 0x0a, 0x0b, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x45, 0x70, 0x6f,
+	0x63, 0x68, 0x12, 0x32, 0x0a, 0x0b, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0b, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x05, 0x70, 0x5f, 0x73, 0x65, 0x74, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x45, 0x70, 0x6f,
+	0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x04, 0x70, 0x53, 0x65, 0x74, 0x12, 0x2f, 0x0a, 0x05, 0x71, 0x5f, 0x73, 0x65, 0x74,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x45, 0x70,
+	0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x04, 0x71, 0x53, 0x65, 0x74, 0x12, 0x2b, 0x0a, 0x07, 0x67, 0x65, 0x6e, 0x65,
+	0x73, 0x69, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x73, 0x67, 0x73,
+	0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x07, 0x67, 0x65,
+	0x6e, 0x65, 0x73, 0x69, 0x73, 0x1a, 0x4f, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e,
+	0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x16,
+	0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x66, 0x0a, 0x0e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x63, 0x6b, 0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6f, 0x72,
+	0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x34, 0x0a, 0x0c, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x22, 0x6e,
+	0x0a, 0x0b, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x16, 0x0a,
+	0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x04, 0x52, 0x07, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12,
+	0x2d, 0x0a, 0x12, 0x70, 0x6c, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x70, 0x6c, 0x61,
+	0x6e, 0x6e, 0x65, 0x64, 0x45, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xab,
+	0x01, 0x0a, 0x0e, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x12, 0x29, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x41, 0x0a, 0x13,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73,
+	0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x12, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12,
+	0x2b, 0x0a, 0x11, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x70, 0x72, 0x65, 0x70, 0x72, 0x65, 0x70,
+	0x61, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x10, 0x66, 0x69, 0x6e, 0x61,
+	0x6c, 0x50, 0x72, 0x65, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x73, 0x22, 0xcc, 0x01, 0x0a,
+	0x08, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x33, 0x0a, 0x0a, 0x6e, 0x65, 0x77,
+	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x09, 0x6e, 0x65, 0x77, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x45,
+	0x0a, 0x0d, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x65, 0x77,
+	0x45, 0x70, 0x6f, 0x63, 0x68, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x45, 0x70, 0x6f, 0x63,
+	0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x1a, 0x44, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x45,
+	0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f,
+	0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x6f, 0x64,
+	0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x42, 0x30, 0x5a, 0x2e, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70, 0x65, 0x72, 0x6c,
+	0x65, 0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x6d, 0x69, 0x72, 0x62, 0x66,
+	0x74, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0x2f, 0x6d, 0x73, 0x67, 0x73, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2701,7 +3134,7 @@ func file_msgs_msgs_proto_rawDescGZIP() []byte {
 	return file_msgs_msgs_proto_rawDescData
 }
 
-var file_msgs_msgs_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
+var file_msgs_msgs_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_msgs_msgs_proto_goTypes = []interface{}{
 	(*NetworkState)(nil),               // 0: msgs.NetworkState
 	(*Reconfiguration)(nil),            // 1: msgs.Reconfiguration
@@ -2709,82 +3142,91 @@ var file_msgs_msgs_proto_goTypes = []interface{}{
 	(*NEntry)(nil),                     // 3: msgs.NEntry
 	(*FEntry)(nil),                     // 4: msgs.FEntry
 	(*ECEntry)(nil),                    // 5: msgs.ECEntry
-	(*TEntry)(nil),                     // 6: msgs.TEntry
-	(*QEntry)(nil),                     // 7: msgs.QEntry
-	(*PEntry)(nil),                     // 8: msgs.PEntry
-	(*CEntry)(nil),                     // 9: msgs.CEntry
-	(*Msg)(nil),                        // 10: msgs.Msg
-	(*FetchBatch)(nil),                 // 11: msgs.FetchBatch
-	(*ForwardBatch)(nil),               // 12: msgs.ForwardBatch
-	(*ForwardRequest)(nil),             // 13: msgs.ForwardRequest
-	(*Request)(nil),                    // 14: msgs.Request
-	(*RequestRef)(nil),                 // 15: msgs.RequestRef
-	(*RequestAck)(nil),                 // 16: msgs.RequestAck
-	(*Preprepare)(nil),                 // 17: msgs.Preprepare
-	(*Prepare)(nil),                    // 18: msgs.Prepare
-	(*Commit)(nil),                     // 19: msgs.Commit
-	(*Checkpoint)(nil),                 // 20: msgs.Checkpoint
-	(*Suspect)(nil),                    // 21: msgs.Suspect
-	(*EpochChange)(nil),                // 22: msgs.EpochChange
-	(*EpochChangeAck)(nil),             // 23: msgs.EpochChangeAck
-	(*EpochConfig)(nil),                // 24: msgs.EpochConfig
-	(*NewEpochConfig)(nil),             // 25: msgs.NewEpochConfig
-	(*NewEpoch)(nil),                   // 26: msgs.NewEpoch
-	(*NetworkState_Config)(nil),        // 27: msgs.NetworkState.Config
-	(*NetworkState_Client)(nil),        // 28: msgs.NetworkState.Client
-	(*Reconfiguration_NewClient)(nil),  // 29: msgs.Reconfiguration.NewClient
-	(*EpochChange_SetEntry)(nil),       // 30: msgs.EpochChange.SetEntry
-	(*NewEpoch_RemoteEpochChange)(nil), // 31: msgs.NewEpoch.RemoteEpochChange
+	(*EAEntry)(nil),                    // 6: msgs.EAEntry
+	(*TEntry)(nil),                     // 7: msgs.TEntry
+	(*QEntry)(nil),                     // 8: msgs.QEntry
+	(*PEntry)(nil),                     // 9: msgs.PEntry
+	(*CEntry)(nil),                     // 10: msgs.CEntry
+	(*Msg)(nil),                        // 11: msgs.Msg
+	(*Hello)(nil),                      // 12: msgs.Hello
+	(*FetchBatch)(nil),                 // 13: msgs.FetchBatch
+	(*ForwardBatch)(nil),               // 14: msgs.ForwardBatch
+	(*ForwardRequest)(nil),             // 15: msgs.ForwardRequest
+	(*ForwardRequests)(nil),            // 16: msgs.ForwardRequests
+	(*Request)(nil),                    // 17: msgs.Request
+	(*RequestRef)(nil),                 // 18: msgs.RequestRef
+	(*RequestAck)(nil),                 // 19: msgs.RequestAck
+	(*Preprepare)(nil),                 // 20: msgs.Preprepare
+	(*Prepare)(nil),                    // 21: msgs.Prepare
+	(*Commit)(nil),                     // 22: msgs.Commit
+	(*Checkpoint)(nil),                 // 23: msgs.Checkpoint
+	(*Suspect)(nil),                    // 24: msgs.Suspect
+	(*EpochChange)(nil),                // 25: msgs.EpochChange
+	(*EpochChangeAck)(nil),             // 26: msgs.EpochChangeAck
+	(*EpochConfig)(nil),                // 27: msgs.EpochConfig
+	(*NewEpochConfig)(nil),             // 28: msgs.NewEpochConfig
+	(*NewEpoch)(nil),                   // 29: msgs.NewEpoch
+	(*NetworkState_Config)(nil),        // 30: msgs.NetworkState.Config
+	(*NetworkState_Client)(nil),        // 31: msgs.NetworkState.Client
+	(*Reconfiguration_NewClient)(nil),  // 32: msgs.Reconfiguration.NewClient
+	(*EpochChange_SetEntry)(nil),       // 33: msgs.EpochChange.SetEntry
+	(*NewEpoch_RemoteEpochChange)(nil), // 34: msgs.NewEpoch.RemoteEpochChange
 }
 var file_msgs_msgs_proto_depIdxs = []int32{
-	27, // 0: msgs.NetworkState.config:type_name -> msgs.NetworkState.Config
-	28, // 1: msgs.NetworkState.clients:type_name -> msgs.NetworkState.Client
+	30, // 0: msgs.NetworkState.config:type_name -> msgs.NetworkState.Config
+	31, // 1: msgs.NetworkState.clients:type_name -> msgs.NetworkState.Client
 	1,  // 2: msgs.NetworkState.pending_reconfigurations:type_name -> msgs.Reconfiguration
-	29, // 3: msgs.Reconfiguration.new_client:type_name -> msgs.Reconfiguration.NewClient
-	27, // 4: msgs.Reconfiguration.new_config:type_name -> msgs.NetworkState.Config
-	7,  // 5: msgs.Persistent.q_entry:type_name -> msgs.QEntry
-	8,  // 6: msgs.Persistent.p_entry:type_name -> msgs.PEntry
-	9,  // 7: msgs.Persistent.c_entry:type_name -> msgs.CEntry
+	32, // 3: msgs.Reconfiguration.new_client:type_name -> msgs.Reconfiguration.NewClient
+	30, // 4: msgs.Reconfiguration.new_config:type_name -> msgs.NetworkState.Config
+	8,  // 5: msgs.Persistent.q_entry:type_name -> msgs.QEntry
+	9,  // 6: msgs.Persistent.p_entry:type_name -> msgs.PEntry
+	10, // 7: msgs.Persistent.c_entry:type_name -> msgs.CEntry
 	3,  // 8: msgs.Persistent.n_entry:type_name -> msgs.NEntry
 	4,  // 9: msgs.Persistent.f_entry:type_name -> msgs.FEntry
 	5,  // 10: msgs.Persistent.e_c_entry:type_name -> msgs.ECEntry
-	6,  // 11: msgs.Persistent.t_entry:type_name -> msgs.TEntry
-	21, // 12: msgs.Persistent.suspect:type_name -> msgs.Suspect
-	24, // 13: msgs.NEntry.epoch_config:type_name -> msgs.EpochConfig
-	24, // 14: msgs.FEntry.ends_epoch_config:type_name -> msgs.EpochConfig
-	16, // 15: msgs.QEntry.requests:type_name -> msgs.RequestAck
-	0,  // 16: msgs.CEntry.network_state:type_name -> msgs.NetworkState
-	17, // 17: msgs.Msg.preprepare:type_name -> msgs.Preprepare
-	18, // 18: msgs.Msg.prepare:type_name -> msgs.Prepare
-	19, // 19: msgs.Msg.commit:type_name -> msgs.Commit
-	20, // 20: msgs.Msg.checkpoint:type_name -> msgs.Checkpoint
-	21, // 21: msgs.Msg.suspect:type_name -> msgs.Suspect
-	22, // 22: msgs.Msg.epoch_change:type_name -> msgs.EpochChange
-	23, // 23: msgs.Msg.epoch_change_ack:type_name -> msgs.EpochChangeAck
-	26, // 24: msgs.Msg.new_epoch:type_name -> msgs.NewEpoch
-	25, // 25: msgs.Msg.new_epoch_echo:type_name -> msgs.NewEpochConfig
-	25, // 26: msgs.Msg.new_epoch_ready:type_name -> msgs.NewEpochConfig
-	11, // 27: msgs.Msg.fetch_batch:type_name -> msgs.FetchBatch
-	12, // 28: msgs.Msg.forward_batch:type_name -> msgs.ForwardBatch
-	16, // 29: msgs.Msg.fetch_request:type_name -> msgs.RequestAck
-	13, // 30: msgs.Msg.forward_request:type_name -> msgs.ForwardRequest
-	16, // 31: msgs.Msg.request_ack:type_name -> msgs.RequestAck
-	16, // 32: msgs.ForwardBatch.request_acks:type_name -> msgs.RequestAck
-	16, // 33: msgs.ForwardRequest.request_ack:type_name -> msgs.RequestAck
-	16, // 34: msgs.Preprepare.batch:type_name -> msgs.RequestAck
-	20, // 35: msgs.EpochChange.checkpoints:type_name -> msgs.Checkpoint
-	30, // 36: msgs.EpochChange.p_set:type_name -> msgs.EpochChange.SetEntry
-	30, // 37: msgs.EpochChange.q_set:type_name -> msgs.EpochChange.SetEntry
-	22, // 38: msgs.EpochChangeAck.epoch_change:type_name -> msgs.EpochChange
-	24, // 39: msgs.NewEpochConfig.config:type_name -> msgs.EpochConfig
-	20, // 40: msgs.NewEpochConfig.starting_checkpoint:type_name -> msgs.Checkpoint
-	25, // 41: msgs.NewEpoch.new_config:type_name -> msgs.NewEpochConfig
-	31, // 42: msgs.NewEpoch.epoch_changes:type_name -> msgs.NewEpoch.RemoteEpochChange
-	43, // [43:43] is the sub-list for method output_type
-	43, // [43:43] is the sub-list for method input_type
-	43, // [43:43] is the sub-list for extension type_name
-	43, // [43:43] is the sub-list for extension extendee
-	0,  // [0:43] is the sub-list for field type_name
+	7,  // 11: msgs.Persistent.t_entry:type_name -> msgs.TEntry
+	24, // 12: msgs.Persistent.suspect:type_name -> msgs.Suspect
+	6,  // 13: msgs.Persistent.e_a_entry:type_name -> msgs.EAEntry
+	27, // 14: msgs.NEntry.epoch_config:type_name -> msgs.EpochConfig
+	27, // 15: msgs.FEntry.ends_epoch_config:type_name -> msgs.EpochConfig
+	25, // 16: msgs.EAEntry.epoch_change:type_name -> msgs.EpochChange
+	19, // 17: msgs.QEntry.requests:type_name -> msgs.RequestAck
+	0,  // 18: msgs.CEntry.network_state:type_name -> msgs.NetworkState
+	20, // 19: msgs.Msg.preprepare:type_name -> msgs.Preprepare
+	21, // 20: msgs.Msg.prepare:type_name -> msgs.Prepare
+	22, // 21: msgs.Msg.commit:type_name -> msgs.Commit
+	23, // 22: msgs.Msg.checkpoint:type_name -> msgs.Checkpoint
+	24, // 23: msgs.Msg.suspect:type_name -> msgs.Suspect
+	25, // 24: msgs.Msg.epoch_change:type_name -> msgs.EpochChange
+	26, // 25: msgs.Msg.epoch_change_ack:type_name -> msgs.EpochChangeAck
+	29, // 26: msgs.Msg.new_epoch:type_name -> msgs.NewEpoch
+	28, // 27: msgs.Msg.new_epoch_echo:type_name -> msgs.NewEpochConfig
+	28, // 28: msgs.Msg.new_epoch_ready:type_name -> msgs.NewEpochConfig
+	13, // 29: msgs.Msg.fetch_batch:type_name -> msgs.FetchBatch
+	14, // 30: msgs.Msg.forward_batch:type_name -> msgs.ForwardBatch
+	19, // 31: msgs.Msg.fetch_request:type_name -> msgs.RequestAck
+	15, // 32: msgs.Msg.forward_request:type_name -> msgs.ForwardRequest
+	19, // 33: msgs.Msg.request_ack:type_name -> msgs.RequestAck
+	12, // 34: msgs.Msg.hello:type_name -> msgs.Hello
+	16, // 35: msgs.Msg.forward_requests:type_name -> msgs.ForwardRequests
+	19, // 36: msgs.ForwardBatch.request_acks:type_name -> msgs.RequestAck
+	19, // 37: msgs.ForwardRequest.request_ack:type_name -> msgs.RequestAck
+	15, // 38: msgs.ForwardRequests.requests:type_name -> msgs.ForwardRequest
+	19, // 39: msgs.Preprepare.batch:type_name -> msgs.RequestAck
+	23, // 40: msgs.EpochChange.checkpoints:type_name -> msgs.Checkpoint
+	33, // 41: msgs.EpochChange.p_set:type_name -> msgs.EpochChange.SetEntry
+	33, // 42: msgs.EpochChange.q_set:type_name -> msgs.EpochChange.SetEntry
+	27, // 43: msgs.EpochChange.genesis:type_name -> msgs.EpochConfig
+	25, // 44: msgs.EpochChangeAck.epoch_change:type_name -> msgs.EpochChange
+	27, // 45: msgs.NewEpochConfig.config:type_name -> msgs.EpochConfig
+	23, // 46: msgs.NewEpochConfig.starting_checkpoint:type_name -> msgs.Checkpoint
+	28, // 47: msgs.NewEpoch.new_config:type_name -> msgs.NewEpochConfig
+	34, // 48: msgs.NewEpoch.epoch_changes:type_name -> msgs.NewEpoch.RemoteEpochChange
+	49, // [49:49] is the sub-list for method output_type
+	49, // [49:49] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
 }
 
 func init() { file_msgs_msgs_proto_init() }
@@ -2866,7 +3308,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TEntry); i {
+			switch v := v.(*EAEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2878,7 +3320,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QEntry); i {
+			switch v := v.(*TEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2890,7 +3332,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PEntry); i {
+			switch v := v.(*QEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2902,7 +3344,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CEntry); i {
+			switch v := v.(*PEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2914,7 +3356,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Msg); i {
+			switch v := v.(*CEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2926,7 +3368,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FetchBatch); i {
+			switch v := v.(*Msg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2938,7 +3380,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ForwardBatch); i {
+			switch v := v.(*Hello); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2950,7 +3392,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ForwardRequest); i {
+			switch v := v.(*FetchBatch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2962,7 +3404,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Request); i {
+			switch v := v.(*ForwardBatch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2974,7 +3416,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestRef); i {
+			switch v := v.(*ForwardRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2986,7 +3428,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestAck); i {
+			switch v := v.(*ForwardRequests); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2998,7 +3440,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Preprepare); i {
+			switch v := v.(*Request); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3010,7 +3452,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Prepare); i {
+			switch v := v.(*RequestRef); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3022,7 +3464,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Commit); i {
+			switch v := v.(*RequestAck); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3034,7 +3476,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Checkpoint); i {
+			switch v := v.(*Preprepare); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3046,7 +3488,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Suspect); i {
+			switch v := v.(*Prepare); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3058,7 +3500,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EpochChange); i {
+			switch v := v.(*Commit); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3070,7 +3512,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EpochChangeAck); i {
+			switch v := v.(*Checkpoint); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3082,7 +3524,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EpochConfig); i {
+			switch v := v.(*Suspect); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3094,7 +3536,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewEpochConfig); i {
+			switch v := v.(*EpochChange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3106,7 +3548,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewEpoch); i {
+			switch v := v.(*EpochChangeAck); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3118,7 +3560,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NetworkState_Config); i {
+			switch v := v.(*EpochConfig); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3130,7 +3572,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NetworkState_Client); i {
+			switch v := v.(*NewEpochConfig); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3142,7 +3584,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Reconfiguration_NewClient); i {
+			switch v := v.(*NewEpoch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3154,7 +3596,7 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EpochChange_SetEntry); i {
+			switch v := v.(*NetworkState_Config); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3166,6 +3608,42 @@ func file_msgs_msgs_proto_init() {
 			}
 		}
 		file_msgs_msgs_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetworkState_Client); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgs_msgs_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Reconfiguration_NewClient); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgs_msgs_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EpochChange_SetEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgs_msgs_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*NewEpoch_RemoteEpochChange); i {
 			case 0:
 				return &v.state
@@ -3192,8 +3670,9 @@ func file_msgs_msgs_proto_init() {
 		(*Persistent_ECEntry)(nil),
 		(*Persistent_TEntry)(nil),
 		(*Persistent_Suspect)(nil),
+		(*Persistent_EAEntry)(nil),
 	}
-	file_msgs_msgs_proto_msgTypes[10].OneofWrappers = []interface{}{
+	file_msgs_msgs_proto_msgTypes[11].OneofWrappers = []interface{}{
 		(*Msg_Preprepare)(nil),
 		(*Msg_Prepare)(nil),
 		(*Msg_Commit)(nil),
@@ -3209,6 +3688,8 @@ func file_msgs_msgs_proto_init() {
 		(*Msg_FetchRequest)(nil),
 		(*Msg_ForwardRequest)(nil),
 		(*Msg_RequestAck)(nil),
+		(*Msg_Hello)(nil),
+		(*Msg_ForwardRequests)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -3216,7 +3697,7 @@ func file_msgs_msgs_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_msgs_msgs_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   32,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
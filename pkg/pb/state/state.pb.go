@@ -48,6 +48,13 @@ type Event struct {
 	//	*Event_ActionsReceived
 	//	*Event_Message
 	//	*Event_Request
+	//	*Event_CommitsApplied
+	//	*Event_Compact
+	//	*Event_StepDown
+	//	*Event_AuditDigest
+	//	*Event_ImportClientState
+	//	*Event_ResetMisbehavior
+	//	*Event_RequestCheckpoint
 	Type isEvent_Type `protobuf_oneof:"type"`
 }
 
@@ -181,6 +188,55 @@ func (x *Event) GetRequest() *msgs.Request {
 	return nil
 }
 
+func (x *Event) GetCommitsApplied() *EventCommitsApplied {
+	if x, ok := x.GetType().(*Event_CommitsApplied); ok {
+		return x.CommitsApplied
+	}
+	return nil
+}
+
+func (x *Event) GetCompact() *EventCompact {
+	if x, ok := x.GetType().(*Event_Compact); ok {
+		return x.Compact
+	}
+	return nil
+}
+
+func (x *Event) GetStepDown() *EventStepDown {
+	if x, ok := x.GetType().(*Event_StepDown); ok {
+		return x.StepDown
+	}
+	return nil
+}
+
+func (x *Event) GetAuditDigest() *EventAuditDigest {
+	if x, ok := x.GetType().(*Event_AuditDigest); ok {
+		return x.AuditDigest
+	}
+	return nil
+}
+
+func (x *Event) GetImportClientState() *EventImportClientState {
+	if x, ok := x.GetType().(*Event_ImportClientState); ok {
+		return x.ImportClientState
+	}
+	return nil
+}
+
+func (x *Event) GetResetMisbehavior() *EventResetMisbehavior {
+	if x, ok := x.GetType().(*Event_ResetMisbehavior); ok {
+		return x.ResetMisbehavior
+	}
+	return nil
+}
+
+func (x *Event) GetRequestCheckpoint() *EventRequestCheckpoint {
+	if x, ok := x.GetType().(*Event_RequestCheckpoint); ok {
+		return x.RequestCheckpoint
+	}
+	return nil
+}
+
 type isEvent_Type interface {
 	isEvent_Type()
 }
@@ -238,6 +294,34 @@ type Event_Request struct {
 	Request *msgs.Request `protobuf:"bytes,13,opt,name=request,proto3,oneof"`
 }
 
+type Event_CommitsApplied struct {
+	CommitsApplied *EventCommitsApplied `protobuf:"bytes,14,opt,name=commits_applied,json=commitsApplied,proto3,oneof"`
+}
+
+type Event_Compact struct {
+	Compact *EventCompact `protobuf:"bytes,15,opt,name=compact,proto3,oneof"`
+}
+
+type Event_StepDown struct {
+	StepDown *EventStepDown `protobuf:"bytes,16,opt,name=step_down,json=stepDown,proto3,oneof"`
+}
+
+type Event_AuditDigest struct {
+	AuditDigest *EventAuditDigest `protobuf:"bytes,17,opt,name=audit_digest,json=auditDigest,proto3,oneof"`
+}
+
+type Event_ImportClientState struct {
+	ImportClientState *EventImportClientState `protobuf:"bytes,18,opt,name=import_client_state,json=importClientState,proto3,oneof"`
+}
+
+type Event_ResetMisbehavior struct {
+	ResetMisbehavior *EventResetMisbehavior `protobuf:"bytes,19,opt,name=reset_misbehavior,json=resetMisbehavior,proto3,oneof"`
+}
+
+type Event_RequestCheckpoint struct {
+	RequestCheckpoint *EventRequestCheckpoint `protobuf:"bytes,20,opt,name=request_checkpoint,json=requestCheckpoint,proto3,oneof"`
+}
+
 func (*Event_Initialize) isEvent_Type() {}
 
 func (*Event_LoadPersistedEntry) isEvent_Type() {}
@@ -264,6 +348,20 @@ func (*Event_Message) isEvent_Type() {}
 
 func (*Event_Request) isEvent_Type() {}
 
+func (*Event_CommitsApplied) isEvent_Type() {}
+
+func (*Event_Compact) isEvent_Type() {}
+
+func (*Event_StepDown) isEvent_Type() {}
+
+func (*Event_AuditDigest) isEvent_Type() {}
+
+func (*Event_ImportClientState) isEvent_Type() {}
+
+func (*Event_ResetMisbehavior) isEvent_Type() {}
+
+func (*Event_RequestCheckpoint) isEvent_Type() {}
+
 type EventInitialParameters struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -275,6 +373,134 @@ type EventInitialParameters struct {
 	SuspectTicks         uint32 `protobuf:"varint,4,opt,name=suspect_ticks,json=suspectTicks,proto3" json:"suspect_ticks,omitempty"`
 	NewEpochTimeoutTicks uint32 `protobuf:"varint,5,opt,name=new_epoch_timeout_ticks,json=newEpochTimeoutTicks,proto3" json:"new_epoch_timeout_ticks,omitempty"`
 	BufferSize           uint32 `protobuf:"varint,6,opt,name=buffer_size,json=bufferSize,proto3" json:"buffer_size,omitempty"`
+	// strict_ordering should be set when the transport delivers messages from
+	// each node in order.  A commit arriving before the sender's prepare is then
+	// flagged as a transport violation and dropped, rather than being reordered,
+	// and a preprepare skipping a sequence of its leader's bucket is flagged as
+	// misbehavior, though still buffered.
+	StrictOrdering bool `protobuf:"varint,7,opt,name=strict_ordering,json=strictOrdering,proto3" json:"strict_ordering,omitempty"`
+	// catch_up_ticks is the number of ticks a node remains in its current
+	// epoch once f+1 nodes reference a later one, before abandoning it to
+	// catch up.  If zero, a default of 10 ticks is used.
+	CatchUpTicks uint32 `protobuf:"varint,8,opt,name=catch_up_ticks,json=catchUpTicks,proto3" json:"catch_up_ticks,omitempty"`
+	// adaptive_batching, when set, lets the batch size of the buckets this
+	// node leads vary between min_batch_size and max_batch_size, growing while
+	// requests back up and shrinking while batches are cut before filling.
+	// batch_size is then ignored.  The requests of a batch are still taken in
+	// order, only the point at which the batch is cut varies.
+	AdaptiveBatching bool   `protobuf:"varint,9,opt,name=adaptive_batching,json=adaptiveBatching,proto3" json:"adaptive_batching,omitempty"`
+	MinBatchSize     uint32 `protobuf:"varint,10,opt,name=min_batch_size,json=minBatchSize,proto3" json:"min_batch_size,omitempty"`
+	MaxBatchSize     uint32 `protobuf:"varint,11,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
+	// max_epoch_jump, if non-zero, caps how many epochs ahead of its current
+	// epoch a node may target when it catches up with f+1 nodes referencing a
+	// later epoch.  Intermediate epochs are targeted in turn, unless 2f+1
+	// nodes reference the later epoch, which justifies skipping directly to it.
+	MaxEpochJump uint32 `protobuf:"varint,12,opt,name=max_epoch_jump,json=maxEpochJump,proto3" json:"max_epoch_jump,omitempty"`
+	// disable_forwarding, when set, stops this node from forwarding request
+	// data to the nodes which have not acked the requests it proposes.  It
+	// is intended for deployments where clients submit their requests
+	// directly to every node, making forwarding redundant.  A request this
+	// node persists is then only ever batched by the leader of its bucket if
+	// that leader received it from the client as well, so a request of a
+	// bucket led by another node is rejected until that leader acks it.
+	// Fetches of requests by other nodes are still answered.
+	DisableForwarding bool `protobuf:"varint,13,opt,name=disable_forwarding,json=disableForwarding,proto3" json:"disable_forwarding,omitempty"`
+	// strict_high_watermark, when set, makes a preprepare for a sequence
+	// beyond this node's high watermark a protocol violation, for which the
+	// leader is suspected, rather than being buffered until the watermarks
+	// move.  A correct leader never proposes beyond its own high watermark,
+	// but this node's watermarks may lag the leader's while checkpoint
+	// messages are in flight, so this should only be set where nodes are
+	// expected to remain in step.
+	StrictHighWatermark bool `protobuf:"varint,14,opt,name=strict_high_watermark,json=strictHighWatermark,proto3" json:"strict_high_watermark,omitempty"`
+	// disable_self_echo, when set, indicates that the transport does not
+	// deliver this node's messages to itself, so this node is removed from
+	// the targets of its sends and applies its own messages directly, in the
+	// order they were sent.  Otherwise, sends target this node too, and the
+	// transport delivers them like those of any other node.  Either way, a
+	// node's own messages are processed as if received from the network, so,
+	// for instance, a leader's preprepare stands in for its prepare.
+	DisableSelfEcho bool `protobuf:"varint,15,opt,name=disable_self_echo,json=disableSelfEcho,proto3" json:"disable_self_echo,omitempty"`
+	// lagging_follower_threshold is the number of sequences led by this node
+	// which may commit without a commit from a follower, before the follower
+	// is reported as lagging in the status.  If zero, a default of 4 is used.
+	LaggingFollowerThreshold uint32 `protobuf:"varint,16,opt,name=lagging_follower_threshold,json=laggingFollowerThreshold,proto3" json:"lagging_follower_threshold,omitempty"`
+	// max_unapplied_commits, if non-zero, bounds the number of commits this
+	// node emits which the application has not yet acknowledged applying
+	// through EventCommitsApplied.  Once reached, further committed batches
+	// are held back, in order, until the application catches up.
+	MaxUnappliedCommits uint32 `protobuf:"varint,17,opt,name=max_unapplied_commits,json=maxUnappliedCommits,proto3" json:"max_unapplied_commits,omitempty"`
+	// verify_commit_digests, if set, has this node recompute the digest of
+	// each committed batch from its request acks, and refuse to deliver any
+	// commit whose digest does not match, as a guard against corruption.
+	VerifyCommitDigests bool `protobuf:"varint,18,opt,name=verify_commit_digests,json=verifyCommitDigests,proto3" json:"verify_commit_digests,omitempty"`
+	// epoch_change_alarm_threshold, if non-zero, is the number of epoch
+	// changes this node may begin within epoch_change_alarm_window_ticks
+	// ticks before it reports the network as unstable.  If the window is
+	// zero, a default of 100 ticks is used.
+	EpochChangeAlarmThreshold   uint32 `protobuf:"varint,19,opt,name=epoch_change_alarm_threshold,json=epochChangeAlarmThreshold,proto3" json:"epoch_change_alarm_threshold,omitempty"`
+	EpochChangeAlarmWindowTicks uint32 `protobuf:"varint,20,opt,name=epoch_change_alarm_window_ticks,json=epochChangeAlarmWindowTicks,proto3" json:"epoch_change_alarm_window_ticks,omitempty"`
+	// deliver_commits_past_gaps, if set, has this node deliver each batch as
+	// soon as it commits, even while lower sequences have yet to commit,
+	// rather than holding it back until the gap fills.  Such commits list
+	// the missing sequences as gaps, and the missing sequences are delivered
+	// once they commit.  Batches committed under a network configuration
+	// with barrier clients are always delivered in order, as are all
+	// batches when coalesce_commits is set.
+	DeliverCommitsPastGaps bool `protobuf:"varint,21,opt,name=deliver_commits_past_gaps,json=deliverCommitsPastGaps,proto3" json:"deliver_commits_past_gaps,omitempty"`
+	// max_buffered_epoch_changes is the number of future epochs for which
+	// this node buffers epoch change messages, and acknowledgements of them,
+	// from each other node, separately from its ordering messages.  A node
+	// sending them for more epochs is reported as misbehaving, and the
+	// excess messages are dropped.  If zero, a default of 4 is used.
+	MaxBufferedEpochChanges uint32 `protobuf:"varint,22,opt,name=max_buffered_epoch_changes,json=maxBufferedEpochChanges,proto3" json:"max_buffered_epoch_changes,omitempty"`
+	// applied_commit_watermark is the last watermark this node reported
+	// through a PersistWatermark action before it stopped.  The commits
+	// through it are not delivered again when the node restarts, as the
+	// application has already applied them.  It is ignored if it passes
+	// the checkpoint which follows the low watermark, as that checkpoint
+	// would then be requested of an application which applied later commits.
+	AppliedCommitWatermark uint64 `protobuf:"varint,23,opt,name=applied_commit_watermark,json=appliedCommitWatermark,proto3" json:"applied_commit_watermark,omitempty"`
+	// future_epoch_lookahead is the number of epochs beyond its current one
+	// for which a node buffers preprepares, prepares and commits.  Those for
+	// later epochs are dropped, though the epoch they reference still counts
+	// towards catching up, see catch_up_ticks.  If zero, a default of 4 is used.
+	FutureEpochLookahead uint32 `protobuf:"varint,24,opt,name=future_epoch_lookahead,json=futureEpochLookahead,proto3" json:"future_epoch_lookahead,omitempty"`
+	// epoch_change_quorum is the number of epoch change messages this node
+	// collects before forming a new epoch, and epoch_join_quorum the number
+	// referencing a later epoch before it abandons its own to join it.
+	// They default to 2f+1 and f+1 respectively for a network of 3f+1 nodes,
+	// and each applies as well to the suspicions this node collects before
+	// ending, or joining the suspicion of, its epoch.  Either may be raised
+	// for testing, the other keeping its default, but both are ignored, and
+	// an error logged, unless epoch_join_quorum <= epoch_change_quorum <=
+	// len(nodes), and neither is below its default.
+	EpochChangeQuorum uint32 `protobuf:"varint,25,opt,name=epoch_change_quorum,json=epochChangeQuorum,proto3" json:"epoch_change_quorum,omitempty"`
+	EpochJoinQuorum   uint32 `protobuf:"varint,26,opt,name=epoch_join_quorum,json=epochJoinQuorum,proto3" json:"epoch_join_quorum,omitempty"`
+	// drop_during_state_transfer, when set, has this node drop every
+	// preprepare, prepare and commit it receives while transferring state.
+	// Otherwise, only those at or below the sequence being transferred to
+	// are dropped, as the node jumps past them, and those above it are
+	// buffered, within buffer_size, and applied once the transfer completes.
+	DropDuringStateTransfer bool `protobuf:"varint,27,opt,name=drop_during_state_transfer,json=dropDuringStateTransfer,proto3" json:"drop_during_state_transfer,omitempty"`
+	// coalesce_commits, when set, has this node hold back the commits of a
+	// checkpoint interval until every one of them has committed, and the
+	// checkpoint of the interval before has completed.  They are then
+	// emitted together with the checkpoint request for the interval, so that
+	// the application applies and checkpoints the interval at once.
+	// Coalescing takes precedence over deliver_commits_past_gaps, which is
+	// ignored when both are set.  Commits held back by max_unapplied_commits,
+	// or behind a barrier or coordinated checkpoint request awaiting
+	// acknowledgement, are still emitted once released, so an interval may
+	// then be split across several action lists, the last carrying the
+	// checkpoint request.
+	CoalesceCommits bool `protobuf:"varint,28,opt,name=coalesce_commits,json=coalesceCommits,proto3" json:"coalesce_commits,omitempty"`
+	// max_decompressed_batch_bytes bounds the size to which this node
+	// decompresses the batch of a preprepare, see batch_compression.  A
+	// preprepare whose batch decompresses to more is dropped, so that a
+	// faulty leader cannot exhaust this node's memory.  If zero, a default
+	// of 16 MiB is used.
+	MaxDecompressedBatchBytes uint32 `protobuf:"varint,29,opt,name=max_decompressed_batch_bytes,json=maxDecompressedBatchBytes,proto3" json:"max_decompressed_batch_bytes,omitempty"`
 }
 
 func (x *EventInitialParameters) Reset() {
@@ -351,6 +577,167 @@ func (x *EventInitialParameters) GetBufferSize() uint32 {
 	return 0
 }
 
+func (x *EventInitialParameters) GetStrictOrdering() bool {
+	if x != nil {
+		return x.StrictOrdering
+	}
+	return false
+}
+
+func (x *EventInitialParameters) GetCatchUpTicks() uint32 {
+	if x != nil {
+		return x.CatchUpTicks
+	}
+	return 0
+}
+
+func (x *EventInitialParameters) GetAdaptiveBatching() bool {
+	if x != nil {
+		return x.AdaptiveBatching
+	}
+	return false
+}
+
+func (x *EventInitialParameters) GetMinBatchSize() uint32 {
+	if x != nil {
+		return x.MinBatchSize
+	}
+	return 0
+}
+
+func (x *EventInitialParameters) GetMaxBatchSize() uint32 {
+	if x != nil {
+		return x.MaxBatchSize
+	}
+	return 0
+}
+
+func (x *EventInitialParameters) GetMaxEpochJump() uint32 {
+	if x != nil {
+		return x.MaxEpochJump
+	}
+	return 0
+}
+
+func (x *EventInitialParameters) GetDisableForwarding() bool {
+	if x != nil {
+		return x.DisableForwarding
+	}
+	return false
+}
+
+func (x *EventInitialParameters) GetStrictHighWatermark() bool {
+	if x != nil {
+		return x.StrictHighWatermark
+	}
+	return false
+}
+
+func (x *EventInitialParameters) GetDisableSelfEcho() bool {
+	if x != nil {
+		return x.DisableSelfEcho
+	}
+	return false
+}
+
+func (x *EventInitialParameters) GetLaggingFollowerThreshold() uint32 {
+	if x != nil {
+		return x.LaggingFollowerThreshold
+	}
+	return 0
+}
+
+func (x *EventInitialParameters) GetMaxUnappliedCommits() uint32 {
+	if x != nil {
+		return x.MaxUnappliedCommits
+	}
+	return 0
+}
+
+func (x *EventInitialParameters) GetVerifyCommitDigests() bool {
+	if x != nil {
+		return x.VerifyCommitDigests
+	}
+	return false
+}
+
+func (x *EventInitialParameters) GetEpochChangeAlarmThreshold() uint32 {
+	if x != nil {
+		return x.EpochChangeAlarmThreshold
+	}
+	return 0
+}
+
+func (x *EventInitialParameters) GetEpochChangeAlarmWindowTicks() uint32 {
+	if x != nil {
+		return x.EpochChangeAlarmWindowTicks
+	}
+	return 0
+}
+
+func (x *EventInitialParameters) GetDeliverCommitsPastGaps() bool {
+	if x != nil {
+		return x.DeliverCommitsPastGaps
+	}
+	return false
+}
+
+func (x *EventInitialParameters) GetMaxBufferedEpochChanges() uint32 {
+	if x != nil {
+		return x.MaxBufferedEpochChanges
+	}
+	return 0
+}
+
+func (x *EventInitialParameters) GetAppliedCommitWatermark() uint64 {
+	if x != nil {
+		return x.AppliedCommitWatermark
+	}
+	return 0
+}
+
+func (x *EventInitialParameters) GetFutureEpochLookahead() uint32 {
+	if x != nil {
+		return x.FutureEpochLookahead
+	}
+	return 0
+}
+
+func (x *EventInitialParameters) GetEpochChangeQuorum() uint32 {
+	if x != nil {
+		return x.EpochChangeQuorum
+	}
+	return 0
+}
+
+func (x *EventInitialParameters) GetEpochJoinQuorum() uint32 {
+	if x != nil {
+		return x.EpochJoinQuorum
+	}
+	return 0
+}
+
+func (x *EventInitialParameters) GetDropDuringStateTransfer() bool {
+	if x != nil {
+		return x.DropDuringStateTransfer
+	}
+	return false
+}
+
+func (x *EventInitialParameters) GetCoalesceCommits() bool {
+	if x != nil {
+		return x.CoalesceCommits
+	}
+	return false
+}
+
+func (x *EventInitialParameters) GetMaxDecompressedBatchBytes() uint32 {
+	if x != nil {
+		return x.MaxDecompressedBatchBytes
+	}
+	return 0
+}
+
 type EventLoadPersistedEntry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -521,6 +908,17 @@ type EventRequestPersisted struct {
 	unknownFields protoimpl.UnknownFields
 
 	RequestAck *msgs.RequestAck `protobuf:"bytes,1,opt,name=request_ack,json=requestAck,proto3" json:"request_ack,omitempty"`
+	// reject_reason describes why preprocessing a rejected request failed.
+	// It is only informational, see rejected.
+	RejectReason string `protobuf:"bytes,2,opt,name=reject_reason,json=rejectReason,proto3" json:"reject_reason,omitempty"`
+	// forwarded is set if the rejected request was forwarded by source,
+	// which is then reported as misbehaving.
+	Forwarded bool   `protobuf:"varint,3,opt,name=forwarded,proto3" json:"forwarded,omitempty"`
+	Source    uint64 `protobuf:"varint,4,opt,name=source,proto3" json:"source,omitempty"`
+	// rejected indicates that preprocessing the request failed, and that
+	// it was not persisted.  A rejected request is never acked, nor
+	// proactively fetched, by this node.
+	Rejected bool `protobuf:"varint,5,opt,name=rejected,proto3" json:"rejected,omitempty"`
 }
 
 func (x *EventRequestPersisted) Reset() {
@@ -562,6 +960,34 @@ func (x *EventRequestPersisted) GetRequestAck() *msgs.RequestAck {
 	return nil
 }
 
+func (x *EventRequestPersisted) GetRejectReason() string {
+	if x != nil {
+		return x.RejectReason
+	}
+	return ""
+}
+
+func (x *EventRequestPersisted) GetForwarded() bool {
+	if x != nil {
+		return x.Forwarded
+	}
+	return false
+}
+
+func (x *EventRequestPersisted) GetSource() uint64 {
+	if x != nil {
+		return x.Source
+	}
+	return 0
+}
+
+func (x *EventRequestPersisted) GetRejected() bool {
+	if x != nil {
+		return x.Rejected
+	}
+	return false
+}
+
 type EventStateTransferComplete struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -782,6 +1208,7 @@ type HashOrigin struct {
 	//	*HashOrigin_Batch_
 	//	*HashOrigin_EpochChange_
 	//	*HashOrigin_VerifyBatch_
+	//	*HashOrigin_CheckpointRequest_
 	Type isHashOrigin_Type `protobuf_oneof:"type"`
 }
 
@@ -845,6 +1272,13 @@ func (x *HashOrigin) GetVerifyBatch() *HashOrigin_VerifyBatch {
 	return nil
 }
 
+func (x *HashOrigin) GetCheckpointRequest() *HashOrigin_CheckpointRequest {
+	if x, ok := x.GetType().(*HashOrigin_CheckpointRequest_); ok {
+		return x.CheckpointRequest
+	}
+	return nil
+}
+
 type isHashOrigin_Type interface {
 	isHashOrigin_Type()
 }
@@ -861,12 +1295,18 @@ type HashOrigin_VerifyBatch_ struct {
 	VerifyBatch *HashOrigin_VerifyBatch `protobuf:"bytes,3,opt,name=verify_batch,json=verifyBatch,proto3,oneof"`
 }
 
+type HashOrigin_CheckpointRequest_ struct {
+	CheckpointRequest *HashOrigin_CheckpointRequest `protobuf:"bytes,4,opt,name=checkpoint_request,json=checkpointRequest,proto3,oneof"`
+}
+
 func (*HashOrigin_Batch_) isHashOrigin_Type() {}
 
 func (*HashOrigin_EpochChange_) isHashOrigin_Type() {}
 
 func (*HashOrigin_VerifyBatch_) isHashOrigin_Type() {}
 
+func (*HashOrigin_CheckpointRequest_) isHashOrigin_Type() {}
+
 type EventHashResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -960,28 +1400,16 @@ func (*EventActionsReceived) Descriptor() ([]byte, []int) {
 	return file_state_state_proto_rawDescGZIP(), []int{12}
 }
 
-type Action struct {
+// EventCompact requests that the write-ahead log be truncated to the current
+// stable checkpoint, without waiting for the next checkpoint to stabilize.
+type EventCompact struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	// Types that are assignable to Type:
-	//	*Action_Send
-	//	*Action_Hash
-	//	*Action_AppendWriteAhead
-	//	*Action_TruncateWriteAhead
-	//	*Action_Commit
-	//	*Action_Checkpoint
-	//	*Action_AllocatedRequest
-	//	*Action_CorrectRequest
-	//	*Action_ForwardRequest
-	//	*Action_StateTransfer
-	//	*Action_StateApplied
-	Type isAction_Type `protobuf_oneof:"type"`
 }
 
-func (x *Action) Reset() {
-	*x = Action{}
+func (x *EventCompact) Reset() {
+	*x = EventCompact{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_state_state_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -989,13 +1417,13 @@ func (x *Action) Reset() {
 	}
 }
 
-func (x *Action) String() string {
+func (x *EventCompact) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Action) ProtoMessage() {}
+func (*EventCompact) ProtoMessage() {}
 
-func (x *Action) ProtoReflect() protoreflect.Message {
+func (x *EventCompact) ProtoReflect() protoreflect.Message {
 	mi := &file_state_state_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1007,191 +1435,1329 @@ func (x *Action) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Action.ProtoReflect.Descriptor instead.
-func (*Action) Descriptor() ([]byte, []int) {
+// Deprecated: Use EventCompact.ProtoReflect.Descriptor instead.
+func (*EventCompact) Descriptor() ([]byte, []int) {
 	return file_state_state_proto_rawDescGZIP(), []int{13}
 }
 
-func (m *Action) GetType() isAction_Type {
-	if m != nil {
-		return m.Type
-	}
-	return nil
+// EventStepDown requests that this node relinquish the leadership of its
+// buckets.  It stops proposing, and once its in-flight sequences commit,
+// suspects the epoch so that its buckets are handed to other leaders.
+type EventStepDown struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 }
 
-func (x *Action) GetSend() *ActionSend {
-	if x, ok := x.GetType().(*Action_Send); ok {
-		return x.Send
+func (x *EventStepDown) Reset() {
+	*x = EventStepDown{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_state_state_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *Action) GetHash() *ActionHashRequest {
-	if x, ok := x.GetType().(*Action_Hash); ok {
-		return x.Hash
-	}
-	return nil
+func (x *EventStepDown) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Action) GetAppendWriteAhead() *ActionWrite {
-	if x, ok := x.GetType().(*Action_AppendWriteAhead); ok {
-		return x.AppendWriteAhead
+func (*EventStepDown) ProtoMessage() {}
+
+func (x *EventStepDown) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Action) GetTruncateWriteAhead() *ActionTruncate {
-	if x, ok := x.GetType().(*Action_TruncateWriteAhead); ok {
-		return x.TruncateWriteAhead
-	}
-	return nil
+// Deprecated: Use EventStepDown.ProtoReflect.Descriptor instead.
+func (*EventStepDown) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *Action) GetCommit() *ActionCommit {
-	if x, ok := x.GetType().(*Action_Commit); ok {
+// EventAuditDigest requests the digest of the application state at an
+// already committed sequence number, for instance to compare it across nodes
+// and detect divergence.  The state machine emits an ActionAuditDigest for
+// the consumer to satisfy, or to fail if seq_no has not yet committed.
+type EventAuditDigest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SeqNo uint64 `protobuf:"varint,1,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"`
+}
+
+func (x *EventAuditDigest) Reset() {
+	*x = EventAuditDigest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_state_state_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventAuditDigest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventAuditDigest) ProtoMessage() {}
+
+func (x *EventAuditDigest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventAuditDigest.ProtoReflect.Descriptor instead.
+func (*EventAuditDigest) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *EventAuditDigest) GetSeqNo() uint64 {
+	if x != nil {
+		return x.SeqNo
+	}
+	return 0
+}
+
+// EventImportClientState imports the window state of a client, as exported
+// by another node, recording the pending requests it carries as persisted by
+// this node.  The consumer must therefore have persisted the data of these
+// requests beforehand, just as for EventRequestPersisted.  A client state
+// conflicting with the commits of this node is ignored.
+type EventImportClientState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientState *ClientState `protobuf:"bytes,1,opt,name=client_state,json=clientState,proto3" json:"client_state,omitempty"`
+}
+
+func (x *EventImportClientState) Reset() {
+	*x = EventImportClientState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_state_state_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventImportClientState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventImportClientState) ProtoMessage() {}
+
+func (x *EventImportClientState) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventImportClientState.ProtoReflect.Descriptor instead.
+func (*EventImportClientState) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *EventImportClientState) GetClientState() *ClientState {
+	if x != nil {
+		return x.ClientState
+	}
+	return nil
+}
+
+// EventResetMisbehavior clears the misbehavior count of a node, for instance
+// once an operator has investigated and repaired it.
+type EventResetMisbehavior struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NodeId uint64 `protobuf:"varint,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+}
+
+func (x *EventResetMisbehavior) Reset() {
+	*x = EventResetMisbehavior{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_state_state_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventResetMisbehavior) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventResetMisbehavior) ProtoMessage() {}
+
+func (x *EventResetMisbehavior) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventResetMisbehavior.ProtoReflect.Descriptor instead.
+func (*EventResetMisbehavior) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *EventResetMisbehavior) GetNodeId() uint64 {
+	if x != nil {
+		return x.NodeId
+	}
+	return 0
+}
+
+// EventRequestCheckpoint proposes a coordinated checkpoint: the next request
+// of the first of the CheckpointClients is acknowledged on behalf of the
+// operator, and once it commits, every node is asked for a checkpoint at the
+// same sequence.
+type EventRequestCheckpoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EventRequestCheckpoint) Reset() {
+	*x = EventRequestCheckpoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_state_state_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventRequestCheckpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventRequestCheckpoint) ProtoMessage() {}
+
+func (x *EventRequestCheckpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventRequestCheckpoint.ProtoReflect.Descriptor instead.
+func (*EventRequestCheckpoint) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{18}
+}
+
+// EventCommitsApplied acknowledges that the application has applied
+// all committed batches with sequence numbers in the inclusive range [from, to].
+type EventCommitsApplied struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From uint64 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   uint64 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *EventCommitsApplied) Reset() {
+	*x = EventCommitsApplied{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_state_state_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventCommitsApplied) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventCommitsApplied) ProtoMessage() {}
+
+func (x *EventCommitsApplied) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventCommitsApplied.ProtoReflect.Descriptor instead.
+func (*EventCommitsApplied) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *EventCommitsApplied) GetFrom() uint64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *EventCommitsApplied) GetTo() uint64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type Action struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Type:
+	//	*Action_Send
+	//	*Action_Hash
+	//	*Action_AppendWriteAhead
+	//	*Action_TruncateWriteAhead
+	//	*Action_Commit
+	//	*Action_Checkpoint
+	//	*Action_AllocatedRequest
+	//	*Action_CorrectRequest
+	//	*Action_ForwardRequest
+	//	*Action_StateTransfer
+	//	*Action_StateApplied
+	//	*Action_LeadershipChanged
+	//	*Action_Unrecoverable
+	//	*Action_AuditDigest
+	//	*Action_EpochInstability
+	//	*Action_ConfigMismatch
+	//	*Action_Misbehavior
+	//	*Action_PersistWatermark
+	//	*Action_EpochChanged
+	//	*Action_ClientStatePruned
+	//	*Action_CoordinatedCheckpoint
+	//	*Action_NetworkAsymmetry
+	Type isAction_Type `protobuf_oneof:"type"`
+}
+
+func (x *Action) Reset() {
+	*x = Action{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_state_state_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Action) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Action) ProtoMessage() {}
+
+func (x *Action) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Action.ProtoReflect.Descriptor instead.
+func (*Action) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{20}
+}
+
+func (m *Action) GetType() isAction_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (x *Action) GetSend() *ActionSend {
+	if x, ok := x.GetType().(*Action_Send); ok {
+		return x.Send
+	}
+	return nil
+}
+
+func (x *Action) GetHash() *ActionHashRequest {
+	if x, ok := x.GetType().(*Action_Hash); ok {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *Action) GetAppendWriteAhead() *ActionWrite {
+	if x, ok := x.GetType().(*Action_AppendWriteAhead); ok {
+		return x.AppendWriteAhead
+	}
+	return nil
+}
+
+func (x *Action) GetTruncateWriteAhead() *ActionTruncate {
+	if x, ok := x.GetType().(*Action_TruncateWriteAhead); ok {
+		return x.TruncateWriteAhead
+	}
+	return nil
+}
+
+func (x *Action) GetCommit() *ActionCommit {
+	if x, ok := x.GetType().(*Action_Commit); ok {
 		return x.Commit
 	}
-	return nil
+	return nil
+}
+
+func (x *Action) GetCheckpoint() *ActionCheckpoint {
+	if x, ok := x.GetType().(*Action_Checkpoint); ok {
+		return x.Checkpoint
+	}
+	return nil
+}
+
+func (x *Action) GetAllocatedRequest() *ActionRequestSlot {
+	if x, ok := x.GetType().(*Action_AllocatedRequest); ok {
+		return x.AllocatedRequest
+	}
+	return nil
+}
+
+func (x *Action) GetCorrectRequest() *msgs.RequestAck {
+	if x, ok := x.GetType().(*Action_CorrectRequest); ok {
+		return x.CorrectRequest
+	}
+	return nil
+}
+
+func (x *Action) GetForwardRequest() *ActionForward {
+	if x, ok := x.GetType().(*Action_ForwardRequest); ok {
+		return x.ForwardRequest
+	}
+	return nil
+}
+
+func (x *Action) GetStateTransfer() *ActionStateTarget {
+	if x, ok := x.GetType().(*Action_StateTransfer); ok {
+		return x.StateTransfer
+	}
+	return nil
+}
+
+func (x *Action) GetStateApplied() *ActionStateApplied {
+	if x, ok := x.GetType().(*Action_StateApplied); ok {
+		return x.StateApplied
+	}
+	return nil
+}
+
+func (x *Action) GetLeadershipChanged() *ActionLeadershipChanged {
+	if x, ok := x.GetType().(*Action_LeadershipChanged); ok {
+		return x.LeadershipChanged
+	}
+	return nil
+}
+
+func (x *Action) GetUnrecoverable() *ActionUnrecoverable {
+	if x, ok := x.GetType().(*Action_Unrecoverable); ok {
+		return x.Unrecoverable
+	}
+	return nil
+}
+
+func (x *Action) GetAuditDigest() *ActionAuditDigest {
+	if x, ok := x.GetType().(*Action_AuditDigest); ok {
+		return x.AuditDigest
+	}
+	return nil
+}
+
+func (x *Action) GetEpochInstability() *ActionEpochInstability {
+	if x, ok := x.GetType().(*Action_EpochInstability); ok {
+		return x.EpochInstability
+	}
+	return nil
+}
+
+func (x *Action) GetConfigMismatch() *ActionConfigMismatch {
+	if x, ok := x.GetType().(*Action_ConfigMismatch); ok {
+		return x.ConfigMismatch
+	}
+	return nil
+}
+
+func (x *Action) GetMisbehavior() *ActionMisbehavior {
+	if x, ok := x.GetType().(*Action_Misbehavior); ok {
+		return x.Misbehavior
+	}
+	return nil
+}
+
+func (x *Action) GetPersistWatermark() *ActionPersistWatermark {
+	if x, ok := x.GetType().(*Action_PersistWatermark); ok {
+		return x.PersistWatermark
+	}
+	return nil
+}
+
+func (x *Action) GetEpochChanged() *ActionEpochChanged {
+	if x, ok := x.GetType().(*Action_EpochChanged); ok {
+		return x.EpochChanged
+	}
+	return nil
+}
+
+func (x *Action) GetClientStatePruned() *ActionClientStatePruned {
+	if x, ok := x.GetType().(*Action_ClientStatePruned); ok {
+		return x.ClientStatePruned
+	}
+	return nil
+}
+
+func (x *Action) GetCoordinatedCheckpoint() *ActionCoordinatedCheckpoint {
+	if x, ok := x.GetType().(*Action_CoordinatedCheckpoint); ok {
+		return x.CoordinatedCheckpoint
+	}
+	return nil
+}
+
+func (x *Action) GetNetworkAsymmetry() *ActionNetworkAsymmetry {
+	if x, ok := x.GetType().(*Action_NetworkAsymmetry); ok {
+		return x.NetworkAsymmetry
+	}
+	return nil
+}
+
+type isAction_Type interface {
+	isAction_Type()
+}
+
+type Action_Send struct {
+	Send *ActionSend `protobuf:"bytes,1,opt,name=send,proto3,oneof"`
+}
+
+type Action_Hash struct {
+	Hash *ActionHashRequest `protobuf:"bytes,2,opt,name=hash,proto3,oneof"`
+}
+
+type Action_AppendWriteAhead struct {
+	AppendWriteAhead *ActionWrite `protobuf:"bytes,3,opt,name=append_write_ahead,json=appendWriteAhead,proto3,oneof"`
+}
+
+type Action_TruncateWriteAhead struct {
+	TruncateWriteAhead *ActionTruncate `protobuf:"bytes,4,opt,name=truncate_write_ahead,json=truncateWriteAhead,proto3,oneof"`
+}
+
+type Action_Commit struct {
+	Commit *ActionCommit `protobuf:"bytes,5,opt,name=commit,proto3,oneof"`
+}
+
+type Action_Checkpoint struct {
+	Checkpoint *ActionCheckpoint `protobuf:"bytes,6,opt,name=checkpoint,proto3,oneof"`
+}
+
+type Action_AllocatedRequest struct {
+	AllocatedRequest *ActionRequestSlot `protobuf:"bytes,7,opt,name=allocated_request,json=allocatedRequest,proto3,oneof"`
+}
+
+type Action_CorrectRequest struct {
+	CorrectRequest *msgs.RequestAck `protobuf:"bytes,8,opt,name=correct_request,json=correctRequest,proto3,oneof"`
+}
+
+type Action_ForwardRequest struct {
+	ForwardRequest *ActionForward `protobuf:"bytes,9,opt,name=forward_request,json=forwardRequest,proto3,oneof"`
+}
+
+type Action_StateTransfer struct {
+	StateTransfer *ActionStateTarget `protobuf:"bytes,10,opt,name=state_transfer,json=stateTransfer,proto3,oneof"`
+}
+
+type Action_StateApplied struct {
+	StateApplied *ActionStateApplied `protobuf:"bytes,11,opt,name=state_applied,json=stateApplied,proto3,oneof"`
+}
+
+type Action_LeadershipChanged struct {
+	LeadershipChanged *ActionLeadershipChanged `protobuf:"bytes,12,opt,name=leadership_changed,json=leadershipChanged,proto3,oneof"`
+}
+
+type Action_Unrecoverable struct {
+	Unrecoverable *ActionUnrecoverable `protobuf:"bytes,13,opt,name=unrecoverable,proto3,oneof"`
+}
+
+type Action_AuditDigest struct {
+	AuditDigest *ActionAuditDigest `protobuf:"bytes,14,opt,name=audit_digest,json=auditDigest,proto3,oneof"`
+}
+
+type Action_EpochInstability struct {
+	EpochInstability *ActionEpochInstability `protobuf:"bytes,15,opt,name=epoch_instability,json=epochInstability,proto3,oneof"`
+}
+
+type Action_ConfigMismatch struct {
+	ConfigMismatch *ActionConfigMismatch `protobuf:"bytes,16,opt,name=config_mismatch,json=configMismatch,proto3,oneof"`
+}
+
+type Action_Misbehavior struct {
+	Misbehavior *ActionMisbehavior `protobuf:"bytes,17,opt,name=misbehavior,proto3,oneof"`
+}
+
+type Action_PersistWatermark struct {
+	PersistWatermark *ActionPersistWatermark `protobuf:"bytes,18,opt,name=persist_watermark,json=persistWatermark,proto3,oneof"`
+}
+
+type Action_EpochChanged struct {
+	EpochChanged *ActionEpochChanged `protobuf:"bytes,19,opt,name=epoch_changed,json=epochChanged,proto3,oneof"`
+}
+
+type Action_ClientStatePruned struct {
+	ClientStatePruned *ActionClientStatePruned `protobuf:"bytes,20,opt,name=client_state_pruned,json=clientStatePruned,proto3,oneof"`
+}
+
+type Action_CoordinatedCheckpoint struct {
+	CoordinatedCheckpoint *ActionCoordinatedCheckpoint `protobuf:"bytes,21,opt,name=coordinated_checkpoint,json=coordinatedCheckpoint,proto3,oneof"`
+}
+
+type Action_NetworkAsymmetry struct {
+	NetworkAsymmetry *ActionNetworkAsymmetry `protobuf:"bytes,22,opt,name=network_asymmetry,json=networkAsymmetry,proto3,oneof"`
+}
+
+func (*Action_Send) isAction_Type() {}
+
+func (*Action_Hash) isAction_Type() {}
+
+func (*Action_AppendWriteAhead) isAction_Type() {}
+
+func (*Action_TruncateWriteAhead) isAction_Type() {}
+
+func (*Action_Commit) isAction_Type() {}
+
+func (*Action_Checkpoint) isAction_Type() {}
+
+func (*Action_AllocatedRequest) isAction_Type() {}
+
+func (*Action_CorrectRequest) isAction_Type() {}
+
+func (*Action_ForwardRequest) isAction_Type() {}
+
+func (*Action_StateTransfer) isAction_Type() {}
+
+func (*Action_StateApplied) isAction_Type() {}
+
+func (*Action_LeadershipChanged) isAction_Type() {}
+
+func (*Action_Unrecoverable) isAction_Type() {}
+
+func (*Action_AuditDigest) isAction_Type() {}
+
+func (*Action_EpochInstability) isAction_Type() {}
+
+func (*Action_ConfigMismatch) isAction_Type() {}
+
+func (*Action_Misbehavior) isAction_Type() {}
+
+func (*Action_PersistWatermark) isAction_Type() {}
+
+func (*Action_EpochChanged) isAction_Type() {}
+
+func (*Action_ClientStatePruned) isAction_Type() {}
+
+func (*Action_CoordinatedCheckpoint) isAction_Type() {}
+
+func (*Action_NetworkAsymmetry) isAction_Type() {}
+
+// ActionAuditDigest asks the consumer for the digest of the application
+// state at the committed sequence seq_no, as requested by an EventAuditDigest.
+// Unlike a checkpoint, the digest is returned to the requester rather than
+// to the state machine.  If seq_no has not yet committed, uncommitted is set,
+// and the consumer reports the failure to the requester instead.
+type ActionAuditDigest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SeqNo       uint64 `protobuf:"varint,1,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"`
+	Uncommitted bool   `protobuf:"varint,2,opt,name=uncommitted,proto3" json:"uncommitted,omitempty"`
+}
+
+func (x *ActionAuditDigest) Reset() {
+	*x = ActionAuditDigest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_state_state_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionAuditDigest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionAuditDigest) ProtoMessage() {}
+
+func (x *ActionAuditDigest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionAuditDigest.ProtoReflect.Descriptor instead.
+func (*ActionAuditDigest) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ActionAuditDigest) GetSeqNo() uint64 {
+	if x != nil {
+		return x.SeqNo
+	}
+	return 0
+}
+
+func (x *ActionAuditDigest) GetUncommitted() bool {
+	if x != nil {
+		return x.Uncommitted
+	}
+	return false
+}
+
+// ActionCoordinatedCheckpoint asks the consumer to checkpoint the application
+// state at the committed sequence seq_no, once it has applied every batch
+// through it, as a request from one of the checkpoint clients of the network
+// committed at seq_no.  Every node is asked at the same sequence, unlike for
+// the periodic Checkpoint actions, whose results are returned to the state
+// machine, and unlike for AuditDigest actions, which are local to a node.
+type ActionCoordinatedCheckpoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SeqNo uint64 `protobuf:"varint,1,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"`
+}
+
+func (x *ActionCoordinatedCheckpoint) Reset() {
+	*x = ActionCoordinatedCheckpoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_state_state_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionCoordinatedCheckpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionCoordinatedCheckpoint) ProtoMessage() {}
+
+func (x *ActionCoordinatedCheckpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionCoordinatedCheckpoint.ProtoReflect.Descriptor instead.
+func (*ActionCoordinatedCheckpoint) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ActionCoordinatedCheckpoint) GetSeqNo() uint64 {
+	if x != nil {
+		return x.SeqNo
+	}
+	return 0
+}
+
+type ActionSend struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Targets []uint64  `protobuf:"varint,1,rep,packed,name=targets,proto3" json:"targets,omitempty"`
+	Msg     *msgs.Msg `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *ActionSend) Reset() {
+	*x = ActionSend{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_state_state_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionSend) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionSend) ProtoMessage() {}
+
+func (x *ActionSend) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionSend.ProtoReflect.Descriptor instead.
+func (*ActionSend) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ActionSend) GetTargets() []uint64 {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *ActionSend) GetMsg() *msgs.Msg {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+type ActionTruncate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index uint64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (x *ActionTruncate) Reset() {
+	*x = ActionTruncate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_state_state_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionTruncate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionTruncate) ProtoMessage() {}
+
+func (x *ActionTruncate) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionTruncate.ProtoReflect.Descriptor instead.
+func (*ActionTruncate) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ActionTruncate) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type ActionWrite struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index uint64           `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Data  *msgs.Persistent `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *ActionWrite) Reset() {
+	*x = ActionWrite{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_state_state_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionWrite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionWrite) ProtoMessage() {}
+
+func (x *ActionWrite) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionWrite.ProtoReflect.Descriptor instead.
+func (*ActionWrite) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ActionWrite) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ActionWrite) GetData() *msgs.Persistent {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ActionCommit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Batch *msgs.QEntry `protobuf:"bytes,1,opt,name=batch,proto3" json:"batch,omitempty"`
+	// ConfigChanges are the requests of the batch which were submitted
+	// by a configuration client, in the order they appear in the batch.
+	ConfigChanges []*msgs.RequestAck `protobuf:"bytes,2,rep,name=config_changes,json=configChanges,proto3" json:"config_changes,omitempty"`
+	// gaps lists the sequences below that of the batch which had not yet
+	// committed when the batch was delivered, which only happens when
+	// deliver_commits_past_gaps is set.
+	Gaps []uint64 `protobuf:"varint,3,rep,packed,name=gaps,proto3" json:"gaps,omitempty"`
+}
+
+func (x *ActionCommit) Reset() {
+	*x = ActionCommit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_state_state_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionCommit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionCommit) ProtoMessage() {}
+
+func (x *ActionCommit) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionCommit.ProtoReflect.Descriptor instead.
+func (*ActionCommit) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ActionCommit) GetBatch() *msgs.QEntry {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+func (x *ActionCommit) GetConfigChanges() []*msgs.RequestAck {
+	if x != nil {
+		return x.ConfigChanges
+	}
+	return nil
+}
+
+func (x *ActionCommit) GetGaps() []uint64 {
+	if x != nil {
+		return x.Gaps
+	}
+	return nil
+}
+
+type ActionCheckpoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SeqNo         uint64                      `protobuf:"varint,2,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"`
+	NetworkConfig *msgs.NetworkState_Config   `protobuf:"bytes,3,opt,name=network_config,json=networkConfig,proto3" json:"network_config,omitempty"`
+	ClientStates  []*msgs.NetworkState_Client `protobuf:"bytes,4,rep,name=client_states,json=clientStates,proto3" json:"client_states,omitempty"`
+}
+
+func (x *ActionCheckpoint) Reset() {
+	*x = ActionCheckpoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_state_state_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionCheckpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionCheckpoint) ProtoMessage() {}
+
+func (x *ActionCheckpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *Action) GetCheckpoint() *ActionCheckpoint {
-	if x, ok := x.GetType().(*Action_Checkpoint); ok {
-		return x.Checkpoint
+// Deprecated: Use ActionCheckpoint.ProtoReflect.Descriptor instead.
+func (*ActionCheckpoint) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ActionCheckpoint) GetSeqNo() uint64 {
+	if x != nil {
+		return x.SeqNo
 	}
-	return nil
+	return 0
 }
 
-func (x *Action) GetAllocatedRequest() *ActionRequestSlot {
-	if x, ok := x.GetType().(*Action_AllocatedRequest); ok {
-		return x.AllocatedRequest
+func (x *ActionCheckpoint) GetNetworkConfig() *msgs.NetworkState_Config {
+	if x != nil {
+		return x.NetworkConfig
 	}
 	return nil
 }
 
-func (x *Action) GetCorrectRequest() *msgs.RequestAck {
-	if x, ok := x.GetType().(*Action_CorrectRequest); ok {
-		return x.CorrectRequest
+func (x *ActionCheckpoint) GetClientStates() []*msgs.NetworkState_Client {
+	if x != nil {
+		return x.ClientStates
 	}
 	return nil
 }
 
-func (x *Action) GetForwardRequest() *ActionForward {
-	if x, ok := x.GetType().(*Action_ForwardRequest); ok {
-		return x.ForwardRequest
+type ActionRequestSlot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId uint64 `protobuf:"varint,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ReqNo    uint64 `protobuf:"varint,2,opt,name=req_no,json=reqNo,proto3" json:"req_no,omitempty"`
+}
+
+func (x *ActionRequestSlot) Reset() {
+	*x = ActionRequestSlot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_state_state_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *Action) GetStateTransfer() *ActionStateTarget {
-	if x, ok := x.GetType().(*Action_StateTransfer); ok {
-		return x.StateTransfer
+func (x *ActionRequestSlot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionRequestSlot) ProtoMessage() {}
+
+func (x *ActionRequestSlot) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Action) GetStateApplied() *ActionStateApplied {
-	if x, ok := x.GetType().(*Action_StateApplied); ok {
-		return x.StateApplied
+// Deprecated: Use ActionRequestSlot.ProtoReflect.Descriptor instead.
+func (*ActionRequestSlot) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ActionRequestSlot) GetClientId() uint64 {
+	if x != nil {
+		return x.ClientId
 	}
-	return nil
+	return 0
 }
 
-type isAction_Type interface {
-	isAction_Type()
+func (x *ActionRequestSlot) GetReqNo() uint64 {
+	if x != nil {
+		return x.ReqNo
+	}
+	return 0
 }
 
-type Action_Send struct {
-	Send *ActionSend `protobuf:"bytes,1,opt,name=send,proto3,oneof"`
+// ActionForward requests that the data of the acknowledged requests be
+// forwarded to the targets.  The requests are sent together, in a single
+// msgs.ForwardRequests message, rather than as one message per request.
+type ActionForward struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Targets []uint64           `protobuf:"varint,1,rep,packed,name=targets,proto3" json:"targets,omitempty"`
+	Acks    []*msgs.RequestAck `protobuf:"bytes,3,rep,name=acks,proto3" json:"acks,omitempty"`
 }
 
-type Action_Hash struct {
-	Hash *ActionHashRequest `protobuf:"bytes,2,opt,name=hash,proto3,oneof"`
+func (x *ActionForward) Reset() {
+	*x = ActionForward{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_state_state_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-type Action_AppendWriteAhead struct {
-	AppendWriteAhead *ActionWrite `protobuf:"bytes,3,opt,name=append_write_ahead,json=appendWriteAhead,proto3,oneof"`
+func (x *ActionForward) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type Action_TruncateWriteAhead struct {
-	TruncateWriteAhead *ActionTruncate `protobuf:"bytes,4,opt,name=truncate_write_ahead,json=truncateWriteAhead,proto3,oneof"`
+func (*ActionForward) ProtoMessage() {}
+
+func (x *ActionForward) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type Action_Commit struct {
-	Commit *ActionCommit `protobuf:"bytes,5,opt,name=commit,proto3,oneof"`
+// Deprecated: Use ActionForward.ProtoReflect.Descriptor instead.
+func (*ActionForward) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{29}
 }
 
-type Action_Checkpoint struct {
-	Checkpoint *ActionCheckpoint `protobuf:"bytes,6,opt,name=checkpoint,proto3,oneof"`
+func (x *ActionForward) GetTargets() []uint64 {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
 }
 
-type Action_AllocatedRequest struct {
-	AllocatedRequest *ActionRequestSlot `protobuf:"bytes,7,opt,name=allocated_request,json=allocatedRequest,proto3,oneof"`
+func (x *ActionForward) GetAcks() []*msgs.RequestAck {
+	if x != nil {
+		return x.Acks
+	}
+	return nil
 }
 
-type Action_CorrectRequest struct {
-	CorrectRequest *msgs.RequestAck `protobuf:"bytes,8,opt,name=correct_request,json=correctRequest,proto3,oneof"`
+type ActionStateApplied struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SeqNo        uint64             `protobuf:"varint,1,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"`
+	NetworkState *msgs.NetworkState `protobuf:"bytes,2,opt,name=network_state,json=networkState,proto3" json:"network_state,omitempty"`
 }
 
-type Action_ForwardRequest struct {
-	ForwardRequest *ActionForward `protobuf:"bytes,9,opt,name=forward_request,json=forwardRequest,proto3,oneof"`
+func (x *ActionStateApplied) Reset() {
+	*x = ActionStateApplied{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_state_state_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-type Action_StateTransfer struct {
-	StateTransfer *ActionStateTarget `protobuf:"bytes,10,opt,name=state_transfer,json=stateTransfer,proto3,oneof"`
+func (x *ActionStateApplied) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type Action_StateApplied struct {
-	StateApplied *ActionStateApplied `protobuf:"bytes,11,opt,name=state_applied,json=stateApplied,proto3,oneof"`
+func (*ActionStateApplied) ProtoMessage() {}
+
+func (x *ActionStateApplied) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (*Action_Send) isAction_Type() {}
+// Deprecated: Use ActionStateApplied.ProtoReflect.Descriptor instead.
+func (*ActionStateApplied) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{30}
+}
 
-func (*Action_Hash) isAction_Type() {}
+func (x *ActionStateApplied) GetSeqNo() uint64 {
+	if x != nil {
+		return x.SeqNo
+	}
+	return 0
+}
 
-func (*Action_AppendWriteAhead) isAction_Type() {}
+func (x *ActionStateApplied) GetNetworkState() *msgs.NetworkState {
+	if x != nil {
+		return x.NetworkState
+	}
+	return nil
+}
 
-func (*Action_TruncateWriteAhead) isAction_Type() {}
+// ActionLeadershipChanged reports the buckets this node started
+// and stopped leading when the given epoch became active.
+type ActionLeadershipChanged struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (*Action_Commit) isAction_Type() {}
+	Epoch         uint64   `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	GainedBuckets []uint64 `protobuf:"varint,2,rep,packed,name=gained_buckets,json=gainedBuckets,proto3" json:"gained_buckets,omitempty"`
+	LostBuckets   []uint64 `protobuf:"varint,3,rep,packed,name=lost_buckets,json=lostBuckets,proto3" json:"lost_buckets,omitempty"`
+}
 
-func (*Action_Checkpoint) isAction_Type() {}
+func (x *ActionLeadershipChanged) Reset() {
+	*x = ActionLeadershipChanged{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_state_state_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func (*Action_AllocatedRequest) isAction_Type() {}
+func (x *ActionLeadershipChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func (*Action_CorrectRequest) isAction_Type() {}
+func (*ActionLeadershipChanged) ProtoMessage() {}
 
-func (*Action_ForwardRequest) isAction_Type() {}
+func (x *ActionLeadershipChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (*Action_StateTransfer) isAction_Type() {}
+// Deprecated: Use ActionLeadershipChanged.ProtoReflect.Descriptor instead.
+func (*ActionLeadershipChanged) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{31}
+}
 
-func (*Action_StateApplied) isAction_Type() {}
+func (x *ActionLeadershipChanged) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
 
-type ActionSend struct {
+func (x *ActionLeadershipChanged) GetGainedBuckets() []uint64 {
+	if x != nil {
+		return x.GainedBuckets
+	}
+	return nil
+}
+
+func (x *ActionLeadershipChanged) GetLostBuckets() []uint64 {
+	if x != nil {
+		return x.LostBuckets
+	}
+	return nil
+}
+
+// ActionEpochInstability reports that this node began epoch_changes
+// epoch changes, the latest of them to epoch, within the last window_ticks
+// ticks, reaching the configured epoch change alarm threshold.  It is only
+// reported again once the rate of epoch changes has dropped below the
+// threshold in between.
+type ActionEpochInstability struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Targets []uint64  `protobuf:"varint,1,rep,packed,name=targets,proto3" json:"targets,omitempty"`
-	Msg     *msgs.Msg `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Epoch        uint64 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	EpochChanges uint32 `protobuf:"varint,2,opt,name=epoch_changes,json=epochChanges,proto3" json:"epoch_changes,omitempty"`
+	WindowTicks  uint32 `protobuf:"varint,3,opt,name=window_ticks,json=windowTicks,proto3" json:"window_ticks,omitempty"`
 }
 
-func (x *ActionSend) Reset() {
-	*x = ActionSend{}
+func (x *ActionEpochInstability) Reset() {
+	*x = ActionEpochInstability{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_state_state_proto_msgTypes[14]
+		mi := &file_state_state_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ActionSend) String() string {
+func (x *ActionEpochInstability) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ActionSend) ProtoMessage() {}
+func (*ActionEpochInstability) ProtoMessage() {}
 
-func (x *ActionSend) ProtoReflect() protoreflect.Message {
-	mi := &file_state_state_proto_msgTypes[14]
+func (x *ActionEpochInstability) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1202,50 +2768,67 @@ func (x *ActionSend) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ActionSend.ProtoReflect.Descriptor instead.
-func (*ActionSend) Descriptor() ([]byte, []int) {
-	return file_state_state_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ActionEpochInstability.ProtoReflect.Descriptor instead.
+func (*ActionEpochInstability) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *ActionSend) GetTargets() []uint64 {
+func (x *ActionEpochInstability) GetEpoch() uint64 {
 	if x != nil {
-		return x.Targets
+		return x.Epoch
 	}
-	return nil
+	return 0
 }
 
-func (x *ActionSend) GetMsg() *msgs.Msg {
+func (x *ActionEpochInstability) GetEpochChanges() uint32 {
 	if x != nil {
-		return x.Msg
+		return x.EpochChanges
 	}
-	return nil
+	return 0
 }
 
-type ActionTruncate struct {
+func (x *ActionEpochInstability) GetWindowTicks() uint32 {
+	if x != nil {
+		return x.WindowTicks
+	}
+	return 0
+}
+
+// ActionEpochChanged reports that this node abandoned previous_epoch and
+// began an epoch change to epoch.  The reason is one of "expired", when the
+// previous epoch reached its planned expiration, "timeout", when this node
+// suspected it on its own, "suspicion", when this node only joined the
+// suspicions of others, "step_down", when a leader stepped down, or
+// "catch_up", when f+1 nodes referenced a later epoch.  Epoch changes resumed
+// when the state machine is reinitialized, as after a restart or a
+// reconfiguration, are not reported.
+type ActionEpochChanged struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Index uint64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Epoch         uint64 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	PreviousEpoch uint64 `protobuf:"varint,2,opt,name=previous_epoch,json=previousEpoch,proto3" json:"previous_epoch,omitempty"`
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *ActionTruncate) Reset() {
-	*x = ActionTruncate{}
+func (x *ActionEpochChanged) Reset() {
+	*x = ActionEpochChanged{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_state_state_proto_msgTypes[15]
+		mi := &file_state_state_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ActionTruncate) String() string {
+func (x *ActionEpochChanged) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ActionTruncate) ProtoMessage() {}
+func (*ActionEpochChanged) ProtoMessage() {}
 
-func (x *ActionTruncate) ProtoReflect() protoreflect.Message {
-	mi := &file_state_state_proto_msgTypes[15]
+func (x *ActionEpochChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1256,44 +2839,64 @@ func (x *ActionTruncate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ActionTruncate.ProtoReflect.Descriptor instead.
-func (*ActionTruncate) Descriptor() ([]byte, []int) {
-	return file_state_state_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ActionEpochChanged.ProtoReflect.Descriptor instead.
+func (*ActionEpochChanged) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *ActionTruncate) GetIndex() uint64 {
+func (x *ActionEpochChanged) GetEpoch() uint64 {
 	if x != nil {
-		return x.Index
+		return x.Epoch
 	}
 	return 0
 }
 
-type ActionWrite struct {
+func (x *ActionEpochChanged) GetPreviousEpoch() uint64 {
+	if x != nil {
+		return x.PreviousEpoch
+	}
+	return 0
+}
+
+func (x *ActionEpochChanged) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// ActionNetworkAsymmetry warns that, in epoch, the listed peers formed
+// commit quorums among themselves, but none of them prepared or committed
+// the sequences this node allocated in the buckets it leads.  As this node
+// receives their messages, but its own do not seem to reach them, the
+// connectivity between this node and its peers is likely one way.  It is
+// reported at most once per epoch.
+type ActionNetworkAsymmetry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Index uint64           `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
-	Data  *msgs.Persistent `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Epoch uint64   `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Peers []uint64 `protobuf:"varint,2,rep,packed,name=peers,proto3" json:"peers,omitempty"`
 }
 
-func (x *ActionWrite) Reset() {
-	*x = ActionWrite{}
+func (x *ActionNetworkAsymmetry) Reset() {
+	*x = ActionNetworkAsymmetry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_state_state_proto_msgTypes[16]
+		mi := &file_state_state_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ActionWrite) String() string {
+func (x *ActionNetworkAsymmetry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ActionWrite) ProtoMessage() {}
+func (*ActionNetworkAsymmetry) ProtoMessage() {}
 
-func (x *ActionWrite) ProtoReflect() protoreflect.Message {
-	mi := &file_state_state_proto_msgTypes[16]
+func (x *ActionNetworkAsymmetry) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1304,50 +2907,56 @@ func (x *ActionWrite) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ActionWrite.ProtoReflect.Descriptor instead.
-func (*ActionWrite) Descriptor() ([]byte, []int) {
-	return file_state_state_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use ActionNetworkAsymmetry.ProtoReflect.Descriptor instead.
+func (*ActionNetworkAsymmetry) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *ActionWrite) GetIndex() uint64 {
+func (x *ActionNetworkAsymmetry) GetEpoch() uint64 {
 	if x != nil {
-		return x.Index
+		return x.Epoch
 	}
 	return 0
 }
 
-func (x *ActionWrite) GetData() *msgs.Persistent {
+func (x *ActionNetworkAsymmetry) GetPeers() []uint64 {
 	if x != nil {
-		return x.Data
+		return x.Peers
 	}
 	return nil
 }
 
-type ActionCommit struct {
+// ActionClientStatePruned reports that the checkpoint at seq_no became
+// stable, and that the state of the listed clients was garbage collected
+// below their low watermarks as of it.  The requests below these low
+// watermarks have committed, and will not be referenced again, so the
+// consumer may, for instance, clean its caches of responses to them.
+type ActionClientStatePruned struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Batch *msgs.QEntry `protobuf:"bytes,1,opt,name=batch,proto3" json:"batch,omitempty"`
+	SeqNo   uint64                      `protobuf:"varint,1,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"`
+	Clients []*msgs.NetworkState_Client `protobuf:"bytes,2,rep,name=clients,proto3" json:"clients,omitempty"`
 }
 
-func (x *ActionCommit) Reset() {
-	*x = ActionCommit{}
+func (x *ActionClientStatePruned) Reset() {
+	*x = ActionClientStatePruned{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_state_state_proto_msgTypes[17]
+		mi := &file_state_state_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ActionCommit) String() string {
+func (x *ActionClientStatePruned) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ActionCommit) ProtoMessage() {}
+func (*ActionClientStatePruned) ProtoMessage() {}
 
-func (x *ActionCommit) ProtoReflect() protoreflect.Message {
-	mi := &file_state_state_proto_msgTypes[17]
+func (x *ActionClientStatePruned) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1358,45 +2967,57 @@ func (x *ActionCommit) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ActionCommit.ProtoReflect.Descriptor instead.
-func (*ActionCommit) Descriptor() ([]byte, []int) {
-	return file_state_state_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ActionClientStatePruned.ProtoReflect.Descriptor instead.
+func (*ActionClientStatePruned) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *ActionCommit) GetBatch() *msgs.QEntry {
+func (x *ActionClientStatePruned) GetSeqNo() uint64 {
 	if x != nil {
-		return x.Batch
+		return x.SeqNo
+	}
+	return 0
+}
+
+func (x *ActionClientStatePruned) GetClients() []*msgs.NetworkState_Client {
+	if x != nil {
+		return x.Clients
 	}
 	return nil
 }
 
-type ActionCheckpoint struct {
+// ActionConfigMismatch reports that node_id announced a network
+// configuration for the checkpoint at seq_no which differs from this node's,
+// as happens when nodes are deployed with differing genesis configurations.
+// The messages of node_id are ignored until it announces a matching
+// configuration.
+type ActionConfigMismatch struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SeqNo         uint64                      `protobuf:"varint,2,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"`
-	NetworkConfig *msgs.NetworkState_Config   `protobuf:"bytes,3,opt,name=network_config,json=networkConfig,proto3" json:"network_config,omitempty"`
-	ClientStates  []*msgs.NetworkState_Client `protobuf:"bytes,4,rep,name=client_states,json=clientStates,proto3" json:"client_states,omitempty"`
+	NodeId uint64 `protobuf:"varint,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	SeqNo  uint64 `protobuf:"varint,2,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"`
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *ActionCheckpoint) Reset() {
-	*x = ActionCheckpoint{}
+func (x *ActionConfigMismatch) Reset() {
+	*x = ActionConfigMismatch{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_state_state_proto_msgTypes[18]
+		mi := &file_state_state_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ActionCheckpoint) String() string {
+func (x *ActionConfigMismatch) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ActionCheckpoint) ProtoMessage() {}
+func (*ActionConfigMismatch) ProtoMessage() {}
 
-func (x *ActionCheckpoint) ProtoReflect() protoreflect.Message {
-	mi := &file_state_state_proto_msgTypes[18]
+func (x *ActionConfigMismatch) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1407,58 +3028,60 @@ func (x *ActionCheckpoint) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ActionCheckpoint.ProtoReflect.Descriptor instead.
-func (*ActionCheckpoint) Descriptor() ([]byte, []int) {
-	return file_state_state_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ActionConfigMismatch.ProtoReflect.Descriptor instead.
+func (*ActionConfigMismatch) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *ActionCheckpoint) GetSeqNo() uint64 {
+func (x *ActionConfigMismatch) GetNodeId() uint64 {
 	if x != nil {
-		return x.SeqNo
+		return x.NodeId
 	}
 	return 0
 }
 
-func (x *ActionCheckpoint) GetNetworkConfig() *msgs.NetworkState_Config {
+func (x *ActionConfigMismatch) GetSeqNo() uint64 {
 	if x != nil {
-		return x.NetworkConfig
+		return x.SeqNo
 	}
-	return nil
+	return 0
 }
 
-func (x *ActionCheckpoint) GetClientStates() []*msgs.NetworkState_Client {
+func (x *ActionConfigMismatch) GetReason() string {
 	if x != nil {
-		return x.ClientStates
+		return x.Reason
 	}
-	return nil
+	return ""
 }
 
-type ActionRequestSlot struct {
+// ActionMisbehavior reports that node_id sent messages which a correct
+// node would not, such as a flood of epoch change messages.
+type ActionMisbehavior struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ClientId uint64 `protobuf:"varint,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	ReqNo    uint64 `protobuf:"varint,2,opt,name=req_no,json=reqNo,proto3" json:"req_no,omitempty"`
+	NodeId uint64 `protobuf:"varint,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *ActionRequestSlot) Reset() {
-	*x = ActionRequestSlot{}
+func (x *ActionMisbehavior) Reset() {
+	*x = ActionMisbehavior{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_state_state_proto_msgTypes[19]
+		mi := &file_state_state_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ActionRequestSlot) String() string {
+func (x *ActionMisbehavior) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ActionRequestSlot) ProtoMessage() {}
+func (*ActionMisbehavior) ProtoMessage() {}
 
-func (x *ActionRequestSlot) ProtoReflect() protoreflect.Message {
-	mi := &file_state_state_proto_msgTypes[19]
+func (x *ActionMisbehavior) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1469,51 +3092,53 @@ func (x *ActionRequestSlot) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ActionRequestSlot.ProtoReflect.Descriptor instead.
-func (*ActionRequestSlot) Descriptor() ([]byte, []int) {
-	return file_state_state_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use ActionMisbehavior.ProtoReflect.Descriptor instead.
+func (*ActionMisbehavior) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *ActionRequestSlot) GetClientId() uint64 {
+func (x *ActionMisbehavior) GetNodeId() uint64 {
 	if x != nil {
-		return x.ClientId
+		return x.NodeId
 	}
 	return 0
 }
 
-func (x *ActionRequestSlot) GetReqNo() uint64 {
+func (x *ActionMisbehavior) GetReason() string {
 	if x != nil {
-		return x.ReqNo
+		return x.Reason
 	}
-	return 0
+	return ""
 }
 
-type ActionForward struct {
+// ActionPersistWatermark reports that the application has acknowledged
+// applying every commit through seq_no.  The consumer should store seq_no
+// and pass it back as the applied_commit_watermark when the node restarts.
+type ActionPersistWatermark struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Targets []uint64         `protobuf:"varint,1,rep,packed,name=targets,proto3" json:"targets,omitempty"`
-	Ack     *msgs.RequestAck `protobuf:"bytes,2,opt,name=ack,proto3" json:"ack,omitempty"`
+	SeqNo uint64 `protobuf:"varint,1,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"`
 }
 
-func (x *ActionForward) Reset() {
-	*x = ActionForward{}
+func (x *ActionPersistWatermark) Reset() {
+	*x = ActionPersistWatermark{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_state_state_proto_msgTypes[20]
+		mi := &file_state_state_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ActionForward) String() string {
+func (x *ActionPersistWatermark) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ActionForward) ProtoMessage() {}
+func (*ActionPersistWatermark) ProtoMessage() {}
 
-func (x *ActionForward) ProtoReflect() protoreflect.Message {
-	mi := &file_state_state_proto_msgTypes[20]
+func (x *ActionPersistWatermark) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1524,51 +3149,46 @@ func (x *ActionForward) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ActionForward.ProtoReflect.Descriptor instead.
-func (*ActionForward) Descriptor() ([]byte, []int) {
-	return file_state_state_proto_rawDescGZIP(), []int{20}
-}
-
-func (x *ActionForward) GetTargets() []uint64 {
-	if x != nil {
-		return x.Targets
-	}
-	return nil
+// Deprecated: Use ActionPersistWatermark.ProtoReflect.Descriptor instead.
+func (*ActionPersistWatermark) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *ActionForward) GetAck() *msgs.RequestAck {
+func (x *ActionPersistWatermark) GetSeqNo() uint64 {
 	if x != nil {
-		return x.Ack
+		return x.SeqNo
 	}
-	return nil
+	return 0
 }
 
-type ActionStateApplied struct {
+// ActionUnrecoverable reports that the state machine has reached a
+// state from which it cannot make progress, such as a network
+// configuration with too few nodes to tolerate its configured faults.
+type ActionUnrecoverable struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SeqNo        uint64             `protobuf:"varint,1,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"`
-	NetworkState *msgs.NetworkState `protobuf:"bytes,2,opt,name=network_state,json=networkState,proto3" json:"network_state,omitempty"`
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *ActionStateApplied) Reset() {
-	*x = ActionStateApplied{}
+func (x *ActionUnrecoverable) Reset() {
+	*x = ActionUnrecoverable{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_state_state_proto_msgTypes[21]
+		mi := &file_state_state_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ActionStateApplied) String() string {
+func (x *ActionUnrecoverable) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ActionStateApplied) ProtoMessage() {}
+func (*ActionUnrecoverable) ProtoMessage() {}
 
-func (x *ActionStateApplied) ProtoReflect() protoreflect.Message {
-	mi := &file_state_state_proto_msgTypes[21]
+func (x *ActionUnrecoverable) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1579,23 +3199,16 @@ func (x *ActionStateApplied) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ActionStateApplied.ProtoReflect.Descriptor instead.
-func (*ActionStateApplied) Descriptor() ([]byte, []int) {
-	return file_state_state_proto_rawDescGZIP(), []int{21}
-}
-
-func (x *ActionStateApplied) GetSeqNo() uint64 {
-	if x != nil {
-		return x.SeqNo
-	}
-	return 0
+// Deprecated: Use ActionUnrecoverable.ProtoReflect.Descriptor instead.
+func (*ActionUnrecoverable) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *ActionStateApplied) GetNetworkState() *msgs.NetworkState {
+func (x *ActionUnrecoverable) GetReason() string {
 	if x != nil {
-		return x.NetworkState
+		return x.Reason
 	}
-	return nil
+	return ""
 }
 
 type ActionHashRequest struct {
@@ -1610,7 +3223,7 @@ type ActionHashRequest struct {
 func (x *ActionHashRequest) Reset() {
 	*x = ActionHashRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_state_state_proto_msgTypes[22]
+		mi := &file_state_state_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1623,7 +3236,7 @@ func (x *ActionHashRequest) String() string {
 func (*ActionHashRequest) ProtoMessage() {}
 
 func (x *ActionHashRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_state_proto_msgTypes[22]
+	mi := &file_state_state_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1636,7 +3249,7 @@ func (x *ActionHashRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ActionHashRequest.ProtoReflect.Descriptor instead.
 func (*ActionHashRequest) Descriptor() ([]byte, []int) {
-	return file_state_state_proto_rawDescGZIP(), []int{22}
+	return file_state_state_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *ActionHashRequest) GetData() [][]byte {
@@ -1665,7 +3278,7 @@ type ActionStateTarget struct {
 func (x *ActionStateTarget) Reset() {
 	*x = ActionStateTarget{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_state_state_proto_msgTypes[23]
+		mi := &file_state_state_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1678,7 +3291,7 @@ func (x *ActionStateTarget) String() string {
 func (*ActionStateTarget) ProtoMessage() {}
 
 func (x *ActionStateTarget) ProtoReflect() protoreflect.Message {
-	mi := &file_state_state_proto_msgTypes[23]
+	mi := &file_state_state_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1691,7 +3304,7 @@ func (x *ActionStateTarget) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ActionStateTarget.ProtoReflect.Descriptor instead.
 func (*ActionStateTarget) Descriptor() ([]byte, []int) {
-	return file_state_state_proto_rawDescGZIP(), []int{23}
+	return file_state_state_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *ActionStateTarget) GetSeqNo() uint64 {
@@ -1708,6 +3321,65 @@ func (x *ActionStateTarget) GetValue() []byte {
 	return nil
 }
 
+// ClientState is the window state of a single client, as exported by one
+// node for import into another: the client's watermarks and committed
+// requests, along with the requests the exporting node had persisted for
+// the client which had not yet committed.
+type ClientState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Client   *msgs.NetworkState_Client `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	Requests []*msgs.RequestAck        `protobuf:"bytes,2,rep,name=requests,proto3" json:"requests,omitempty"`
+}
+
+func (x *ClientState) Reset() {
+	*x = ClientState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_state_state_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientState) ProtoMessage() {}
+
+func (x *ClientState) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientState.ProtoReflect.Descriptor instead.
+func (*ClientState) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ClientState) GetClient() *msgs.NetworkState_Client {
+	if x != nil {
+		return x.Client
+	}
+	return nil
+}
+
+func (x *ClientState) GetRequests() []*msgs.RequestAck {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
 type EventMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1720,7 +3392,7 @@ type EventMessage struct {
 func (x *EventMessage) Reset() {
 	*x = EventMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_state_state_proto_msgTypes[24]
+		mi := &file_state_state_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1733,7 +3405,7 @@ func (x *EventMessage) String() string {
 func (*EventMessage) ProtoMessage() {}
 
 func (x *EventMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_state_state_proto_msgTypes[24]
+	mi := &file_state_state_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1746,7 +3418,7 @@ func (x *EventMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EventMessage.ProtoReflect.Descriptor instead.
 func (*EventMessage) Descriptor() ([]byte, []int) {
-	return file_state_state_proto_rawDescGZIP(), []int{24}
+	return file_state_state_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *EventMessage) GetSource() uint64 {
@@ -1777,7 +3449,7 @@ type HashOrigin_Batch struct {
 func (x *HashOrigin_Batch) Reset() {
 	*x = HashOrigin_Batch{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_state_state_proto_msgTypes[25]
+		mi := &file_state_state_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1790,7 +3462,7 @@ func (x *HashOrigin_Batch) String() string {
 func (*HashOrigin_Batch) ProtoMessage() {}
 
 func (x *HashOrigin_Batch) ProtoReflect() protoreflect.Message {
-	mi := &file_state_state_proto_msgTypes[25]
+	mi := &file_state_state_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1848,7 +3520,7 @@ type HashOrigin_VerifyBatch struct {
 func (x *HashOrigin_VerifyBatch) Reset() {
 	*x = HashOrigin_VerifyBatch{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_state_state_proto_msgTypes[26]
+		mi := &file_state_state_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1861,7 +3533,7 @@ func (x *HashOrigin_VerifyBatch) String() string {
 func (*HashOrigin_VerifyBatch) ProtoMessage() {}
 
 func (x *HashOrigin_VerifyBatch) ProtoReflect() protoreflect.Message {
-	mi := &file_state_state_proto_msgTypes[26]
+	mi := &file_state_state_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1915,23 +3587,85 @@ type HashOrigin_EpochChange struct {
 	EpochChange *msgs.EpochChange `protobuf:"bytes,3,opt,name=epoch_change,json=epochChange,proto3" json:"epoch_change,omitempty"`
 }
 
-func (x *HashOrigin_EpochChange) Reset() {
-	*x = HashOrigin_EpochChange{}
+func (x *HashOrigin_EpochChange) Reset() {
+	*x = HashOrigin_EpochChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_state_state_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HashOrigin_EpochChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashOrigin_EpochChange) ProtoMessage() {}
+
+func (x *HashOrigin_EpochChange) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashOrigin_EpochChange.ProtoReflect.Descriptor instead.
+func (*HashOrigin_EpochChange) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{10, 2}
+}
+
+func (x *HashOrigin_EpochChange) GetSource() uint64 {
+	if x != nil {
+		return x.Source
+	}
+	return 0
+}
+
+func (x *HashOrigin_EpochChange) GetOrigin() uint64 {
+	if x != nil {
+		return x.Origin
+	}
+	return 0
+}
+
+func (x *HashOrigin_EpochChange) GetEpochChange() *msgs.EpochChange {
+	if x != nil {
+		return x.EpochChange
+	}
+	return nil
+}
+
+type HashOrigin_CheckpointRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId uint64 `protobuf:"varint,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ReqNo    uint64 `protobuf:"varint,2,opt,name=req_no,json=reqNo,proto3" json:"req_no,omitempty"`
+}
+
+func (x *HashOrigin_CheckpointRequest) Reset() {
+	*x = HashOrigin_CheckpointRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_state_state_proto_msgTypes[27]
+		mi := &file_state_state_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *HashOrigin_EpochChange) String() string {
+func (x *HashOrigin_CheckpointRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HashOrigin_EpochChange) ProtoMessage() {}
+func (*HashOrigin_CheckpointRequest) ProtoMessage() {}
 
-func (x *HashOrigin_EpochChange) ProtoReflect() protoreflect.Message {
-	mi := &file_state_state_proto_msgTypes[27]
+func (x *HashOrigin_CheckpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_state_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1942,38 +3676,31 @@ func (x *HashOrigin_EpochChange) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HashOrigin_EpochChange.ProtoReflect.Descriptor instead.
-func (*HashOrigin_EpochChange) Descriptor() ([]byte, []int) {
-	return file_state_state_proto_rawDescGZIP(), []int{10, 2}
+// Deprecated: Use HashOrigin_CheckpointRequest.ProtoReflect.Descriptor instead.
+func (*HashOrigin_CheckpointRequest) Descriptor() ([]byte, []int) {
+	return file_state_state_proto_rawDescGZIP(), []int{10, 3}
 }
 
-func (x *HashOrigin_EpochChange) GetSource() uint64 {
+func (x *HashOrigin_CheckpointRequest) GetClientId() uint64 {
 	if x != nil {
-		return x.Source
+		return x.ClientId
 	}
 	return 0
 }
 
-func (x *HashOrigin_EpochChange) GetOrigin() uint64 {
+func (x *HashOrigin_CheckpointRequest) GetReqNo() uint64 {
 	if x != nil {
-		return x.Origin
+		return x.ReqNo
 	}
 	return 0
 }
 
-func (x *HashOrigin_EpochChange) GetEpochChange() *msgs.EpochChange {
-	if x != nil {
-		return x.EpochChange
-	}
-	return nil
-}
-
 var File_state_state_proto protoreflect.FileDescriptor
 
 var file_state_state_proto_rawDesc = []byte{
 	0x0a, 0x11, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x12, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x1a, 0x0f, 0x6d, 0x73, 0x67, 0x73,
-	0x2f, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8f, 0x07, 0x0a, 0x05,
+	0x2f, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe8, 0x0a, 0x0a, 0x05,
 	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x3f, 0x0a, 0x0a, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c,
 	0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x74, 0x61, 0x74,
 	0x65, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x50, 0x61,
@@ -2030,22 +3757,125 @@ var file_state_state_proto_rawDesc = []byte{
 	0x00, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x29, 0x0a, 0x07, 0x72, 0x65,
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6d, 0x73,
 	0x67, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x07, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0xed, 0x01,
-	0x0a, 0x16, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x50, 0x61,
-	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x74, 0x63,
-	0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x61,
-	0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x68, 0x65, 0x61, 0x72, 0x74,
-	0x62, 0x65, 0x61, 0x74, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x0e, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x73,
-	0x12, 0x23, 0x0a, 0x0d, 0x73, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x5f, 0x74, 0x69, 0x63, 0x6b,
-	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x73, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74,
-	0x54, 0x69, 0x63, 0x6b, 0x73, 0x12, 0x35, 0x0a, 0x17, 0x6e, 0x65, 0x77, 0x5f, 0x65, 0x70, 0x6f,
-	0x63, 0x68, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x73,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x6e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68,
-	0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
-	0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x0a, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x57, 0x0a,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x45, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73,
+	0x5f, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x73, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x73, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x07,
+	0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x63, 0x74, 0x48, 0x00, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x12, 0x33, 0x0a,
+	0x09, 0x73, 0x74, 0x65, 0x70, 0x5f, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x74,
+	0x65, 0x70, 0x44, 0x6f, 0x77, 0x6e, 0x48, 0x00, 0x52, 0x08, 0x73, 0x74, 0x65, 0x70, 0x44, 0x6f,
+	0x77, 0x6e, 0x12, 0x3c, 0x0a, 0x0c, 0x61, 0x75, 0x64, 0x69, 0x74, 0x5f, 0x64, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x48, 0x00, 0x52, 0x0b, 0x61, 0x75, 0x64, 0x69, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x12, 0x4f, 0x0a, 0x13, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x49, 0x6d, 0x70, 0x6f, 0x72,
+	0x74, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x11,
+	0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x4b, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x6d, 0x69, 0x73, 0x62, 0x65,
+	0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x65, 0x74, 0x4d,
+	0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x10, 0x72, 0x65,
+	0x73, 0x65, 0x74, 0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x12, 0x4e,
+	0x0a, 0x12, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x11, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x42, 0x06,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x84, 0x0b, 0x0a, 0x16, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x27, 0x0a, 0x0f, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x5f, 0x74, 0x69,
+	0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x68, 0x65, 0x61, 0x72, 0x74,
+	0x62, 0x65, 0x61, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x75, 0x73,
+	0x70, 0x65, 0x63, 0x74, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0c, 0x73, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x73, 0x12, 0x35,
+	0x0a, 0x17, 0x6e, 0x65, 0x77, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x14, 0x6e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x54, 0x69, 0x63, 0x6b, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x62, 0x75, 0x66, 0x66,
+	0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74,
+	0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0e, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x12,
+	0x24, 0x0a, 0x0e, 0x63, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x75, 0x70, 0x5f, 0x74, 0x69, 0x63, 0x6b,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x63, 0x61, 0x74, 0x63, 0x68, 0x55, 0x70,
+	0x54, 0x69, 0x63, 0x6b, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x64, 0x61, 0x70, 0x74, 0x69, 0x76,
+	0x65, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x10, 0x61, 0x64, 0x61, 0x70, 0x74, 0x69, 0x76, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x69,
+	0x6e, 0x67, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x69, 0x6e, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6d, 0x69, 0x6e, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f,
+	0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0c, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x24,
+	0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x6a, 0x75, 0x6d, 0x70,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x45, 0x70, 0x6f, 0x63, 0x68,
+	0x4a, 0x75, 0x6d, 0x70, 0x12, 0x2d, 0x0a, 0x12, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f,
+	0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x11, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x32, 0x0a, 0x15, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x5f, 0x68, 0x69,
+	0x67, 0x68, 0x5f, 0x77, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x13, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x48, 0x69, 0x67, 0x68, 0x57, 0x61,
+	0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x2a, 0x0a, 0x11, 0x64, 0x69, 0x73, 0x61, 0x62,
+	0x6c, 0x65, 0x5f, 0x73, 0x65, 0x6c, 0x66, 0x5f, 0x65, 0x63, 0x68, 0x6f, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0f, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x65, 0x6c, 0x66, 0x45,
+	0x63, 0x68, 0x6f, 0x12, 0x3c, 0x0a, 0x1a, 0x6c, 0x61, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x5f, 0x66,
+	0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x18, 0x6c, 0x61, 0x67, 0x67, 0x69, 0x6e, 0x67,
+	0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x12, 0x32, 0x0a, 0x15, 0x6d, 0x61, 0x78, 0x5f, 0x75, 0x6e, 0x61, 0x70, 0x70, 0x6c, 0x69,
+	0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x13, 0x6d, 0x61, 0x78, 0x55, 0x6e, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x43, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x5f,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x12,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x43, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x12, 0x3f, 0x0a, 0x1c, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x5f,
+	0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x19, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x6c, 0x61, 0x72,
+	0x6d, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x44, 0x0a, 0x1f, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x61, 0x6c, 0x61, 0x72, 0x6d,
+	0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x14, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x1b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x41, 0x6c, 0x61, 0x72, 0x6d, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x54, 0x69, 0x63, 0x6b, 0x73,
+	0x12, 0x39, 0x0a, 0x19, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x73, 0x5f, 0x70, 0x61, 0x73, 0x74, 0x5f, 0x67, 0x61, 0x70, 0x73, 0x18, 0x15, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x16, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x73, 0x50, 0x61, 0x73, 0x74, 0x47, 0x61, 0x70, 0x73, 0x12, 0x3b, 0x0a, 0x1a, 0x6d,
+	0x61, 0x78, 0x5f, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x65, 0x64, 0x5f, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x17, 0x6d, 0x61, 0x78, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x65, 0x64, 0x45, 0x70, 0x6f, 0x63,
+	0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x18, 0x61, 0x70, 0x70, 0x6c,
+	0x69, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x77, 0x61, 0x74, 0x65, 0x72,
+	0x6d, 0x61, 0x72, 0x6b, 0x18, 0x17, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16, 0x61, 0x70, 0x70, 0x6c,
+	0x69, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61,
+	0x72, 0x6b, 0x12, 0x34, 0x0a, 0x16, 0x66, 0x75, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x5f, 0x6c, 0x6f, 0x6f, 0x6b, 0x61, 0x68, 0x65, 0x61, 0x64, 0x18, 0x18, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x14, 0x66, 0x75, 0x74, 0x75, 0x72, 0x65, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4c,
+	0x6f, 0x6f, 0x6b, 0x61, 0x68, 0x65, 0x61, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x18,
+	0x19, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x12, 0x2a, 0x0a, 0x11, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x5f, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x18, 0x1a, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x4a, 0x6f, 0x69, 0x6e, 0x51, 0x75,
+	0x6f, 0x72, 0x75, 0x6d, 0x12, 0x3b, 0x0a, 0x1a, 0x64, 0x72, 0x6f, 0x70, 0x5f, 0x64, 0x75, 0x72,
+	0x69, 0x6e, 0x67, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x17, 0x64, 0x72, 0x6f, 0x70, 0x44, 0x75,
+	0x72, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x61, 0x6c, 0x65, 0x73, 0x63, 0x65, 0x5f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x63, 0x6f, 0x61,
+	0x6c, 0x65, 0x73, 0x63, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x3f, 0x0a, 0x1c,
+	0x6d, 0x61, 0x78, 0x5f, 0x64, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64,
+	0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x1d, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x19, 0x6d, 0x61, 0x78, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x65, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x57, 0x0a,
 	0x17, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x61, 0x64, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73,
 	0x74, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65,
 	0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x26,
@@ -2063,179 +3893,336 @@ var file_state_state_proto_rawDesc = []byte{
 	0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x0c,
 	0x72, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01,
 	0x28, 0x08, 0x52, 0x0c, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x64,
-	0x22, 0x4a, 0x0a, 0x15, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x64, 0x12, 0x31, 0x0a, 0x0b, 0x72, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
-	0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b,
-	0x52, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x22, 0x97, 0x01, 0x0a,
-	0x1a, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73,
-	0x66, 0x65, 0x72, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x73,
-	0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71,
-	0x4e, 0x6f, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74,
-	0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x63, 0x68,
-	0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x37, 0x0a,
-	0x0d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x65, 0x74, 0x77,
-	0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72,
-	0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x22, 0x5c, 0x0a, 0x18, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53,
-	0x74, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x46, 0x61, 0x69, 0x6c,
-	0x65, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x68, 0x65,
-	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x0f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x22, 0x40, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x65,
-	0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x03, 0x6d, 0x73, 0x67,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4d, 0x73,
-	0x67, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x12, 0x0a, 0x10, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54,
-	0x69, 0x63, 0x6b, 0x45, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x22, 0xe3, 0x04, 0x0a, 0x0a, 0x48,
-	0x61, 0x73, 0x68, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x12, 0x2f, 0x0a, 0x05, 0x62, 0x61, 0x74,
-	0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65,
-	0x2e, 0x48, 0x61, 0x73, 0x68, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x2e, 0x42, 0x61, 0x74, 0x63,
-	0x68, 0x48, 0x00, 0x52, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x12, 0x42, 0x0a, 0x0c, 0x65, 0x70,
-	0x6f, 0x63, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1d, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x4f, 0x72, 0x69,
-	0x67, 0x69, 0x6e, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x48,
-	0x00, 0x52, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x42,
-	0x0a, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x48, 0x61, 0x73,
-	0x68, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x42, 0x61,
-	0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x0b, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x42, 0x61, 0x74,
-	0x63, 0x68, 0x1a, 0x81, 0x01, 0x0a, 0x05, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65,
-	0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e,
-	0x6f, 0x12, 0x33, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x6b,
-	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x41, 0x63, 0x6b, 0x73, 0x1a, 0x9a, 0x01, 0x0a, 0x0b, 0x56, 0x65, 0x72, 0x69, 0x66,
-	0x79, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x15,
-	0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
-	0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x33, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x5f, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73,
-	0x67, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x0b, 0x72,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78,
-	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x44, 0x69, 0x67,
-	0x65, 0x73, 0x74, 0x1a, 0x73, 0x0a, 0x0b, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e,
-	0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x72,
-	0x69, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67,
-	0x69, 0x6e, 0x12, 0x34, 0x0a, 0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e,
-	0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e,
-	0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0b, 0x65, 0x70, 0x6f,
-	0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x22, 0x54, 0x0a, 0x0f, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x52, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x06, 0x6f,
-	0x72, 0x69, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x74,
-	0x61, 0x74, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x52, 0x06,
-	0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x22, 0x16, 0x0a, 0x14, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x41,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x22, 0xae,
-	0x05, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x04, 0x73, 0x65, 0x6e,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e,
-	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x04, 0x73, 0x65,
-	0x6e, 0x64, 0x12, 0x2e, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x18, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48,
-	0x61, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x04, 0x68, 0x61,
-	0x73, 0x68, 0x12, 0x42, 0x0a, 0x12, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x5f, 0x77, 0x72, 0x69,
-	0x74, 0x65, 0x5f, 0x61, 0x68, 0x65, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
-	0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x72, 0x69,
-	0x74, 0x65, 0x48, 0x00, 0x52, 0x10, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x57, 0x72, 0x69, 0x74,
-	0x65, 0x41, 0x68, 0x65, 0x61, 0x64, 0x12, 0x49, 0x0a, 0x14, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61,
-	0x74, 0x65, 0x5f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x61, 0x68, 0x65, 0x61, 0x64, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x12, 0x74,
-	0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x41, 0x68, 0x65, 0x61,
-	0x64, 0x12, 0x2d, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x13, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x48, 0x00, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
-	0x12, 0x39, 0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x48, 0x00, 0x52,
-	0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x47, 0x0a, 0x11, 0x61,
-	0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x41,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x53, 0x6c, 0x6f, 0x74,
-	0x48, 0x00, 0x52, 0x10, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x64, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x0f, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x5f,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x6d, 0x73, 0x67, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x48,
-	0x00, 0x52, 0x0e, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x3f, 0x0a, 0x0f, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x74, 0x61,
-	0x74, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64,
-	0x48, 0x00, 0x52, 0x0e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x41, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x6e,
-	0x73, 0x66, 0x65, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x74, 0x61,
-	0x74, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x54, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61,
-	0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x40, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x61,
-	0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73,
-	0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65,
-	0x41, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x65,
-	0x41, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22,
-	0x43, 0x0a, 0x0a, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x6e, 0x64, 0x12, 0x18, 0x0a,
-	0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04, 0x52, 0x07,
-	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4d, 0x73, 0x67, 0x52,
-	0x03, 0x6d, 0x73, 0x67, 0x22, 0x26, 0x0a, 0x0e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72,
-	0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x49, 0x0a, 0x0b,
-	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69,
-	0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65,
-	0x78, 0x12, 0x24, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e,
-	0x74, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x32, 0x0a, 0x0c, 0x41, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x22, 0x0a, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x51, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x22, 0xab, 0x01, 0x0a, 0x10,
-	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74,
-	0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x40, 0x0a, 0x0e, 0x6e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x19, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74,
-	0x61, 0x74, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0d, 0x6e, 0x65, 0x74, 0x77,
-	0x6f, 0x72, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3e, 0x0a, 0x0d, 0x63, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x22, 0xc1, 0x01, 0x0a, 0x15, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x64, 0x12, 0x31, 0x0a, 0x0b, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63,
+	0x6b, 0x52, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x12, 0x23, 0x0a,
+	0x0d, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x65, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x6a, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x6a, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x22, 0x97, 0x01, 0x0a, 0x1a, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x43, 0x6f, 0x6d, 0x70, 0x6c,
+	0x65, 0x74, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x37, 0x0a, 0x0d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d,
+	0x73, 0x67, 0x73, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x0c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x22, 0x5c,
+	0x0a, 0x18, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65,
+	0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e,
+	0x6f, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x40, 0x0a, 0x09,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x65, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x1b, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09,
+	0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4d, 0x73, 0x67, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x12,
+	0x0a, 0x10, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x45, 0x6c, 0x61, 0x70, 0x73,
+	0x65, 0x64, 0x22, 0x82, 0x06, 0x0a, 0x0a, 0x48, 0x61, 0x73, 0x68, 0x4f, 0x72, 0x69, 0x67, 0x69,
+	0x6e, 0x12, 0x2f, 0x0a, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x4f, 0x72, 0x69,
+	0x67, 0x69, 0x6e, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x05, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x12, 0x42, 0x0a, 0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x2e, 0x48, 0x61, 0x73, 0x68, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x2e, 0x45, 0x70, 0x6f, 0x63,
+	0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x42, 0x0a, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x2e,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x0b, 0x76,
+	0x65, 0x72, 0x69, 0x66, 0x79, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x54, 0x0a, 0x12, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x48,
+	0x61, 0x73, 0x68, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x11, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x81, 0x01, 0x0a, 0x05, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f,
+	0x6e, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12,
+	0x33, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x6b, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x41, 0x63, 0x6b, 0x73, 0x1a, 0x9a, 0x01, 0x0a, 0x0b, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x15, 0x0a, 0x06,
+	0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65,
+	0x71, 0x4e, 0x6f, 0x12, 0x33, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61,
+	0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73,
+	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x0b, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x44, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x1a, 0x73, 0x0a, 0x0b, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x12, 0x34, 0x0a, 0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x45, 0x70,
+	0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x47, 0x0a, 0x11, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x72, 0x65, 0x71, 0x5f,
+	0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x65, 0x71, 0x4e, 0x6f, 0x42,
+	0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x54, 0x0a, 0x0f, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x48, 0x61, 0x73, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x12, 0x29, 0x0a, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x4f,
+	0x72, 0x69, 0x67, 0x69, 0x6e, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x22, 0x16, 0x0a,
+	0x14, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x64, 0x22, 0x0e, 0x0a, 0x0c, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x43, 0x6f,
+	0x6d, 0x70, 0x61, 0x63, 0x74, 0x22, 0x0f, 0x0a, 0x0d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x74,
+	0x65, 0x70, 0x44, 0x6f, 0x77, 0x6e, 0x22, 0x29, 0x0a, 0x10, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x41,
+	0x75, 0x64, 0x69, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65,
+	0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e,
+	0x6f, 0x22, 0x4f, 0x0a, 0x16, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x35, 0x0a, 0x0c, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x22, 0x30, 0x0a, 0x15, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x65, 0x74,
+	0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x6e,
+	0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x6f,
+	0x64, 0x65, 0x49, 0x64, 0x22, 0x18, 0x0a, 0x16, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x22, 0x39,
+	0x0a, 0x13, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x41, 0x70,
+	0x70, 0x6c, 0x69, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74, 0x6f, 0x22, 0xe3, 0x0b, 0x0a, 0x06, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x04, 0x73, 0x65, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x65, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x04, 0x73, 0x65, 0x6e, 0x64, 0x12, 0x2e, 0x0a,
+	0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x42, 0x0a,
+	0x12, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x5f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x61, 0x68,
+	0x65, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x48, 0x00, 0x52,
+	0x10, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x57, 0x72, 0x69, 0x74, 0x65, 0x41, 0x68, 0x65, 0x61,
+	0x64, 0x12, 0x49, 0x0a, 0x14, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x77, 0x72,
+	0x69, 0x74, 0x65, 0x5f, 0x61, 0x68, 0x65, 0x61, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72,
+	0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x12, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61,
+	0x74, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x41, 0x68, 0x65, 0x61, 0x64, 0x12, 0x2d, 0x0a, 0x06,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x48, 0x00, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x47, 0x0a, 0x11, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x53, 0x6c, 0x6f, 0x74, 0x48, 0x00, 0x52, 0x10, 0x61,
+	0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x3b, 0x0a, 0x0f, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x0e, 0x63, 0x6f,
+	0x72, 0x72, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3f, 0x0a, 0x0f,
+	0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x48, 0x00, 0x52, 0x0e, 0x66,
+	0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x41, 0x0a,
+	0x0e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x48,
+	0x00, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x12, 0x40, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65,
+	0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x6c, 0x69,
+	0x65, 0x64, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x6c, 0x69,
+	0x65, 0x64, 0x12, 0x4f, 0x0a, 0x12, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70,
+	0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x48, 0x00,
+	0x52, 0x11, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x64, 0x12, 0x42, 0x0a, 0x0d, 0x75, 0x6e, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72,
+	0x61, 0x62, 0x6c, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x72, 0x65, 0x63, 0x6f, 0x76,
+	0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x75, 0x6e, 0x72, 0x65, 0x63, 0x6f,
+	0x76, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x3d, 0x0a, 0x0c, 0x61, 0x75, 0x64, 0x69, 0x74,
+	0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x75, 0x64, 0x69,
+	0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x61, 0x75, 0x64, 0x69, 0x74,
+	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x4c, 0x0a, 0x11, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x45, 0x70, 0x6f, 0x63, 0x68, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x48, 0x00, 0x52, 0x10, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x79, 0x12, 0x46, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x6d,
+	0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x0e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x3c, 0x0a, 0x0b,
+	0x6d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x0b, 0x6d,
+	0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x12, 0x4c, 0x0a, 0x11, 0x70, 0x65,
+	0x72, 0x73, 0x69, 0x73, 0x74, 0x5f, 0x77, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x18,
+	0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x57, 0x61, 0x74, 0x65, 0x72,
+	0x6d, 0x61, 0x72, 0x6b, 0x48, 0x00, 0x52, 0x10, 0x70, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x57,
+	0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x40, 0x0a, 0x0d, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x70,
+	0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0c, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x50, 0x0a, 0x13, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x72, 0x75, 0x6e, 0x65,
+	0x64, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x64, 0x48, 0x00, 0x52, 0x11, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x64, 0x12, 0x5b, 0x0a, 0x16,
+	0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x48, 0x00, 0x52, 0x15, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x4c, 0x0a, 0x11, 0x6e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x61, 0x73, 0x79, 0x6d, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x18, 0x16,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x41, 0x73, 0x79, 0x6d, 0x6d, 0x65,
+	0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x10, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x41, 0x73,
+	0x79, 0x6d, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22,
+	0x4c, 0x0a, 0x11, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x20, 0x0a, 0x0b, 0x75,
+	0x6e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0b, 0x75, 0x6e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x22, 0x34, 0x0a,
+	0x1b, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74,
+	0x65, 0x64, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x15, 0x0a, 0x06,
+	0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65,
+	0x71, 0x4e, 0x6f, 0x22, 0x43, 0x0a, 0x0a, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x6e,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x04, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x03, 0x6d,
+	0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e,
+	0x4d, 0x73, 0x67, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x26, 0x0a, 0x0e, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x22, 0x49, 0x0a, 0x0b, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x24, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x50, 0x65, 0x72, 0x73, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x7f, 0x0a, 0x0c, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x22, 0x0a, 0x05, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x73, 0x67,
+	0x73, 0x2e, 0x51, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x12,
+	0x37, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x61, 0x70, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x04, 0x52, 0x04, 0x67, 0x61, 0x70, 0x73, 0x22, 0xab, 0x01, 0x0a,
+	0x10, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x40, 0x0a, 0x0e, 0x6e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x19, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53,
-	0x74, 0x61, 0x74, 0x65, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x0c, 0x63, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x22, 0x47, 0x0a, 0x11, 0x41, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x53, 0x6c, 0x6f, 0x74, 0x12, 0x1b,
-	0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x72,
-	0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x65, 0x71,
-	0x4e, 0x6f, 0x22, 0x4d, 0x0a, 0x0d, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x6f, 0x72, 0x77,
-	0x61, 0x72, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x04, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x22, 0x0a,
-	0x03, 0x61, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73, 0x67,
-	0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x03, 0x61, 0x63,
-	0x6b, 0x22, 0x64, 0x0a, 0x12, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65,
-	0x41, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e,
-	0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x37,
-	0x0a, 0x0d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x65, 0x74,
-	0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0c, 0x6e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x22, 0x52, 0x0a, 0x11, 0x41, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
-	0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x12, 0x29, 0x0a, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x11, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x4f, 0x72, 0x69,
-	0x67, 0x69, 0x6e, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x22, 0x40, 0x0a, 0x11, 0x41,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x74, 0x61, 0x74, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0d, 0x6e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3e, 0x0a, 0x0d, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x0c, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x22, 0x47, 0x0a, 0x11, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x53, 0x6c, 0x6f, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06,
+	0x72, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x65,
+	0x71, 0x4e, 0x6f, 0x22, 0x55, 0x0a, 0x0d, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x6f, 0x72,
+	0x77, 0x61, 0x72, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x04, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x24,
+	0x0a, 0x04, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d,
+	0x73, 0x67, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x04,
+	0x61, 0x63, 0x6b, 0x73, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0x64, 0x0a, 0x12, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64,
 	0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x43, 0x0a,
-	0x0c, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a,
-	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x09, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4d, 0x73, 0x67, 0x52, 0x03, 0x6d,
-	0x73, 0x67, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x68, 0x79, 0x70, 0x65, 0x72, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62,
-	0x73, 0x2f, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0x2f,
-	0x73, 0x74, 0x61, 0x74, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x37, 0x0a, 0x0d, 0x6e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x0c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x22, 0x79, 0x0a, 0x17, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x68, 0x69, 0x70, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x12, 0x25, 0x0a, 0x0e, 0x67, 0x61, 0x69, 0x6e, 0x65, 0x64, 0x5f, 0x62, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x04, 0x52, 0x0d, 0x67, 0x61, 0x69, 0x6e, 0x65,
+	0x64, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x6f, 0x73, 0x74,
+	0x5f, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x04, 0x52, 0x0b,
+	0x6c, 0x6f, 0x73, 0x74, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x22, 0x76, 0x0a, 0x16, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x65,
+	0x70, 0x6f, 0x63, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x54, 0x69,
+	0x63, 0x6b, 0x73, 0x22, 0x69, 0x0a, 0x12, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x70, 0x6f,
+	0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12,
+	0x25, 0x0a, 0x0e, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75,
+	0x73, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x44,
+	0x0a, 0x16, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x41,
+	0x73, 0x79, 0x6d, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x14,
+	0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x04, 0x52, 0x05, 0x70,
+	0x65, 0x65, 0x72, 0x73, 0x22, 0x65, 0x0a, 0x17, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x64, 0x12,
+	0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x33, 0x0a, 0x07, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x52, 0x07, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x5e, 0x0a, 0x14, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4d, 0x69, 0x73, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06,
+	0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65,
+	0x71, 0x4e, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x44, 0x0a, 0x11, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72,
+	0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x22, 0x2f, 0x0a, 0x16, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x65, 0x72, 0x73, 0x69,
+	0x73, 0x74, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x15, 0x0a, 0x06, 0x73,
+	0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71,
+	0x4e, 0x6f, 0x22, 0x2d, 0x0a, 0x13, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x72, 0x65,
+	0x63, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x22, 0x52, 0x0a, 0x11, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x29, 0x0a, 0x06, 0x6f, 0x72,
+	0x69, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x52, 0x06, 0x6f,
+	0x72, 0x69, 0x67, 0x69, 0x6e, 0x22, 0x40, 0x0a, 0x11, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65,
+	0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e,
+	0x6f, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x6e, 0x0a, 0x0b, 0x43, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x52, 0x06, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x08, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x73,
+	0x67, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x08, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x22, 0x43, 0x0a, 0x0c, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
+	0x1b, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x6d,
+	0x73, 0x67, 0x73, 0x2e, 0x4d, 0x73, 0x67, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x42, 0x31, 0x5a, 0x2f,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70, 0x65, 0x72,
+	0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x6d, 0x69, 0x72, 0x62,
+	0x66, 0x74, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2250,45 +4237,65 @@ func file_state_state_proto_rawDescGZIP() []byte {
 	return file_state_state_proto_rawDescData
 }
 
-var file_state_state_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_state_state_proto_msgTypes = make([]protoimpl.MessageInfo, 48)
 var file_state_state_proto_goTypes = []interface{}{
-	(*Event)(nil),                      // 0: state.Event
-	(*EventInitialParameters)(nil),     // 1: state.EventInitialParameters
-	(*EventLoadPersistedEntry)(nil),    // 2: state.EventLoadPersistedEntry
-	(*EventLoadCompleted)(nil),         // 3: state.EventLoadCompleted
-	(*EventCheckpointResult)(nil),      // 4: state.EventCheckpointResult
-	(*EventRequestPersisted)(nil),      // 5: state.EventRequestPersisted
-	(*EventStateTransferComplete)(nil), // 6: state.EventStateTransferComplete
-	(*EventStateTransferFailed)(nil),   // 7: state.EventStateTransferFailed
-	(*EventStep)(nil),                  // 8: state.EventStep
-	(*EventTickElapsed)(nil),           // 9: state.EventTickElapsed
-	(*HashOrigin)(nil),                 // 10: state.HashOrigin
-	(*EventHashResult)(nil),            // 11: state.EventHashResult
-	(*EventActionsReceived)(nil),       // 12: state.EventActionsReceived
-	(*Action)(nil),                     // 13: state.Action
-	(*ActionSend)(nil),                 // 14: state.ActionSend
-	(*ActionTruncate)(nil),             // 15: state.ActionTruncate
-	(*ActionWrite)(nil),                // 16: state.ActionWrite
-	(*ActionCommit)(nil),               // 17: state.ActionCommit
-	(*ActionCheckpoint)(nil),           // 18: state.ActionCheckpoint
-	(*ActionRequestSlot)(nil),          // 19: state.ActionRequestSlot
-	(*ActionForward)(nil),              // 20: state.ActionForward
-	(*ActionStateApplied)(nil),         // 21: state.ActionStateApplied
-	(*ActionHashRequest)(nil),          // 22: state.ActionHashRequest
-	(*ActionStateTarget)(nil),          // 23: state.ActionStateTarget
-	(*EventMessage)(nil),               // 24: state.EventMessage
-	(*HashOrigin_Batch)(nil),           // 25: state.HashOrigin.Batch
-	(*HashOrigin_VerifyBatch)(nil),     // 26: state.HashOrigin.VerifyBatch
-	(*HashOrigin_EpochChange)(nil),     // 27: state.HashOrigin.EpochChange
-	(*msgs.Request)(nil),               // 28: msgs.Request
-	(*msgs.Persistent)(nil),            // 29: msgs.Persistent
-	(*msgs.NetworkState)(nil),          // 30: msgs.NetworkState
-	(*msgs.RequestAck)(nil),            // 31: msgs.RequestAck
-	(*msgs.Msg)(nil),                   // 32: msgs.Msg
-	(*msgs.QEntry)(nil),                // 33: msgs.QEntry
-	(*msgs.NetworkState_Config)(nil),   // 34: msgs.NetworkState.Config
-	(*msgs.NetworkState_Client)(nil),   // 35: msgs.NetworkState.Client
-	(*msgs.EpochChange)(nil),           // 36: msgs.EpochChange
+	(*Event)(nil),                        // 0: state.Event
+	(*EventInitialParameters)(nil),       // 1: state.EventInitialParameters
+	(*EventLoadPersistedEntry)(nil),      // 2: state.EventLoadPersistedEntry
+	(*EventLoadCompleted)(nil),           // 3: state.EventLoadCompleted
+	(*EventCheckpointResult)(nil),        // 4: state.EventCheckpointResult
+	(*EventRequestPersisted)(nil),        // 5: state.EventRequestPersisted
+	(*EventStateTransferComplete)(nil),   // 6: state.EventStateTransferComplete
+	(*EventStateTransferFailed)(nil),     // 7: state.EventStateTransferFailed
+	(*EventStep)(nil),                    // 8: state.EventStep
+	(*EventTickElapsed)(nil),             // 9: state.EventTickElapsed
+	(*HashOrigin)(nil),                   // 10: state.HashOrigin
+	(*EventHashResult)(nil),              // 11: state.EventHashResult
+	(*EventActionsReceived)(nil),         // 12: state.EventActionsReceived
+	(*EventCompact)(nil),                 // 13: state.EventCompact
+	(*EventStepDown)(nil),                // 14: state.EventStepDown
+	(*EventAuditDigest)(nil),             // 15: state.EventAuditDigest
+	(*EventImportClientState)(nil),       // 16: state.EventImportClientState
+	(*EventResetMisbehavior)(nil),        // 17: state.EventResetMisbehavior
+	(*EventRequestCheckpoint)(nil),       // 18: state.EventRequestCheckpoint
+	(*EventCommitsApplied)(nil),          // 19: state.EventCommitsApplied
+	(*Action)(nil),                       // 20: state.Action
+	(*ActionAuditDigest)(nil),            // 21: state.ActionAuditDigest
+	(*ActionCoordinatedCheckpoint)(nil),  // 22: state.ActionCoordinatedCheckpoint
+	(*ActionSend)(nil),                   // 23: state.ActionSend
+	(*ActionTruncate)(nil),               // 24: state.ActionTruncate
+	(*ActionWrite)(nil),                  // 25: state.ActionWrite
+	(*ActionCommit)(nil),                 // 26: state.ActionCommit
+	(*ActionCheckpoint)(nil),             // 27: state.ActionCheckpoint
+	(*ActionRequestSlot)(nil),            // 28: state.ActionRequestSlot
+	(*ActionForward)(nil),                // 29: state.ActionForward
+	(*ActionStateApplied)(nil),           // 30: state.ActionStateApplied
+	(*ActionLeadershipChanged)(nil),      // 31: state.ActionLeadershipChanged
+	(*ActionEpochInstability)(nil),       // 32: state.ActionEpochInstability
+	(*ActionEpochChanged)(nil),           // 33: state.ActionEpochChanged
+	(*ActionNetworkAsymmetry)(nil),       // 34: state.ActionNetworkAsymmetry
+	(*ActionClientStatePruned)(nil),      // 35: state.ActionClientStatePruned
+	(*ActionConfigMismatch)(nil),         // 36: state.ActionConfigMismatch
+	(*ActionMisbehavior)(nil),            // 37: state.ActionMisbehavior
+	(*ActionPersistWatermark)(nil),       // 38: state.ActionPersistWatermark
+	(*ActionUnrecoverable)(nil),          // 39: state.ActionUnrecoverable
+	(*ActionHashRequest)(nil),            // 40: state.ActionHashRequest
+	(*ActionStateTarget)(nil),            // 41: state.ActionStateTarget
+	(*ClientState)(nil),                  // 42: state.ClientState
+	(*EventMessage)(nil),                 // 43: state.EventMessage
+	(*HashOrigin_Batch)(nil),             // 44: state.HashOrigin.Batch
+	(*HashOrigin_VerifyBatch)(nil),       // 45: state.HashOrigin.VerifyBatch
+	(*HashOrigin_EpochChange)(nil),       // 46: state.HashOrigin.EpochChange
+	(*HashOrigin_CheckpointRequest)(nil), // 47: state.HashOrigin.CheckpointRequest
+	(*msgs.Request)(nil),                 // 48: msgs.Request
+	(*msgs.Persistent)(nil),              // 49: msgs.Persistent
+	(*msgs.NetworkState)(nil),            // 50: msgs.NetworkState
+	(*msgs.RequestAck)(nil),              // 51: msgs.RequestAck
+	(*msgs.Msg)(nil),                     // 52: msgs.Msg
+	(*msgs.QEntry)(nil),                  // 53: msgs.QEntry
+	(*msgs.NetworkState_Config)(nil),     // 54: msgs.NetworkState.Config
+	(*msgs.NetworkState_Client)(nil),     // 55: msgs.NetworkState.Client
+	(*msgs.EpochChange)(nil),             // 56: msgs.EpochChange
 }
 var file_state_state_proto_depIdxs = []int32{
 	1,  // 0: state.Event.initialize:type_name -> state.EventInitialParameters
@@ -2302,45 +4309,69 @@ var file_state_state_proto_depIdxs = []int32{
 	8,  // 8: state.Event.step:type_name -> state.EventStep
 	9,  // 9: state.Event.tick_elapsed:type_name -> state.EventTickElapsed
 	12, // 10: state.Event.actions_received:type_name -> state.EventActionsReceived
-	24, // 11: state.Event.message:type_name -> state.EventMessage
-	28, // 12: state.Event.request:type_name -> msgs.Request
-	29, // 13: state.EventLoadPersistedEntry.entry:type_name -> msgs.Persistent
-	30, // 14: state.EventCheckpointResult.network_state:type_name -> msgs.NetworkState
-	31, // 15: state.EventRequestPersisted.request_ack:type_name -> msgs.RequestAck
-	30, // 16: state.EventStateTransferComplete.network_state:type_name -> msgs.NetworkState
-	32, // 17: state.EventStep.msg:type_name -> msgs.Msg
-	25, // 18: state.HashOrigin.batch:type_name -> state.HashOrigin.Batch
-	27, // 19: state.HashOrigin.epoch_change:type_name -> state.HashOrigin.EpochChange
-	26, // 20: state.HashOrigin.verify_batch:type_name -> state.HashOrigin.VerifyBatch
-	10, // 21: state.EventHashResult.origin:type_name -> state.HashOrigin
-	14, // 22: state.Action.send:type_name -> state.ActionSend
-	22, // 23: state.Action.hash:type_name -> state.ActionHashRequest
-	16, // 24: state.Action.append_write_ahead:type_name -> state.ActionWrite
-	15, // 25: state.Action.truncate_write_ahead:type_name -> state.ActionTruncate
-	17, // 26: state.Action.commit:type_name -> state.ActionCommit
-	18, // 27: state.Action.checkpoint:type_name -> state.ActionCheckpoint
-	19, // 28: state.Action.allocated_request:type_name -> state.ActionRequestSlot
-	31, // 29: state.Action.correct_request:type_name -> msgs.RequestAck
-	20, // 30: state.Action.forward_request:type_name -> state.ActionForward
-	23, // 31: state.Action.state_transfer:type_name -> state.ActionStateTarget
-	21, // 32: state.Action.state_applied:type_name -> state.ActionStateApplied
-	32, // 33: state.ActionSend.msg:type_name -> msgs.Msg
-	29, // 34: state.ActionWrite.data:type_name -> msgs.Persistent
-	33, // 35: state.ActionCommit.batch:type_name -> msgs.QEntry
-	34, // 36: state.ActionCheckpoint.network_config:type_name -> msgs.NetworkState.Config
-	35, // 37: state.ActionCheckpoint.client_states:type_name -> msgs.NetworkState.Client
-	31, // 38: state.ActionForward.ack:type_name -> msgs.RequestAck
-	30, // 39: state.ActionStateApplied.network_state:type_name -> msgs.NetworkState
-	10, // 40: state.ActionHashRequest.origin:type_name -> state.HashOrigin
-	32, // 41: state.EventMessage.msg:type_name -> msgs.Msg
-	31, // 42: state.HashOrigin.Batch.request_acks:type_name -> msgs.RequestAck
-	31, // 43: state.HashOrigin.VerifyBatch.request_acks:type_name -> msgs.RequestAck
-	36, // 44: state.HashOrigin.EpochChange.epoch_change:type_name -> msgs.EpochChange
-	45, // [45:45] is the sub-list for method output_type
-	45, // [45:45] is the sub-list for method input_type
-	45, // [45:45] is the sub-list for extension type_name
-	45, // [45:45] is the sub-list for extension extendee
-	0,  // [0:45] is the sub-list for field type_name
+	43, // 11: state.Event.message:type_name -> state.EventMessage
+	48, // 12: state.Event.request:type_name -> msgs.Request
+	19, // 13: state.Event.commits_applied:type_name -> state.EventCommitsApplied
+	13, // 14: state.Event.compact:type_name -> state.EventCompact
+	14, // 15: state.Event.step_down:type_name -> state.EventStepDown
+	15, // 16: state.Event.audit_digest:type_name -> state.EventAuditDigest
+	16, // 17: state.Event.import_client_state:type_name -> state.EventImportClientState
+	17, // 18: state.Event.reset_misbehavior:type_name -> state.EventResetMisbehavior
+	18, // 19: state.Event.request_checkpoint:type_name -> state.EventRequestCheckpoint
+	49, // 20: state.EventLoadPersistedEntry.entry:type_name -> msgs.Persistent
+	50, // 21: state.EventCheckpointResult.network_state:type_name -> msgs.NetworkState
+	51, // 22: state.EventRequestPersisted.request_ack:type_name -> msgs.RequestAck
+	50, // 23: state.EventStateTransferComplete.network_state:type_name -> msgs.NetworkState
+	52, // 24: state.EventStep.msg:type_name -> msgs.Msg
+	44, // 25: state.HashOrigin.batch:type_name -> state.HashOrigin.Batch
+	46, // 26: state.HashOrigin.epoch_change:type_name -> state.HashOrigin.EpochChange
+	45, // 27: state.HashOrigin.verify_batch:type_name -> state.HashOrigin.VerifyBatch
+	47, // 28: state.HashOrigin.checkpoint_request:type_name -> state.HashOrigin.CheckpointRequest
+	10, // 29: state.EventHashResult.origin:type_name -> state.HashOrigin
+	42, // 30: state.EventImportClientState.client_state:type_name -> state.ClientState
+	23, // 31: state.Action.send:type_name -> state.ActionSend
+	40, // 32: state.Action.hash:type_name -> state.ActionHashRequest
+	25, // 33: state.Action.append_write_ahead:type_name -> state.ActionWrite
+	24, // 34: state.Action.truncate_write_ahead:type_name -> state.ActionTruncate
+	26, // 35: state.Action.commit:type_name -> state.ActionCommit
+	27, // 36: state.Action.checkpoint:type_name -> state.ActionCheckpoint
+	28, // 37: state.Action.allocated_request:type_name -> state.ActionRequestSlot
+	51, // 38: state.Action.correct_request:type_name -> msgs.RequestAck
+	29, // 39: state.Action.forward_request:type_name -> state.ActionForward
+	41, // 40: state.Action.state_transfer:type_name -> state.ActionStateTarget
+	30, // 41: state.Action.state_applied:type_name -> state.ActionStateApplied
+	31, // 42: state.Action.leadership_changed:type_name -> state.ActionLeadershipChanged
+	39, // 43: state.Action.unrecoverable:type_name -> state.ActionUnrecoverable
+	21, // 44: state.Action.audit_digest:type_name -> state.ActionAuditDigest
+	32, // 45: state.Action.epoch_instability:type_name -> state.ActionEpochInstability
+	36, // 46: state.Action.config_mismatch:type_name -> state.ActionConfigMismatch
+	37, // 47: state.Action.misbehavior:type_name -> state.ActionMisbehavior
+	38, // 48: state.Action.persist_watermark:type_name -> state.ActionPersistWatermark
+	33, // 49: state.Action.epoch_changed:type_name -> state.ActionEpochChanged
+	35, // 50: state.Action.client_state_pruned:type_name -> state.ActionClientStatePruned
+	22, // 51: state.Action.coordinated_checkpoint:type_name -> state.ActionCoordinatedCheckpoint
+	34, // 52: state.Action.network_asymmetry:type_name -> state.ActionNetworkAsymmetry
+	52, // 53: state.ActionSend.msg:type_name -> msgs.Msg
+	49, // 54: state.ActionWrite.data:type_name -> msgs.Persistent
+	53, // 55: state.ActionCommit.batch:type_name -> msgs.QEntry
+	51, // 56: state.ActionCommit.config_changes:type_name -> msgs.RequestAck
+	54, // 57: state.ActionCheckpoint.network_config:type_name -> msgs.NetworkState.Config
+	55, // 58: state.ActionCheckpoint.client_states:type_name -> msgs.NetworkState.Client
+	51, // 59: state.ActionForward.acks:type_name -> msgs.RequestAck
+	50, // 60: state.ActionStateApplied.network_state:type_name -> msgs.NetworkState
+	55, // 61: state.ActionClientStatePruned.clients:type_name -> msgs.NetworkState.Client
+	10, // 62: state.ActionHashRequest.origin:type_name -> state.HashOrigin
+	55, // 63: state.ClientState.client:type_name -> msgs.NetworkState.Client
+	51, // 64: state.ClientState.requests:type_name -> msgs.RequestAck
+	52, // 65: state.EventMessage.msg:type_name -> msgs.Msg
+	51, // 66: state.HashOrigin.Batch.request_acks:type_name -> msgs.RequestAck
+	51, // 67: state.HashOrigin.VerifyBatch.request_acks:type_name -> msgs.RequestAck
+	56, // 68: state.HashOrigin.EpochChange.epoch_change:type_name -> msgs.EpochChange
+	69, // [69:69] is the sub-list for method output_type
+	69, // [69:69] is the sub-list for method input_type
+	69, // [69:69] is the sub-list for extension type_name
+	69, // [69:69] is the sub-list for extension extendee
+	0,  // [0:69] is the sub-list for field type_name
 }
 
 func init() { file_state_state_proto_init() }
@@ -2506,7 +4537,7 @@ func file_state_state_proto_init() {
 			}
 		}
 		file_state_state_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Action); i {
+			switch v := v.(*EventCompact); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2518,7 +4549,7 @@ func file_state_state_proto_init() {
 			}
 		}
 		file_state_state_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ActionSend); i {
+			switch v := v.(*EventStepDown); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2530,7 +4561,7 @@ func file_state_state_proto_init() {
 			}
 		}
 		file_state_state_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ActionTruncate); i {
+			switch v := v.(*EventAuditDigest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2542,7 +4573,7 @@ func file_state_state_proto_init() {
 			}
 		}
 		file_state_state_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ActionWrite); i {
+			switch v := v.(*EventImportClientState); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2554,7 +4585,7 @@ func file_state_state_proto_init() {
 			}
 		}
 		file_state_state_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ActionCommit); i {
+			switch v := v.(*EventResetMisbehavior); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2566,7 +4597,7 @@ func file_state_state_proto_init() {
 			}
 		}
 		file_state_state_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ActionCheckpoint); i {
+			switch v := v.(*EventRequestCheckpoint); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2578,7 +4609,7 @@ func file_state_state_proto_init() {
 			}
 		}
 		file_state_state_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ActionRequestSlot); i {
+			switch v := v.(*EventCommitsApplied); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2590,7 +4621,7 @@ func file_state_state_proto_init() {
 			}
 		}
 		file_state_state_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ActionForward); i {
+			switch v := v.(*Action); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2602,7 +4633,7 @@ func file_state_state_proto_init() {
 			}
 		}
 		file_state_state_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ActionStateApplied); i {
+			switch v := v.(*ActionAuditDigest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2614,7 +4645,7 @@ func file_state_state_proto_init() {
 			}
 		}
 		file_state_state_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ActionHashRequest); i {
+			switch v := v.(*ActionCoordinatedCheckpoint); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2626,7 +4657,7 @@ func file_state_state_proto_init() {
 			}
 		}
 		file_state_state_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ActionStateTarget); i {
+			switch v := v.(*ActionSend); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2638,7 +4669,7 @@ func file_state_state_proto_init() {
 			}
 		}
 		file_state_state_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EventMessage); i {
+			switch v := v.(*ActionTruncate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2650,7 +4681,7 @@ func file_state_state_proto_init() {
 			}
 		}
 		file_state_state_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HashOrigin_Batch); i {
+			switch v := v.(*ActionWrite); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2662,7 +4693,7 @@ func file_state_state_proto_init() {
 			}
 		}
 		file_state_state_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HashOrigin_VerifyBatch); i {
+			switch v := v.(*ActionCommit); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2674,6 +4705,234 @@ func file_state_state_proto_init() {
 			}
 		}
 		file_state_state_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionCheckpoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_state_state_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionRequestSlot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_state_state_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionForward); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_state_state_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionStateApplied); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_state_state_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionLeadershipChanged); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_state_state_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionEpochInstability); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_state_state_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionEpochChanged); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_state_state_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionNetworkAsymmetry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_state_state_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionClientStatePruned); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_state_state_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionConfigMismatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_state_state_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionMisbehavior); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_state_state_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionPersistWatermark); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_state_state_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionUnrecoverable); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_state_state_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionHashRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_state_state_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionStateTarget); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_state_state_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_state_state_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EventMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_state_state_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HashOrigin_Batch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_state_state_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HashOrigin_VerifyBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_state_state_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*HashOrigin_EpochChange); i {
 			case 0:
 				return &v.state
@@ -2685,6 +4944,18 @@ func file_state_state_proto_init() {
 				return nil
 			}
 		}
+		file_state_state_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HashOrigin_CheckpointRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_state_state_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*Event_Initialize)(nil),
@@ -2700,13 +4971,21 @@ func file_state_state_proto_init() {
 		(*Event_ActionsReceived)(nil),
 		(*Event_Message)(nil),
 		(*Event_Request)(nil),
+		(*Event_CommitsApplied)(nil),
+		(*Event_Compact)(nil),
+		(*Event_StepDown)(nil),
+		(*Event_AuditDigest)(nil),
+		(*Event_ImportClientState)(nil),
+		(*Event_ResetMisbehavior)(nil),
+		(*Event_RequestCheckpoint)(nil),
 	}
 	file_state_state_proto_msgTypes[10].OneofWrappers = []interface{}{
 		(*HashOrigin_Batch_)(nil),
 		(*HashOrigin_EpochChange_)(nil),
 		(*HashOrigin_VerifyBatch_)(nil),
+		(*HashOrigin_CheckpointRequest_)(nil),
 	}
-	file_state_state_proto_msgTypes[13].OneofWrappers = []interface{}{
+	file_state_state_proto_msgTypes[20].OneofWrappers = []interface{}{
 		(*Action_Send)(nil),
 		(*Action_Hash)(nil),
 		(*Action_AppendWriteAhead)(nil),
@@ -2718,6 +4997,17 @@ func file_state_state_proto_init() {
 		(*Action_ForwardRequest)(nil),
 		(*Action_StateTransfer)(nil),
 		(*Action_StateApplied)(nil),
+		(*Action_LeadershipChanged)(nil),
+		(*Action_Unrecoverable)(nil),
+		(*Action_AuditDigest)(nil),
+		(*Action_EpochInstability)(nil),
+		(*Action_ConfigMismatch)(nil),
+		(*Action_Misbehavior)(nil),
+		(*Action_PersistWatermark)(nil),
+		(*Action_EpochChanged)(nil),
+		(*Action_ClientStatePruned)(nil),
+		(*Action_CoordinatedCheckpoint)(nil),
+		(*Action_NetworkAsymmetry)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -2725,7 +5015,7 @@ func file_state_state_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_state_state_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   28,
+			NumMessages:   48,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
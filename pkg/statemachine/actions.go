@@ -8,11 +8,24 @@ package statemachine
 
 import (
 	"container/list"
+	"encoding/hex"
+	"encoding/json"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 
 	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
 	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
 )
 
+// ActionList is an ordered list of actions produced by the state machine.
+//
+// The state machine guarantees that any write-ahead log entry (such as a
+// QEntry, PEntry, or Suspect) is appended to the list before any Send which
+// depends on it.  Consumers must therefore ensure that every Persist action
+// is durable before acting on any Send which follows it in the same list,
+// otherwise a crash could cause a node to contradict messages it has
+// already sent.
 type ActionList struct {
 	list *list.List
 }
@@ -78,17 +91,17 @@ func ActionAllocateRequest(clientID, reqNo uint64) *state.Action {
 	}
 }
 
-func (al *ActionList) ForwardRequest(targets []uint64, requestAck *msgs.RequestAck) *ActionList {
-	al.PushBack(ActionForwardRequest(targets, requestAck))
+func (al *ActionList) ForwardRequest(targets []uint64, requestAcks ...*msgs.RequestAck) *ActionList {
+	al.PushBack(ActionForwardRequest(targets, requestAcks...))
 	return al
 }
 
-func ActionForwardRequest(targets []uint64, requestAck *msgs.RequestAck) *state.Action {
+func ActionForwardRequest(targets []uint64, requestAcks ...*msgs.RequestAck) *state.Action {
 	return &state.Action{
 		Type: &state.Action_ForwardRequest{
 			ForwardRequest: &state.ActionForward{
 				Targets: targets,
-				Ack:     requestAck,
+				Acks:    requestAcks,
 			},
 		},
 	}
@@ -125,16 +138,18 @@ func ActionPersist(index uint64, p *msgs.Persistent) *state.Action {
 	}
 }
 
-func (al *ActionList) Commit(qEntry *msgs.QEntry) *ActionList {
-	al.PushBack(ActionCommit(qEntry))
+func (al *ActionList) Commit(qEntry *msgs.QEntry, configChanges []*msgs.RequestAck, gaps ...uint64) *ActionList {
+	al.PushBack(ActionCommit(qEntry, configChanges, gaps...))
 	return al
 }
 
-func ActionCommit(qEntry *msgs.QEntry) *state.Action {
+func ActionCommit(qEntry *msgs.QEntry, configChanges []*msgs.RequestAck, gaps ...uint64) *state.Action {
 	return &state.Action{
 		Type: &state.Action_Commit{
 			Commit: &state.ActionCommit{
-				Batch: qEntry,
+				Batch:         qEntry,
+				ConfigChanges: configChanges,
+				Gaps:          gaps,
 			},
 		},
 	}
@@ -202,6 +217,22 @@ func ActionStateApplied(seqNo uint64, ns *msgs.NetworkState) *state.Action {
 	}
 }
 
+func (al *ActionList) AuditDigest(seqNo uint64, uncommitted bool) *ActionList {
+	al.PushBack(ActionAuditDigest(seqNo, uncommitted))
+	return al
+}
+
+func ActionAuditDigest(seqNo uint64, uncommitted bool) *state.Action {
+	return &state.Action{
+		Type: &state.Action_AuditDigest{
+			AuditDigest: &state.ActionAuditDigest{
+				SeqNo:       seqNo,
+				Uncommitted: uncommitted,
+			},
+		},
+	}
+}
+
 func (al *ActionList) StateTransfer(seqNo uint64, value []byte) *ActionList {
 	al.PushBack(ActionStateTransfer(seqNo, value))
 	return al
@@ -218,6 +249,186 @@ func ActionStateTransfer(seqNo uint64, value []byte) *state.Action {
 	}
 }
 
+func (al *ActionList) LeadershipChanged(epoch uint64, gainedBuckets, lostBuckets []uint64) *ActionList {
+	al.PushBack(ActionLeadershipChanged(epoch, gainedBuckets, lostBuckets))
+	return al
+}
+
+func ActionLeadershipChanged(epoch uint64, gainedBuckets, lostBuckets []uint64) *state.Action {
+	return &state.Action{
+		Type: &state.Action_LeadershipChanged{
+			LeadershipChanged: &state.ActionLeadershipChanged{
+				Epoch:         epoch,
+				GainedBuckets: gainedBuckets,
+				LostBuckets:   lostBuckets,
+			},
+		},
+	}
+}
+
+func (al *ActionList) EpochInstability(epoch uint64, epochChanges, windowTicks uint32) *ActionList {
+	al.PushBack(ActionEpochInstability(epoch, epochChanges, windowTicks))
+	return al
+}
+
+func ActionEpochInstability(epoch uint64, epochChanges, windowTicks uint32) *state.Action {
+	return &state.Action{
+		Type: &state.Action_EpochInstability{
+			EpochInstability: &state.ActionEpochInstability{
+				Epoch:        epoch,
+				EpochChanges: epochChanges,
+				WindowTicks:  windowTicks,
+			},
+		},
+	}
+}
+
+// The reasons reported by an EpochChanged action.
+const (
+	EpochChangeExpired   = "expired"
+	EpochChangeTimeout   = "timeout"
+	EpochChangeSuspicion = "suspicion"
+	EpochChangeStepDown  = "step_down"
+	EpochChangeCatchUp   = "catch_up"
+)
+
+func (al *ActionList) EpochChanged(epoch, previousEpoch uint64, reason string) *ActionList {
+	al.PushBack(ActionEpochChanged(epoch, previousEpoch, reason))
+	return al
+}
+
+func ActionEpochChanged(epoch, previousEpoch uint64, reason string) *state.Action {
+	return &state.Action{
+		Type: &state.Action_EpochChanged{
+			EpochChanged: &state.ActionEpochChanged{
+				Epoch:         epoch,
+				PreviousEpoch: previousEpoch,
+				Reason:        reason,
+			},
+		},
+	}
+}
+
+func (al *ActionList) CoordinatedCheckpoint(seqNo uint64) *ActionList {
+	al.PushBack(ActionCoordinatedCheckpoint(seqNo))
+	return al
+}
+
+func ActionCoordinatedCheckpoint(seqNo uint64) *state.Action {
+	return &state.Action{
+		Type: &state.Action_CoordinatedCheckpoint{
+			CoordinatedCheckpoint: &state.ActionCoordinatedCheckpoint{
+				SeqNo: seqNo,
+			},
+		},
+	}
+}
+
+func (al *ActionList) NetworkAsymmetry(epoch uint64, peers []uint64) *ActionList {
+	al.PushBack(ActionNetworkAsymmetry(epoch, peers))
+	return al
+}
+
+func ActionNetworkAsymmetry(epoch uint64, peers []uint64) *state.Action {
+	return &state.Action{
+		Type: &state.Action_NetworkAsymmetry{
+			NetworkAsymmetry: &state.ActionNetworkAsymmetry{
+				Epoch: epoch,
+				Peers: peers,
+			},
+		},
+	}
+}
+
+func (al *ActionList) ClientStatePruned(seqNo uint64, clients []*msgs.NetworkState_Client) *ActionList {
+	al.PushBack(ActionClientStatePruned(seqNo, clients))
+	return al
+}
+
+func ActionClientStatePruned(seqNo uint64, clients []*msgs.NetworkState_Client) *state.Action {
+	return &state.Action{
+		Type: &state.Action_ClientStatePruned{
+			ClientStatePruned: &state.ActionClientStatePruned{
+				SeqNo:   seqNo,
+				Clients: clients,
+			},
+		},
+	}
+}
+
+func (al *ActionList) ConfigMismatch(nodeID, seqNo uint64, reason string) *ActionList {
+	al.PushBack(ActionConfigMismatch(nodeID, seqNo, reason))
+	return al
+}
+
+func ActionConfigMismatch(nodeID, seqNo uint64, reason string) *state.Action {
+	return &state.Action{
+		Type: &state.Action_ConfigMismatch{
+			ConfigMismatch: &state.ActionConfigMismatch{
+				NodeId: nodeID,
+				SeqNo:  seqNo,
+				Reason: reason,
+			},
+		},
+	}
+}
+
+func (al *ActionList) Misbehavior(nodeID uint64, reason string) *ActionList {
+	al.PushBack(ActionMisbehavior(nodeID, reason))
+	return al
+}
+
+func ActionMisbehavior(nodeID uint64, reason string) *state.Action {
+	return &state.Action{
+		Type: &state.Action_Misbehavior{
+			Misbehavior: &state.ActionMisbehavior{
+				NodeId: nodeID,
+				Reason: reason,
+			},
+		},
+	}
+}
+
+func (al *ActionList) PersistWatermark(seqNo uint64) *ActionList {
+	al.PushBack(ActionPersistWatermark(seqNo))
+	return al
+}
+
+func ActionPersistWatermark(seqNo uint64) *state.Action {
+	return &state.Action{
+		Type: &state.Action_PersistWatermark{
+			PersistWatermark: &state.ActionPersistWatermark{
+				SeqNo: seqNo,
+			},
+		},
+	}
+}
+
+func (al *ActionList) Unrecoverable(reason string) *ActionList {
+	al.PushBack(ActionUnrecoverable(reason))
+	return al
+}
+
+func ActionUnrecoverable(reason string) *state.Action {
+	return &state.Action{
+		Type: &state.Action_Unrecoverable{
+			Unrecoverable: &state.ActionUnrecoverable{
+				Reason: reason,
+			},
+		},
+	}
+}
+
+// clone returns a deep copy of the list, sharing no actions with it.
+func (al *ActionList) clone() *ActionList {
+	result := &ActionList{}
+	iter := al.Iterator()
+	for action := iter.Next(); action != nil; action = iter.Next() {
+		result.PushBack(proto.Clone(action).(*state.Action))
+	}
+	return result
+}
+
 func (al *ActionList) isEmpty() bool {
 	return al.list == nil || al.list.Len() == 0
 }
@@ -231,6 +442,72 @@ func (al *ActionList) concat(o *ActionList) *ActionList {
 	return al
 }
 
+// MarshalJSON renders the actions as a JSON array.  The rendering is stable,
+// object keys are sorted and byte fields are hex encoded, so that it is
+// suitable both for logging and for comparing actions in tests.
+func (al *ActionList) MarshalJSON() ([]byte, error) {
+	actions := []interface{}{}
+	iter := al.Iterator()
+	for action := iter.Next(); action != nil; action = iter.Next() {
+		actions = append(actions, jsonValue(action.ProtoReflect()))
+	}
+
+	return json.Marshal(actions)
+}
+
+// String returns the JSON rendering of the actions.
+func (al *ActionList) String() string {
+	data, err := al.MarshalJSON()
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+// jsonValue converts a message into a value which encoding/json renders
+// deterministically, as encoding/json sorts the keys of maps.
+func jsonValue(m protoreflect.Message) map[string]interface{} {
+	result := map[string]interface{}{}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			list := v.List()
+			values := make([]interface{}, list.Len())
+			for i := range values {
+				values[i] = jsonScalar(fd, list.Get(i))
+			}
+			result[string(fd.Name())] = values
+		case fd.IsMap():
+			values := map[string]interface{}{}
+			v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+				values[k.String()] = jsonScalar(fd.MapValue(), mv)
+				return true
+			})
+			result[string(fd.Name())] = values
+		default:
+			result[string(fd.Name())] = jsonScalar(fd, v)
+		}
+		return true
+	})
+	return result
+}
+
+func jsonScalar(fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return jsonValue(v.Message())
+	case protoreflect.BytesKind:
+		return hex.EncodeToString(v.Bytes())
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return int32(v.Enum())
+	default:
+		return v.Interface()
+	}
+}
+
 type ActionListIterator struct {
 	currentElement *list.Element
 }
@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statemachine
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+)
+
+var _ = Describe("ActionList", func() {
+	It("renders as stable JSON", func() {
+		actions := (&ActionList{}).Persist(
+			3,
+			&msgs.Persistent{
+				Type: &msgs.Persistent_PEntry{
+					PEntry: &msgs.PEntry{
+						SeqNo:  5,
+						Digest: []byte{0xca, 0xfe},
+					},
+				},
+			},
+		).Send(
+			[]uint64{0, 1, 2, 3},
+			&msgs.Msg{
+				Type: &msgs.Msg_Commit{
+					Commit: &msgs.Commit{
+						SeqNo:  5,
+						Epoch:  4,
+						Digest: []byte{0xca, 0xfe},
+					},
+				},
+			},
+		).Hash(
+			[][]byte{{0x01}, {0x02, 0x03}},
+			&state.HashOrigin{
+				Type: &state.HashOrigin_Batch_{
+					Batch: &state.HashOrigin_Batch{
+						Source: 1,
+						SeqNo:  6,
+						Epoch:  4,
+					},
+				},
+			},
+		)
+
+		expected := `[` +
+			`{"append_write_ahead":{"data":{"p_entry":{"digest":"cafe","seq_no":5}},"index":3}},` +
+			`{"send":{"msg":{"commit":{"digest":"cafe","epoch":4,"seq_no":5}},"targets":[0,1,2,3]}},` +
+			`{"hash":{"data":["01","0203"],"origin":{"batch":{"epoch":4,"seq_no":6,"source":1}}}}` +
+			`]`
+
+		data, err := actions.MarshalJSON()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(expected))
+		Expect(actions.String()).To(Equal(expected))
+	})
+})
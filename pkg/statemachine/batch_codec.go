@@ -0,0 +1,178 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statemachine
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hyperledger-labs/mirbft/pkg/logger"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+
+	"github.com/pkg/errors"
+)
+
+// BatchCodec compresses the batches of the preprepares this node sends, and
+// decompresses those of the preprepares it receives.  It is only used when its
+// name matches the batch compression of the network configuration, so that
+// every node of the network agrees on how batches are encoded.  Decompress
+// must return an error rather than decompress data to more than limit bytes.
+type BatchCodec interface {
+	Name() string
+	Compress(data []byte) ([]byte, error)
+	Decompress(data []byte, limit int) ([]byte, error)
+}
+
+// defaultMaxDecompressedBatchBytes bounds the size to which batches are
+// decompressed, when the initial parameters do not specify one.
+const defaultMaxDecompressedBatchBytes = 16 << 20
+
+// compressBatch returns a copy of the preprepare whose batch is replaced by
+// its compressed encoding.
+func compressBatch(pp *msgs.Preprepare, codec BatchCodec) (*msgs.Preprepare, error) {
+	data, err := proto.Marshal(&msgs.Preprepare{Batch: pp.Batch})
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not marshal batch")
+	}
+
+	compressed, err := codec.Compress(data)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not compress batch with codec %q", codec.Name())
+	}
+
+	return &msgs.Preprepare{
+		SeqNo:           pp.SeqNo,
+		Epoch:           pp.Epoch,
+		CompressedBatch: compressed,
+	}, nil
+}
+
+// decompressBatch returns a copy of the preprepare whose compressed batch is
+// replaced by the batch it encodes, of at most limit bytes.  The digest of the
+// batch is later computed over the decompressed request acks, so compression
+// never affects consensus.
+func decompressBatch(pp *msgs.Preprepare, codec BatchCodec, limit int) (*msgs.Preprepare, error) {
+	if len(pp.Batch) != 0 {
+		return nil, errors.Errorf("preprepare carries both a batch and a compressed batch")
+	}
+
+	data, err := codec.Decompress(pp.CompressedBatch, limit)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not decompress batch with codec %q", codec.Name())
+	}
+
+	if len(data) > limit {
+		return nil, errors.Errorf("batch decompressed with codec %q to %d bytes, exceeding the limit of %d", codec.Name(), len(data), limit)
+	}
+
+	batch := &msgs.Preprepare{}
+	if err := proto.Unmarshal(data, batch); err != nil {
+		return nil, errors.WithMessage(err, "could not unmarshal batch")
+	}
+
+	return &msgs.Preprepare{
+		SeqNo: pp.SeqNo,
+		Epoch: pp.Epoch,
+		Batch: batch.Batch,
+	}, nil
+}
+
+// batchCodec returns the codec to apply to preprepare batches under the
+// active network configuration, or nil if batches are sent uncompressed.
+func (sm *StateMachine) batchCodec() (BatchCodec, error) {
+	name := sm.commitState.activeState.Config.BatchCompression
+	if name == "" {
+		return nil, nil
+	}
+
+	if sm.BatchCodec == nil || sm.BatchCodec.Name() != name {
+		return nil, errors.Errorf("network compresses batches with codec %q which this node does not have", name)
+	}
+
+	return sm.BatchCodec, nil
+}
+
+// compressSends replaces the preprepares sent in actions by copies with
+// compressed batches, if the network configuration calls for it.
+func (sm *StateMachine) compressSends(actions *ActionList) *ActionList {
+	if sm.commitState.activeState.Config.BatchCompression == "" {
+		return actions
+	}
+
+	codec, codecErr := sm.batchCodec()
+
+	result := &ActionList{}
+	iter := actions.Iterator()
+	for action := iter.Next(); action != nil; action = iter.Next() {
+		send, ok := action.Type.(*state.Action_Send)
+		if !ok {
+			result.PushBack(action)
+			continue
+		}
+
+		pp, ok := send.Send.Msg.Type.(*msgs.Msg_Preprepare)
+		if !ok || len(pp.Preprepare.Batch) == 0 {
+			result.PushBack(action)
+			continue
+		}
+
+		if codecErr != nil {
+			sm.Logger.Log(logger.LevelWarn, "sending preprepare uncompressed", "seq_no", pp.Preprepare.SeqNo, "err", codecErr)
+			result.PushBack(action)
+			continue
+		}
+
+		compressed, err := compressBatch(pp.Preprepare, codec)
+		if err != nil {
+			sm.Logger.Log(logger.LevelWarn, "sending preprepare uncompressed", "seq_no", pp.Preprepare.SeqNo, "err", err)
+			result.PushBack(action)
+			continue
+		}
+
+		result.Send(send.Send.Targets, &msgs.Msg{
+			Type: &msgs.Msg_Preprepare{
+				Preprepare: compressed,
+			},
+		})
+	}
+
+	return result
+}
+
+// decompressStep returns the message with its preprepare batch decompressed,
+// if it carries a compressed one.
+func (sm *StateMachine) decompressStep(msg *msgs.Msg) (*msgs.Msg, error) {
+	pp, ok := msg.Type.(*msgs.Msg_Preprepare)
+	if !ok || pp.Preprepare.CompressedBatch == nil {
+		return msg, nil
+	}
+
+	codec, err := sm.batchCodec()
+	if err != nil {
+		return nil, err
+	}
+
+	if codec == nil {
+		return nil, errors.Errorf("received a compressed batch, but the network does not compress batches")
+	}
+
+	limit := int(sm.myConfig.MaxDecompressedBatchBytes)
+	if limit == 0 {
+		limit = defaultMaxDecompressedBatchBytes
+	}
+
+	decompressed, err := decompressBatch(pp.Preprepare, codec, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &msgs.Msg{
+		Type: &msgs.Msg_Preprepare{
+			Preprepare: decompressed,
+		},
+	}, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statemachine
+
+import (
+	"bytes"
+	"compress/flate"
+	"io"
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+
+	"github.com/pkg/errors"
+)
+
+// flateCodec is a BatchCodec backed by DEFLATE.
+type flateCodec struct{}
+
+func (flateCodec) Name() string {
+	return "flate"
+}
+
+func (flateCodec) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (flateCodec) Decompress(data []byte, limit int) ([]byte, error) {
+	decompressed, err := ioutil.ReadAll(io.LimitReader(flate.NewReader(bytes.NewReader(data)), int64(limit)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(decompressed) > limit {
+		return nil, errors.Errorf("data decompresses to more than %d bytes", limit)
+	}
+	return decompressed, nil
+}
+
+var _ = Describe("compressBatch", func() {
+	var (
+		pp *msgs.Preprepare
+	)
+
+	BeforeEach(func() {
+		pp = &msgs.Preprepare{
+			SeqNo: 7,
+			Epoch: 2,
+		}
+		for i := uint64(0); i < 20; i++ {
+			pp.Batch = append(pp.Batch, &msgs.RequestAck{
+				ClientId: 3,
+				ReqNo:    i,
+				Digest:   bytes.Repeat([]byte{byte(i)}, 32),
+			})
+		}
+	})
+
+	It("round trips the batch, preserving its digest", func() {
+		compressed, err := compressBatch(pp, flateCodec{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(compressed.Batch).To(BeEmpty())
+		Expect(compressed.SeqNo).To(Equal(uint64(7)))
+		Expect(compressed.Epoch).To(Equal(uint64(2)))
+
+		decompressed, err := decompressBatch(compressed, flateCodec{}, defaultMaxDecompressedBatchBytes)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decompressed.CompressedBatch).To(BeNil())
+		Expect(decompressed.SeqNo).To(Equal(uint64(7)))
+		Expect(decompressed.Epoch).To(Equal(uint64(2)))
+		Expect(decompressed.Batch).To(HaveLen(20))
+		for i, ack := range decompressed.Batch {
+			Expect(ack.ClientId).To(Equal(pp.Batch[i].ClientId))
+			Expect(ack.ReqNo).To(Equal(pp.Batch[i].ReqNo))
+		}
+		Expect(batchHashData(decompressed.Batch)).To(Equal(batchHashData(pp.Batch)))
+	})
+
+	It("rejects a preprepare with both a batch and a compressed batch", func() {
+		compressed, err := compressBatch(pp, flateCodec{})
+		Expect(err).NotTo(HaveOccurred())
+		compressed.Batch = pp.Batch
+
+		_, err = decompressBatch(compressed, flateCodec{}, defaultMaxDecompressedBatchBytes)
+		Expect(err).To(MatchError("preprepare carries both a batch and a compressed batch"))
+	})
+
+	It("rejects a batch which decompresses beyond the limit", func() {
+		compressed, err := compressBatch(pp, flateCodec{})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = decompressBatch(compressed, flateCodec{}, 64)
+		Expect(err).To(MatchError(`could not decompress batch with codec "flate": data decompresses to more than 64 bytes`))
+	})
+})
@@ -37,6 +37,14 @@ type checkpointTracker struct {
 	networkConfig      *msgs.NetworkState_Config
 	persisted          *persisted
 
+	// learnerCheckpoints is the highest checkpoint each learner of the
+	// network reported having caught up to.
+	learnerCheckpoints map[nodeID]uint64
+
+	// lastCheckpoints is the highest checkpoint each node sent, including
+	// those below the low watermark, see lagging.
+	lastCheckpoints map[nodeID]uint64
+
 	nodeBuffers *nodeBuffers
 	myConfig    *state.EventInitialParameters
 	logger      logger.Logger
@@ -44,11 +52,13 @@ type checkpointTracker struct {
 
 func newCheckpointTracker(seqNo uint64, networkState *msgs.NetworkState, persisted *persisted, nodeBuffers *nodeBuffers, myConfig *state.EventInitialParameters, logger logger.Logger) *checkpointTracker {
 	ct := &checkpointTracker{
-		myConfig:    myConfig,
-		state:       cpsIdle,
-		persisted:   persisted,
-		nodeBuffers: nodeBuffers,
-		logger:      logger,
+		myConfig:           myConfig,
+		state:              cpsIdle,
+		persisted:          persisted,
+		nodeBuffers:        nodeBuffers,
+		logger:             logger,
+		learnerCheckpoints: map[nodeID]uint64{},
+		lastCheckpoints:    map[nodeID]uint64{},
 	}
 
 	return ct
@@ -89,6 +99,12 @@ func (ct *checkpointTracker) reinitialize() {
 		validNodes[nodeID(id)] = struct{}{}
 	}
 
+	for learner := range ct.learnerCheckpoints {
+		if !isLearner(ct.networkConfig, learner) {
+			delete(ct.learnerCheckpoints, learner)
+		}
+	}
+
 	// Lots of non-determinism in this iteration... but it should
 	// all be commutative.
 	for seqNo, cp := range oldCheckpointMap {
@@ -124,6 +140,10 @@ func (ct *checkpointTracker) filter(_ nodeID, msg *msgs.Msg) applyable {
 }
 
 func (ct *checkpointTracker) step(source nodeID, msg *msgs.Msg) {
+	if seqNo := msg.Type.(*msgs.Msg_Checkpoint).Checkpoint.SeqNo; seqNo > ct.lastCheckpoints[source] {
+		ct.lastCheckpoints[source] = seqNo
+	}
+
 	switch ct.filter(source, msg) {
 	case past:
 		return
@@ -197,6 +217,54 @@ func (ct *checkpointTracker) lowWatermark() uint64 {
 	return ct.activeCheckpoints.Front().Value.(*checkpoint).seqNo
 }
 
+// highestNetworkCheckpoint returns the highest checkpoint sequence number
+// whose value f+1 nodes agree on, whether or not this node has reached it.
+func (ct *checkpointTracker) highestNetworkCheckpoint() uint64 {
+	highest := ct.lowWatermark()
+	for seqNo, cp := range ct.checkpointMap {
+		if cp.committedValue != nil && seqNo > highest {
+			highest = seqNo
+		}
+	}
+
+	return highest
+}
+
+// lagging returns whether the last checkpoint source sent is below the low
+// watermark, that is, whether source is behind this node, e.g. as it is
+// recovering from a partition.
+func (ct *checkpointTracker) lagging(source nodeID) bool {
+	return ct.lastCheckpoints[source] < ct.lowWatermark()
+}
+
+// networkCheckpoint returns the sequence number and value of the highest
+// checkpoint whose value f+1 nodes agree on, or zero if there is none.
+func (ct *checkpointTracker) networkCheckpoint() (uint64, []byte) {
+	var highest uint64
+	var value []byte
+	for seqNo, cp := range ct.checkpointMap {
+		if cp.committedValue != nil && seqNo > highest {
+			highest = seqNo
+			value = cp.committedValue
+		}
+	}
+
+	return highest, value
+}
+
+// applyLearnerCheckpoint records that the learner source caught up to the
+// checkpoint at seqNo, provided the network agrees on its value.
+func (ct *checkpointTracker) applyLearnerCheckpoint(source nodeID, seqNo uint64, value []byte) {
+	cp, ok := ct.checkpointMap[seqNo]
+	if !ok || cp.committedValue == nil || !bytes.Equal(cp.committedValue, value) {
+		return
+	}
+
+	if seqNo > ct.learnerCheckpoints[source] {
+		ct.learnerCheckpoints[source] = seqNo
+	}
+}
+
 func (ct *checkpointTracker) applyCheckpointMsg(source nodeID, seqNo uint64, value []byte) {
 	aboveHighWatermark := seqNo > ct.highWatermark()
 	if aboveHighWatermark {
@@ -256,6 +324,37 @@ func (ct *checkpointTracker) status() []*status.Checkpoint {
 	return result
 }
 
+// learnerStatus reports how far each learner of the network has caught up.
+func (ct *checkpointTracker) learnerStatus() []*status.Learner {
+	result := make([]*status.Learner, len(ct.networkConfig.Learners))
+	for i, learner := range ct.networkConfig.Learners {
+		result[i] = &status.Learner{
+			ID:         learner,
+			CaughtUpTo: ct.learnerCheckpoints[nodeID(learner)],
+		}
+	}
+
+	return result
+}
+
+// pendingStatus reports the checkpoints for which values have been collected
+// but which have not yet become stable.
+func (ct *checkpointTracker) pendingStatus() []*status.CheckpointState {
+	result := []*status.CheckpointState{}
+	for _, cp := range ct.checkpointMap {
+		if cp.stable || len(cp.values) == 0 {
+			continue
+		}
+		result = append(result, cp.pendingStatus())
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].SeqNo < result[j].SeqNo
+	})
+
+	return result
+}
+
 type checkpoint struct {
 	seqNo         uint64
 	myConfig      *state.EventInitialParameters
@@ -273,6 +372,17 @@ func (cw *checkpoint) applyCheckpointMsg(source nodeID, value []byte) {
 		cw.values = map[string][]nodeID{}
 	}
 
+	// Checkpoints above the high watermark are applied when received, and
+	// again from the buffer once the watermarks reach them, so a source may
+	// be seen more than once, but must only be counted once.
+	for _, nodes := range cw.values {
+		for _, node := range nodes {
+			if node == source {
+				return
+			}
+		}
+	}
+
 	checkpointValueNodes := append(cw.values[string(value)], source)
 	cw.values[string(value)] = checkpointValueNodes
 
@@ -319,3 +429,22 @@ func (cw *checkpoint) status() *status.Checkpoint {
 		LocalDecision: cw.myValue != nil,
 	}
 }
+
+func (cw *checkpoint) pendingStatus() *status.CheckpointState {
+	sources := []uint64{}
+	for _, nodes := range cw.values {
+		for _, node := range nodes {
+			sources = append(sources, uint64(node))
+		}
+	}
+
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i] < sources[j]
+	})
+
+	return &status.CheckpointState{
+		SeqNo:   cw.seqNo,
+		Values:  len(sources),
+		Sources: sources,
+	}
+}
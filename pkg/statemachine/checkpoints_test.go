@@ -0,0 +1,114 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statemachine
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/mirbft/pkg/logger"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+)
+
+var _ = Describe("checkpointTracker", func() {
+	var ct *checkpointTracker
+
+	checkpointMsg := func(seqNo uint64) *msgs.Msg {
+		return &msgs.Msg{
+			Type: &msgs.Msg_Checkpoint{
+				Checkpoint: &msgs.Checkpoint{
+					SeqNo: seqNo,
+					Value: []byte("value"),
+				},
+			},
+		}
+	}
+
+	// stabilize makes the checkpoint at seqNo stable, with agreement from
+	// every node, and garbage collects the checkpoints below it.
+	stabilize := func(seqNo uint64) {
+		for _, source := range []nodeID{0, 1, 2, 3} {
+			ct.step(source, checkpointMsg(seqNo))
+		}
+		Expect(ct.state).To(Equal(cpsGarbageCollectable))
+		Expect(ct.garbageCollect()).To(Equal(seqNo))
+	}
+
+	BeforeEach(func() {
+		myConfig := &state.EventInitialParameters{
+			Id:         0,
+			BufferSize: 1024 * 1024,
+		}
+
+		persisted := newPersisted(logger.ConsoleErrorLogger)
+		persisted.appendInitialLoad(1, &msgs.Persistent{
+			Type: &msgs.Persistent_CEntry{
+				CEntry: &msgs.CEntry{
+					CheckpointValue: []byte("value"),
+					NetworkState: &msgs.NetworkState{
+						Config: &msgs.NetworkState_Config{
+							Nodes:              []uint64{0, 1, 2, 3},
+							F:                  1,
+							NumberOfBuckets:    4,
+							CheckpointInterval: 5,
+						},
+					},
+				},
+			},
+		})
+
+		ct = newCheckpointTracker(0, nil, persisted, newNodeBuffers(myConfig, logger.ConsoleErrorLogger), myConfig, logger.ConsoleErrorLogger)
+		ct.reinitialize()
+		Expect(ct.lowWatermark()).To(Equal(uint64(0)))
+		Expect(ct.highWatermark()).To(Equal(uint64(10)))
+	})
+
+	It("counts the checkpoints of peers received before this node reaches the sequence", func() {
+		ct.step(1, checkpointMsg(5))
+		ct.step(2, checkpointMsg(5))
+		Expect(ct.checkpoint(5).stable).To(BeFalse())
+		Expect(ct.state).To(Equal(cpsIdle))
+
+		ct.step(0, checkpointMsg(5))
+		Expect(ct.checkpoint(5).stable).To(BeTrue())
+		Expect(ct.state).To(Equal(cpsGarbageCollectable))
+		Expect(ct.garbageCollect()).To(Equal(uint64(5)))
+	})
+
+	It("buffers checkpoints above the high watermark and counts them once this node reaches the sequence", func() {
+		ct.step(1, checkpointMsg(15))
+		ct.step(2, checkpointMsg(15))
+
+		stabilize(5)
+		stabilize(10)
+		Expect(ct.highWatermark()).To(Equal(uint64(20)))
+		Expect(ct.checkpoint(15).stable).To(BeFalse())
+
+		ct.step(0, checkpointMsg(15))
+		Expect(ct.checkpoint(15).values).To(Equal(map[string][]nodeID{
+			"value": {1, 2, 0},
+		}))
+		Expect(ct.checkpoint(15).stable).To(BeTrue())
+		Expect(ct.state).To(Equal(cpsGarbageCollectable))
+		Expect(ct.garbageCollect()).To(Equal(uint64(15)))
+	})
+
+	It("counts each peer once, however often its checkpoint is applied", func() {
+		ct.step(1, checkpointMsg(15))
+		ct.step(1, checkpointMsg(15))
+
+		stabilize(5)
+		stabilize(10)
+
+		ct.step(0, checkpointMsg(15))
+		Expect(ct.checkpoint(15).values).To(Equal(map[string][]nodeID{
+			"value": {1, 0},
+		}))
+		Expect(ct.checkpoint(15).stable).To(BeFalse())
+	})
+})
@@ -9,6 +9,7 @@ package statemachine
 import (
 	"bytes"
 	"container/list"
+	"encoding/binary"
 	"fmt"
 	"github.com/hyperledger-labs/mirbft/pkg/logger"
 	"sort"
@@ -130,6 +131,14 @@ type clientHashDisseminator struct {
 	msgBuffers       map[nodeID]*msgBuffer
 	clients          map[uint64]*client
 	clientTracker    *clientTracker
+
+	// bucketLeader returns the leader of the bucket of a request in the
+	// active epoch, if an epoch is active, see withhold.
+	bucketLeader func(clientID, reqNo uint64) (nodeID, bool)
+
+	// withheld are the requests persisted locally which are rejected until
+	// the leader of their bucket acks them, see withhold.
+	withheld []*msgs.RequestAck
 }
 
 func newClientHashDisseminator(nodeBuffers *nodeBuffers, myConfig *state.EventInitialParameters, logger logger.Logger, clientTracker *clientTracker) *clientHashDisseminator {
@@ -186,6 +195,75 @@ func (ct *clientHashDisseminator) tick() *ActionList {
 		client := ct.clients[clientState.Id]
 		actions.concat(client.tick())
 	}
+
+	// The leadership of the buckets may have changed with the epoch.
+	actions.concat(ct.releaseWithheld())
+
+	return actions.concat(ct.forwardToLeaders())
+}
+
+// leaderForwardTicks is the number of ticks the leader of the bucket of a
+// request this node persisted may take to ack it, before this node forwards
+// the request to it.
+const leaderForwardTicks = 2
+
+// forwardToLeaders forwards the requests this node persisted which the
+// leader of their bucket has not acked within leaderForwardTicks ticks, so
+// that the leader need not fetch them one by one.  The requests lacking at
+// the same leader are forwarded together, in a single action.
+func (ct *clientHashDisseminator) forwardToLeaders() *ActionList {
+	actions := &ActionList{}
+	if ct.myConfig.DisableForwarding || ct.bucketLeader == nil {
+		return actions
+	}
+
+	forwards := map[nodeID][]*msgs.RequestAck{}
+	for _, clientState := range ct.clientStates {
+		client := ct.clients[clientState.Id]
+		for el := client.reqNoList.Front(); el != nil; el = el.Next() {
+			crn := el.Value.(*clientReqNo)
+			if crn.committed {
+				continue
+			}
+
+			leader, ok := ct.bucketLeader(crn.clientID, crn.reqNo)
+			if !ok || leader == nodeID(ct.myConfig.Id) {
+				continue
+			}
+
+			for _, cr := range crn.sortedMyRequests() {
+				if cr.ack.Digest == nil || cr.forwardedToLeader {
+					continue
+				}
+
+				if _, ok := cr.agreements[leader]; ok {
+					continue
+				}
+
+				if cr.ticksLeaderMissing < leaderForwardTicks {
+					cr.ticksLeaderMissing++
+					continue
+				}
+
+				cr.forwardedToLeader = true
+				forwards[leader] = append(forwards[leader], cr.ack)
+			}
+		}
+	}
+
+	leaders := make([]nodeID, 0, len(forwards))
+	for leader := range forwards {
+		leaders = append(leaders, leader)
+	}
+	sort.Slice(leaders, func(i, j int) bool {
+		return leaders[i] < leaders[j]
+	})
+
+	for _, leader := range leaders {
+		ct.logger.Log(logger.LevelDebug, "forwarding requests to the leader of their bucket", "leader", leader, "requests", len(forwards[leader]))
+		actions.ForwardRequest([]uint64{uint64(leader)}, forwards[leader]...)
+	}
+
 	return actions
 }
 
@@ -252,11 +330,93 @@ func (ct *clientHashDisseminator) applyNewRequest(ack *msgs.RequestAck) *ActionL
 		return &ActionList{}
 	}
 
-	client.reqNo(ack.ReqNo).applyNewRequest(ack)
+	if ct.withhold(client, ack) {
+		return &ActionList{}
+	}
+
+	if !client.reqNo(ack.ReqNo).applyNewRequest(ack) {
+		// The same request was proposed twice while still in flight,
+		// it is already acked and queued, so there is nothing to do.
+		ct.logger.Log(logger.LevelDebug, "ignoring duplicate request", "client_id", ack.ClientId, "req_no", ack.ReqNo)
+		return &ActionList{}
+	}
 
 	return client.advanceAcks()
 }
 
+// requestCheckpoint acknowledges a coordinated checkpoint request on behalf of
+// the operator: the first request of the first of the CheckpointClients which
+// has neither committed nor been persisted by this node.  The request carries
+// no data, so its digest only covers the client ID and request number,
+// encoded as for any other request, and it is acknowledged once hashed.  Every
+// node in sync picks the same request number, so that the acks of the nodes
+// asked for a checkpoint agree.
+func (ct *clientHashDisseminator) requestCheckpoint() *ActionList {
+	if len(ct.networkConfig.CheckpointClients) == 0 {
+		ct.logger.Log(logger.LevelWarn, "ignoring checkpoint request, no checkpoint clients are configured")
+		return &ActionList{}
+	}
+
+	clientID := ct.networkConfig.CheckpointClients[0]
+	client, ok := ct.clients[clientID]
+	if !ok {
+		ct.logger.Log(logger.LevelWarn, "ignoring checkpoint request, checkpoint client is unknown", "client_id", clientID)
+		return &ActionList{}
+	}
+
+	for el := client.reqNoList.Front(); el != nil; el = el.Next() {
+		crn := el.Value.(*clientReqNo)
+		if crn.committed || len(crn.myRequests) > 0 {
+			continue
+		}
+
+		data := make([]byte, 16)
+		binary.LittleEndian.PutUint64(data[:8], clientID)
+		binary.LittleEndian.PutUint64(data[8:], crn.reqNo)
+
+		return (&ActionList{}).Hash(
+			[][]byte{data},
+			&state.HashOrigin{
+				Type: &state.HashOrigin_CheckpointRequest_{
+					CheckpointRequest: &state.HashOrigin_CheckpointRequest{
+						ClientId: clientID,
+						ReqNo:    crn.reqNo,
+					},
+				},
+			},
+		)
+	}
+
+	ct.logger.Log(logger.LevelWarn, "ignoring checkpoint request, the window of the checkpoint client is exhausted", "client_id", clientID)
+	return &ActionList{}
+}
+
+// rejectRequest records that preprocessing a request failed, so that this node
+// neither acks nor proactively fetches it.  If the request was forwarded, the
+// forwarding node is reported as misbehaving.
+func (ct *clientHashDisseminator) rejectRequest(rejected *state.EventRequestPersisted) *ActionList {
+	actions := &ActionList{}
+	ack := rejected.RequestAck
+
+	if rejected.Forwarded {
+		actions.Misbehavior(rejected.Source, fmt.Sprintf("forwarded client_id=%d req_no=%d which was rejected: %s", ack.ClientId, ack.ReqNo, rejected.RejectReason))
+	}
+
+	client, ok := ct.clients[ack.ClientId]
+	if !ok || !client.inWatermarks(ack.ReqNo) {
+		return actions
+	}
+
+	if !client.reqNo(ack.ReqNo).rejectRequest(ack) {
+		ct.logger.Log(logger.LevelWarn, "ignoring rejection of a request which was already persisted", "client_id", ack.ClientId, "req_no", ack.ReqNo)
+		return actions
+	}
+
+	ct.logger.Log(logger.LevelInfo, "rejected request", "client_id", ack.ClientId, "req_no", ack.ReqNo, "reason", rejected.RejectReason)
+
+	return actions
+}
+
 // allocate should be invoked after the checkpoint is computed and advances the high watermark.
 func (ct *clientHashDisseminator) allocate(seqNo uint64, networkState *msgs.NetworkState) *ActionList {
 	assertEqual(seqNo, uint64(networkState.Config.CheckpointInterval)+ct.allocatedThrough, "unexpected skip in allocate, expected next allocation at next checkpoint")
@@ -312,7 +472,78 @@ func (ct *clientHashDisseminator) ack(source nodeID, ack *msgs.RequestAck) (*Act
 	c, ok := ct.clients[ack.ClientId]
 	assertEqual(ok, true, "the step filtering should delay reqs for non-existent clients")
 
-	return c.ack(source, ack)
+	actions, cr := c.ack(source, ack)
+	return actions.concat(ct.releaseWithheld()), cr
+}
+
+// withhold returns whether the locally persisted request must be rejected
+// for now, recording it if so.  When forwarding is disabled, a request of a
+// bucket led by another node is only batched if that leader received it from
+// the client as well, so this node acks it only once the leader does, or it
+// is known to be correct.
+func (ct *clientHashDisseminator) withhold(client *client, ack *msgs.RequestAck) bool {
+	if !ct.myConfig.DisableForwarding || ct.releasable(client, ack) {
+		return false
+	}
+
+	for _, withheld := range ct.withheld {
+		if withheld.ClientId == ack.ClientId && withheld.ReqNo == ack.ReqNo && bytes.Equal(withheld.Digest, ack.Digest) {
+			return true
+		}
+	}
+
+	ct.logger.Log(logger.LevelDebug, "rejecting request of a bucket led by another node until it acks the request", "client_id", ack.ClientId, "req_no", ack.ReqNo)
+	ct.withheld = append(ct.withheld, ack)
+	return true
+}
+
+// releasable returns whether a locally persisted request may be acked, as
+// this node leads its bucket, the leader of its bucket acked it, or it is
+// known to be correct.  While no epoch is active, the leader is unknown.
+func (ct *clientHashDisseminator) releasable(client *client, ack *msgs.RequestAck) bool {
+	if ct.bucketLeader == nil {
+		return true
+	}
+
+	leader, ok := ct.bucketLeader(ack.ClientId, ack.ReqNo)
+	if ok && leader == nodeID(ct.myConfig.Id) {
+		return true
+	}
+
+	cr, ok := client.reqNo(ack.ReqNo).requests[string(ack.Digest)]
+	if !ok {
+		return false
+	}
+
+	_, leaderAcked := cr.agreements[leader]
+	return (ok && leaderAcked) || len(cr.agreements) >= someCorrectQuorum(ct.networkConfig)
+}
+
+// releaseWithheld applies the withheld requests which may now be acked, and
+// forgets those which fell below the client watermarks.
+func (ct *clientHashDisseminator) releaseWithheld() *ActionList {
+	actions := &ActionList{}
+	if len(ct.withheld) == 0 {
+		return actions
+	}
+
+	withheld := ct.withheld
+	ct.withheld = nil
+	for _, ack := range withheld {
+		client, ok := ct.clients[ack.ClientId]
+		if !ok || !client.inWatermarks(ack.ReqNo) {
+			continue
+		}
+
+		if !ct.releasable(client, ack) {
+			ct.withheld = append(ct.withheld, ack)
+			continue
+		}
+
+		actions.concat(ct.applyNewRequest(ack))
+	}
+
+	return actions
 }
 
 func (ct *clientHashDisseminator) client(clientID uint64) (*client, bool) {
@@ -429,18 +660,49 @@ func (crn *clientReqNo) clientReq(ack *msgs.RequestAck) *clientRequest {
 	return clientReq
 }
 
-func (crn *clientReqNo) applyNewRequest(ack *msgs.RequestAck) {
+// applyNewRequest records that the request has been persisted locally,
+// returning false if it already had been.
+// sortedMyRequests returns the requests we have persisted, by digest.
+func (crn *clientReqNo) sortedMyRequests() []*clientRequest {
+	digests := make([]string, 0, len(crn.myRequests))
+	for digest := range crn.myRequests {
+		digests = append(digests, digest)
+	}
+	sort.Strings(digests)
+
+	result := make([]*clientRequest, len(digests))
+	for i, digest := range digests {
+		result[i] = crn.myRequests[digest]
+	}
+	return result
+}
+
+func (crn *clientReqNo) applyNewRequest(ack *msgs.RequestAck) bool {
 	_, ok := crn.myRequests[string(ack.Digest)]
 	if ok {
 		// We have already persisted this request, likely
 		// a race between a forward and a local proposal, do nothing
-		return
+		return false
 	}
 
 	clientReq := crn.clientReq(ack)
 	clientReq.stored = true
 
 	crn.myRequests[string(ack.Digest)] = clientReq
+
+	return true
+}
+
+// rejectRequest records that preprocessing the request failed locally,
+// returning false if the request was already persisted.
+func (crn *clientReqNo) rejectRequest(ack *msgs.RequestAck) bool {
+	if _, ok := crn.myRequests[string(ack.Digest)]; ok {
+		return false
+	}
+
+	crn.clientReq(ack).rejected = true
+
+	return true
 }
 
 func (crn *clientReqNo) generateAck() *msgs.Msg {
@@ -544,7 +806,7 @@ func (crn *clientReqNo) tick() *ActionList {
 	if len(crn.weakRequests) == 1 {
 		correctFetchTicks := uint(4)
 		for _, cr := range crn.weakRequests {
-			if cr.stored || cr.fetching {
+			if cr.stored || cr.fetching || cr.rejected {
 				break
 			}
 
@@ -563,7 +825,7 @@ func (crn *clientReqNo) tick() *ActionList {
 	// Third, for every correct request we have, if we have been trying to fetch it
 	// long enough, but received no response, let's try fetching it again.
 	for _, cr := range crn.weakRequests {
-		if !cr.fetching {
+		if !cr.fetching || cr.rejected {
 			continue
 		}
 
@@ -630,13 +892,16 @@ func (crn *clientReqNo) tick() *ActionList {
 }
 
 type clientRequest struct {
-	myConfig      *state.EventInitialParameters
-	ack           *msgs.RequestAck
-	agreements    map[nodeID]struct{}
-	stored        bool // set when the request is persisted locally
-	fetching      bool // set when we have sent a request for this request
-	ticksFetching uint // incremented by one each tick while fetching is true
-	ticksCorrect  uint // incremented by one each tick while not stored
+	myConfig           *state.EventInitialParameters
+	ack                *msgs.RequestAck
+	agreements         map[nodeID]struct{}
+	stored             bool // set when the request is persisted locally
+	rejected           bool // set when preprocessing the request failed locally
+	fetching           bool // set when we have sent a request for this request
+	ticksFetching      uint // incremented by one each tick while fetching is true
+	ticksCorrect       uint // incremented by one each tick while not stored
+	forwardedToLeader  bool // set when we have forwarded the request to its bucket leader
+	ticksLeaderMissing uint // incremented by one each tick while stored and not acked by the bucket leader
 }
 
 func (cr *clientRequest) fetch() *ActionList {
@@ -818,7 +1083,7 @@ func (c *client) ack(source nodeID, ack *msgs.RequestAck) (*ActionList, *clientR
 	if newlyCorrect {
 		crn.weakRequests[string(ack.Digest)] = cr
 
-		if !cr.stored {
+		if !cr.stored && !cr.rejected {
 			// If we already have the req stored, we know it's correct
 			actions.CorrectRequest(ack)
 		}
@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statemachine
+
+import (
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+
+	"github.com/pkg/errors"
+)
+
+// exportClientState returns the window state of the client, along with the
+// requests this node has persisted for it which have not yet committed, by
+// request number.
+func (ct *clientHashDisseminator) exportClientState(clientID uint64) (*state.ClientState, error) {
+	c, ok := ct.client(clientID)
+	if !ok {
+		return nil, errors.Errorf("unknown client_id=%d", clientID)
+	}
+
+	var requests []*msgs.RequestAck
+	for el := c.reqNoList.Front(); el != nil; el = el.Next() {
+		crn := el.Value.(*clientReqNo)
+		if crn.committed {
+			continue
+		}
+
+		digests := make([]string, 0, len(crn.myRequests))
+		for digest := range crn.myRequests {
+			if digest == "" {
+				// The null request is never persisted by the
+				// consumer, it is only ever generated locally.
+				continue
+			}
+			digests = append(digests, digest)
+		}
+		sort.Strings(digests)
+
+		for _, digest := range digests {
+			requests = append(requests, crn.myRequests[digest].ack)
+		}
+	}
+
+	return &state.ClientState{
+		Client:   proto.Clone(c.clientState).(*msgs.NetworkState_Client),
+		Requests: requests,
+	}, nil
+}
+
+// importClientState records the requests of an exported client state as
+// persisted by this node, and returns the actions to ack them.  The import
+// is refused, leaving this node unchanged, if the exported state claims
+// commits this node has not observed, as those may only be learned from the
+// network, or if it carries requests outside the client's window.
+func (ct *clientHashDisseminator) importClientState(clientState *state.ClientState) (*ActionList, error) {
+	if clientState.Client == nil {
+		return nil, errors.Errorf("client state has no client")
+	}
+
+	clientID := clientState.Client.Id
+	c, ok := ct.client(clientID)
+	if !ok {
+		return nil, errors.Errorf("unknown client_id=%d", clientID)
+	}
+
+	for reqNo := c.clientState.LowWatermark; reqNo <= c.highWatermark; reqNo++ {
+		if isCommitted(reqNo, clientState.Client) && !c.reqNo(reqNo).committed {
+			return nil, errors.Errorf("client_id=%d req_no=%d is committed in the imported state, but not locally", clientID, reqNo)
+		}
+	}
+
+	for _, ack := range clientState.Requests {
+		if ack.ClientId != clientID {
+			return nil, errors.Errorf("imported state for client_id=%d contains a request for client_id=%d", clientID, ack.ClientId)
+		}
+
+		if ack.ReqNo > c.highWatermark {
+			return nil, errors.Errorf("client_id=%d req_no=%d is above the high watermark %d", clientID, ack.ReqNo, c.highWatermark)
+		}
+	}
+
+	for _, ack := range clientState.Requests {
+		if !c.inWatermarks(ack.ReqNo) || c.reqNo(ack.ReqNo).committed {
+			// This request has since committed, there is nothing to do.
+			continue
+		}
+
+		c.reqNo(ack.ReqNo).applyNewRequest(ack)
+	}
+
+	return c.advanceAcks(), nil
+}
@@ -12,8 +12,38 @@ import (
 
 	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
 	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+
+	"github.com/pkg/errors"
 )
 
+// HashFunc computes the digest of data, the same way the consumer of Hash
+// actions does.
+type HashFunc func(data [][]byte) []byte
+
+// CommitIterator iterates over committed entries in sequence order.
+type CommitIterator struct {
+	entries []*msgs.QEntry
+}
+
+// NewCommitIterator returns an iterator over entries, which must be in
+// sequence order.
+func NewCommitIterator(entries []*msgs.QEntry) *CommitIterator {
+	return &CommitIterator{entries: entries}
+}
+
+// Next will return the next committed entry until the end is encountered.
+// Thereafter, it will return nil.
+func (ci *CommitIterator) Next() *msgs.QEntry {
+	if len(ci.entries) == 0 {
+		return nil
+	}
+
+	result := ci.entries[0]
+	ci.entries = ci.entries[1:]
+
+	return result
+}
+
 // commitState represents our state, as reflected within our log watermarks.
 // The mir network state only changes at checkpoint boundaries, and it
 // is not possible for two different sets of network configuration state
@@ -25,22 +55,34 @@ import (
 type commitState struct {
 	persisted         *persisted
 	committingClients map[uint64]*committingClient
+	hashFunc          HashFunc
+	myConfig          *state.EventInitialParameters
 	logger            logger.Logger
 
 	lowWatermark      uint64
 	lastAppliedCommit uint64
+	lastAckedCommit   uint64 // Highest SN such that the application acknowledged applying all commits up to it.
 	highestCommit     uint64 // Highest in order commit sequence number. All SNs up to highestCommit are committed.
+	reportedWatermark uint64 // lastAckedCommit as of the last PersistWatermark action.
+	barrierSeqNo      uint64 // Sequence of the last barrier delivered, see BarrierClients.
 	stopAtSeqNo       uint64
 	activeState       *msgs.NetworkState
 	lowerHalfCommits  []*msgs.QEntry
 	upperHalfCommits  []*msgs.QEntry
 	checkpointPending bool
 	transferring      bool
+	transferSeqNo     uint64 // The sequence being transferred to, while transferring.
+	reconfigSeqNo     uint64 // The checkpoint whose network config differs from the active one, or zero.
+
+	// Sequences delivered ahead of a gap, which drain must not deliver again.
+	deliveredPastGaps map[uint64]struct{}
 }
 
-func newCommitState(persisted *persisted, logger logger.Logger) *commitState {
+func newCommitState(persisted *persisted, hashFunc HashFunc, myConfig *state.EventInitialParameters, logger logger.Logger) *commitState {
 	cs := &commitState{
 		persisted: persisted,
+		hashFunc:  hashFunc,
+		myConfig:  myConfig,
 		logger:    logger,
 	}
 
@@ -60,12 +102,16 @@ func (cs *commitState) reinitialize() *ActionList {
 		},
 	})
 
+	cs.reconfigSeqNo = 0
 	if secondToLastCEntry == nil || len(secondToLastCEntry.NetworkState.PendingReconfigurations) == 0 {
 		cs.activeState = lastCEntry.NetworkState
 		cs.lowWatermark = lastCEntry.SeqNo
 	} else {
 		cs.activeState = secondToLastCEntry.NetworkState
 		cs.lowWatermark = secondToLastCEntry.SeqNo
+		if configChanged(cs.activeState, lastCEntry.NetworkState) {
+			cs.reconfigSeqNo = lastCEntry.SeqNo
+		}
 	}
 
 	actions := &ActionList{}
@@ -79,10 +125,14 @@ func (cs *commitState) reinitialize() *ActionList {
 	}
 
 	cs.lastAppliedCommit = lastCEntry.SeqNo
+	cs.lastAckedCommit = lastCEntry.SeqNo
 	cs.highestCommit = lastCEntry.SeqNo
+	cs.reportedWatermark = lastCEntry.SeqNo
+	cs.barrierSeqNo = 0
 
 	cs.lowerHalfCommits = make([]*msgs.QEntry, ci)
 	cs.upperHalfCommits = make([]*msgs.QEntry, ci)
+	cs.deliveredPastGaps = map[uint64]struct{}{}
 
 	cs.committingClients = map[uint64]*committingClient{}
 	for _, clientState := range lastCEntry.NetworkState.Clients {
@@ -99,13 +149,22 @@ func (cs *commitState) reinitialize() *ActionList {
 
 	// We crashed during a state transfer
 	cs.transferring = true
+	cs.transferSeqNo = lastTEntry.SeqNo
 	return actions.StateTransfer(lastTEntry.SeqNo, lastTEntry.Value)
 }
 
+// configChanged returns whether the network config of next differs from that
+// of active, as the network config need not change for reconfigurations which
+// only add or remove clients.
+func configChanged(active, next *msgs.NetworkState) bool {
+	return !bytes.Equal(ConfigDigest(active.Config), ConfigDigest(next.Config))
+}
+
 func (cs *commitState) transferTo(seqNo uint64, value []byte) *ActionList {
 	cs.logger.Log(logger.LevelDebug, "initiating state transfer", "target_seq_no", seqNo, "target_value", value)
 	assertEqual(cs.transferring, false, "multiple state transfers are not supported concurrently")
 	cs.transferring = true
+	cs.transferSeqNo = seqNo
 	return cs.persisted.addTEntry(&msgs.TEntry{
 		SeqNo: seqNo,
 		Value: value,
@@ -124,10 +183,19 @@ func (cs *commitState) applyCheckpointResult(epochConfig *msgs.EpochConfig, resu
 		panic("dev sanity test -- this panic is helpful for dev, but needs to be removed as we could get stale checkpoint results")
 	}
 
-	if len(result.NetworkState.PendingReconfigurations) == 0 {
-		cs.stopAtSeqNo = result.SeqNo + 2*ci
-	} else {
+	switch {
+	case len(cs.activeState.PendingReconfigurations) > 0:
+		// This checkpoint applies the pending reconfigurations, the new
+		// network state takes effect once the checkpoint is stable and
+		// the state machine is reinitialized.
+		cs.logger.Log(logger.LevelDebug, "checkpoint result applies reconfigurations, not extending stop", "stop_at_seq_no", cs.stopAtSeqNo)
+		if configChanged(cs.activeState, result.NetworkState) {
+			cs.reconfigSeqNo = result.SeqNo
+		}
+	case len(result.NetworkState.PendingReconfigurations) > 0:
 		cs.logger.Log(logger.LevelDebug, "checkpoint result has pending reconfigurations, not extending stop", "stop_at_seq_no", cs.stopAtSeqNo)
+	default:
+		cs.stopAtSeqNo = result.SeqNo + 2*ci
 	}
 
 	cs.activeState = result.NetworkState
@@ -141,7 +209,8 @@ func (cs *commitState) applyCheckpointResult(epochConfig *msgs.EpochConfig, resu
 		CheckpointValue: result.Value,
 		NetworkState:    result.NetworkState,
 	}).Send(
-		cs.activeState.Config.Nodes,
+		// Learners catch up from the checkpoints of the network.
+		append(append([]uint64{}, cs.activeState.Config.Nodes...), cs.activeState.Config.Learners...),
 		&msgs.Msg{
 			Type: &msgs.Msg_Checkpoint{
 				Checkpoint: &msgs.Checkpoint{
@@ -186,6 +255,99 @@ func (cs *commitState) commit(qEntry *msgs.QEntry) {
 	}
 }
 
+// committedSince returns an iterator over the committed entries from seqNo
+// through the highest contiguous commit.  Only the entries above the low
+// watermark are retained, earlier entries must be obtained via state transfer.
+func (cs *commitState) committedSince(seqNo uint64) (*CommitIterator, error) {
+	if cs.transferring {
+		return nil, errors.Errorf("cannot iterate commits from seq_no=%d while state transfer is in progress", seqNo)
+	}
+
+	if seqNo <= cs.lowWatermark {
+		return nil, errors.Errorf("seq_no=%d is not above the low watermark %d and is no longer retained, catch up via state transfer", seqNo, cs.lowWatermark)
+	}
+
+	ci := uint64(cs.activeState.Config.CheckpointInterval)
+	var entries []*msgs.QEntry
+	for i := seqNo; i <= cs.highestCommit; i++ {
+		var commits []*msgs.QEntry
+		if i-cs.lowWatermark > ci {
+			commits = cs.upperHalfCommits
+		} else {
+			commits = cs.lowerHalfCommits
+		}
+
+		commit := commits[int((i-(cs.lowWatermark+1))%ci)]
+		if commit == nil {
+			// After reinitialization, the commits below the last checkpoint are not retained.
+			return nil, errors.Errorf("seq_no=%d is no longer retained, catch up via state transfer", i)
+		}
+		entries = append(entries, commit)
+	}
+
+	return NewCommitIterator(entries), nil
+}
+
+// applyCommitsApplied processes an acknowledgement from the application that all commits
+// in the inclusive range [from, to] have been applied.  Ranges which do not extend the
+// contiguous prefix of acknowledged commits are ignored.
+func (cs *commitState) applyCommitsApplied(from, to uint64) {
+	// The acknowledgements come from the application, so a faulty one must
+	// not bring down the state machine.
+	if from > to {
+		cs.logger.Log(logger.LevelWarn, "ignoring inverted commits applied acknowledgement", "from", from, "to", to)
+		return
+	}
+
+	if to > cs.lastAppliedCommit {
+		cs.logger.Log(logger.LevelWarn, "ignoring acknowledgement of commits which were never delivered", "from", from, "to", to, "last_applied_commit", cs.lastAppliedCommit)
+		return
+	}
+
+	if from > cs.lastAckedCommit+1 {
+		cs.logger.Log(logger.LevelWarn, "ignoring non-contiguous commits applied acknowledgement", "from", from, "to", to, "last_acked_commit", cs.lastAckedCommit)
+		return
+	}
+
+	if to <= cs.lastAckedCommit {
+		return
+	}
+
+	cs.lastAckedCommit = to
+}
+
+// persistWatermark returns a PersistWatermark action if the application has
+// acknowledged applying further commits since the last one was returned.
+// The watermark reported does not pass the checkpoint which follows the low
+// watermark, as, should the node restart, the application would otherwise
+// be asked for that checkpoint having already applied later commits.
+func (cs *commitState) persistWatermark() *ActionList {
+	watermark := cs.lastAckedCommit
+	if limit := cs.lowWatermark + uint64(cs.activeState.Config.CheckpointInterval); watermark > limit {
+		watermark = limit
+	}
+
+	if watermark <= cs.reportedWatermark {
+		return &ActionList{}
+	}
+
+	cs.reportedWatermark = watermark
+	return (&ActionList{}).PersistWatermark(watermark)
+}
+
+// appliedCommitWatermark returns the AppliedCommitWatermark through which
+// commits are not delivered again, or zero if it passes the checkpoint which
+// follows the low watermark.  The application would then be asked for that
+// checkpoint with later commits applied, so the watermark is not trusted.
+func (cs *commitState) appliedCommitWatermark() uint64 {
+	watermark := cs.myConfig.AppliedCommitWatermark
+	if watermark > cs.lowWatermark+uint64(cs.activeState.Config.CheckpointInterval) {
+		return 0
+	}
+
+	return watermark
+}
+
 func nextNetworkConfig(startingState *msgs.NetworkState, committingClients map[uint64]*committingClient) (*msgs.NetworkState_Config, []*msgs.NetworkState_Client) {
 	nextConfig := startingState.Config
 
@@ -241,9 +403,28 @@ func (cs *commitState) drain() *ActionList {
 
 		}
 
+		if cs.beyondUnappliedLimit(cs.lastAppliedCommit + 1) {
+			// The application is behind, hold the remaining commits
+			// back until it acknowledges applying some.
+			break
+		}
+
+		if cs.lastAckedCommit < cs.barrierSeqNo {
+			// A barrier was delivered, hold the remaining commits back until
+			// the application acknowledges applying it and all before it.
+			break
+		}
+
 		nextCommit := cs.lastAppliedCommit + 1
 		upper := nextCommit-cs.lowWatermark > ci
 		offset := int((nextCommit - (cs.lowWatermark + 1)) % ci)
+
+		if cs.myConfig.CoalesceCommits && offset == 0 && (upper || !cs.lowerHalfCommitted()) {
+			// The commits of an interval are only delivered once all of
+			// them have, and the checkpoint before completed, so that
+			// they are emitted along with the checkpoint request.
+			break
+		}
 		var commits []*msgs.QEntry
 		if upper {
 			commits = cs.upperHalfCommits
@@ -257,7 +438,34 @@ func (cs *commitState) drain() *ActionList {
 
 		assertEqual(commit.SeqNo, nextCommit, "attempted out of order commit")
 
-		actions.Commit(commit)
+		if cs.myConfig.VerifyCommitDigests {
+			if err := cs.verifyDigest(commit); err != nil {
+				cs.logger.Log(logger.LevelError, "refusing to deliver commit, pausing commit delivery", "seq_no", commit.SeqNo, "err", err)
+				break
+			}
+		}
+
+		configChanges := configChanges(commit, cs.activeState.Config)
+		if _, ok := cs.deliveredPastGaps[nextCommit]; ok {
+			delete(cs.deliveredPastGaps, nextCommit)
+		} else if nextCommit <= cs.appliedCommitWatermark() {
+			// The application applied this commit before the node restarted.
+			cs.lastAckedCommit = nextCommit
+		} else {
+			actions.Commit(commit, configChanges)
+		}
+
+		if containsCheckpointRequest(commit, cs.activeState.Config) {
+			if nextCommit > cs.appliedCommitWatermark() {
+				cs.logger.Log(logger.LevelInfo, "coordinated checkpoint requested", "seq_no", nextCommit)
+				actions.CoordinatedCheckpoint(nextCommit)
+			}
+			cs.barrierSeqNo = nextCommit
+		}
+
+		if containsBarrier(commit, cs.activeState.Config) {
+			cs.barrierSeqNo = nextCommit
+		}
 
 		for _, req := range commit.Requests {
 			cs.committingClients[req.ClientId].markCommitted(commit.SeqNo, req.ReqNo)
@@ -269,6 +477,98 @@ func (cs *commitState) drain() *ActionList {
 	return actions
 }
 
+// lowerHalfCommitted returns whether every sequence of the checkpoint
+// interval above the low watermark has committed.
+func (cs *commitState) lowerHalfCommitted() bool {
+	for _, commit := range cs.lowerHalfCommits {
+		if commit == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// beyondUnappliedLimit returns whether delivering the commit at seqNo would
+// exceed MaxUnappliedCommits.  Every commit up to seqNo is delivered before
+// the application acknowledges seqNo, including those of the gaps a commit is
+// delivered past, so all of them count, whether or not delivered yet.
+func (cs *commitState) beyondUnappliedLimit(seqNo uint64) bool {
+	limit := uint64(cs.myConfig.MaxUnappliedCommits)
+	return limit != 0 && seqNo-cs.lastAckedCommit > limit
+}
+
+// deliverPastGaps returns a Commit action for a batch which committed while
+// the sequences in gaps below it have not, if the node is configured to
+// deliver commits past gaps, and not to coalesce them.  Like drain, it
+// delivers nothing past the pending checkpoint, while a barrier is
+// unacknowledged, or beyond MaxUnappliedCommits.  The batch is later skipped
+// by drain.
+func (cs *commitState) deliverPastGaps(commit *msgs.QEntry, gaps []uint64) *ActionList {
+	if !cs.myConfig.DeliverCommitsPastGaps || cs.myConfig.CoalesceCommits {
+		return &ActionList{}
+	}
+
+	if len(cs.activeState.Config.BarrierClients) > 0 || len(cs.activeState.Config.CheckpointClients) > 0 {
+		// A barrier, or a checkpoint request, may lie in the gap.
+		return &ActionList{}
+	}
+
+	if commit.SeqNo <= cs.lastAppliedCommit || commit.SeqNo <= cs.appliedCommitWatermark() {
+		return &ActionList{}
+	}
+
+	if _, ok := cs.deliveredPastGaps[commit.SeqNo]; ok {
+		return &ActionList{}
+	}
+
+	ci := uint64(cs.activeState.Config.CheckpointInterval)
+	if cs.checkpointPending || commit.SeqNo > cs.lowWatermark+ci {
+		// Commits past the pending checkpoint wait for its result, as in drain.
+		return &ActionList{}
+	}
+
+	if cs.lastAckedCommit < cs.barrierSeqNo {
+		return &ActionList{}
+	}
+
+	if cs.beyondUnappliedLimit(commit.SeqNo) {
+		return &ActionList{}
+	}
+
+	if cs.myConfig.VerifyCommitDigests {
+		if err := cs.verifyDigest(commit); err != nil {
+			// Left for drain to refuse once the gap fills.
+			return &ActionList{}
+		}
+	}
+
+	cs.deliveredPastGaps[commit.SeqNo] = struct{}{}
+
+	return (&ActionList{}).Commit(commit, configChanges(commit, cs.activeState.Config), gaps...)
+}
+
+// verifyDigest recomputes the digest of the committed batch from its request
+// acks and checks it against the digest the batch committed with.
+func (cs *commitState) verifyDigest(commit *msgs.QEntry) error {
+	if len(commit.Requests) == 0 {
+		if len(commit.Digest) != 0 {
+			return errors.Errorf("empty batch committed with digest %x", commit.Digest)
+		}
+		return nil
+	}
+
+	if cs.hashFunc == nil {
+		return errors.Errorf("no hash function to verify commit digests with")
+	}
+
+	digest := cs.hashFunc(batchHashData(commit.Requests))
+	if !bytes.Equal(digest, commit.Digest) {
+		return errors.Errorf("batch committed with digest %x, but its requests hash to %x", commit.Digest, digest)
+	}
+
+	return nil
+}
+
 type committingClient struct {
 	lastState                    *msgs.NetworkState_Client
 	committedSinceLastCheckpoint []*uint64
@@ -0,0 +1,297 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statemachine
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/mirbft/pkg/logger"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+)
+
+var _ = Describe("commitState", func() {
+	var (
+		cs *commitState
+	)
+
+	BeforeEach(func() {
+		cs = &commitState{
+			logger:            logger.ConsoleErrorLogger,
+			lowWatermark:      20,
+			lastAppliedCommit: 120,
+			lastAckedCommit:   20,
+		}
+	})
+
+	Describe("applyCommitsApplied", func() {
+		It("acknowledges a whole range of commits at once", func() {
+			cs.applyCommitsApplied(21, 120)
+			Expect(cs.lastAckedCommit).To(Equal(uint64(120)))
+		})
+
+		It("accepts ranges overlapping already acknowledged commits", func() {
+			cs.applyCommitsApplied(21, 70)
+			Expect(cs.lastAckedCommit).To(Equal(uint64(70)))
+			cs.applyCommitsApplied(50, 100)
+			Expect(cs.lastAckedCommit).To(Equal(uint64(100)))
+			cs.applyCommitsApplied(30, 40)
+			Expect(cs.lastAckedCommit).To(Equal(uint64(100)))
+		})
+
+		It("ignores non-contiguous ranges", func() {
+			cs.applyCommitsApplied(50, 100)
+			Expect(cs.lastAckedCommit).To(Equal(uint64(20)))
+		})
+
+		It("ignores acknowledgements of commits which were never issued", func() {
+			cs.applyCommitsApplied(21, 121)
+			Expect(cs.lastAckedCommit).To(Equal(uint64(20)))
+		})
+
+		It("ignores inverted ranges", func() {
+			cs.applyCommitsApplied(30, 21)
+			Expect(cs.lastAckedCommit).To(Equal(uint64(20)))
+		})
+	})
+
+	Describe("committedSince", func() {
+		BeforeEach(func() {
+			cs.activeState = &msgs.NetworkState{
+				Config: &msgs.NetworkState_Config{
+					CheckpointInterval: 5,
+				},
+			}
+			cs.highestCommit = 20
+			cs.stopAtSeqNo = 30
+			cs.lowerHalfCommits = make([]*msgs.QEntry, 5)
+			cs.upperHalfCommits = make([]*msgs.QEntry, 5)
+
+			for seqNo := uint64(21); seqNo <= 28; seqNo++ {
+				cs.commit(&msgs.QEntry{
+					SeqNo:  seqNo,
+					Digest: []byte{byte(seqNo)},
+				})
+			}
+		})
+
+		It("yields the committed entries in order from the requested sequence", func() {
+			iter, err := cs.committedSince(24)
+			Expect(err).NotTo(HaveOccurred())
+
+			var seqNos []uint64
+			for qEntry := iter.Next(); qEntry != nil; qEntry = iter.Next() {
+				Expect(qEntry.Digest).To(Equal([]byte{byte(qEntry.SeqNo)}))
+				seqNos = append(seqNos, qEntry.SeqNo)
+			}
+			Expect(seqNos).To(Equal([]uint64{24, 25, 26, 27, 28}))
+		})
+
+		It("yields nothing beyond the highest commit", func() {
+			iter, err := cs.committedSince(29)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(iter.Next()).To(BeNil())
+		})
+
+		It("refers to state transfer for sequences at or below the low watermark", func() {
+			_, err := cs.committedSince(20)
+			Expect(err).To(MatchError(ContainSubstring("catch up via state transfer")))
+		})
+	})
+
+	// commits returns the sequences of the Commit actions in actions.
+	commits := func(actions *ActionList) []uint64 {
+		result := []uint64{}
+		iter := actions.Iterator()
+		for action := iter.Next(); action != nil; action = iter.Next() {
+			if commit, ok := action.Type.(*state.Action_Commit); ok {
+				result = append(result, commit.Commit.Batch.SeqNo)
+			}
+		}
+		return result
+	}
+
+	Describe("the applied commit watermark", func() {
+		BeforeEach(func() {
+			cs.myConfig = &state.EventInitialParameters{}
+			cs.activeState = &msgs.NetworkState{
+				Config: &msgs.NetworkState_Config{
+					CheckpointInterval: 5,
+				},
+			}
+			cs.lastAppliedCommit = 20
+			cs.reportedWatermark = 20
+			cs.highestCommit = 20
+			cs.stopAtSeqNo = 30
+			cs.lowerHalfCommits = make([]*msgs.QEntry, 5)
+			cs.upperHalfCommits = make([]*msgs.QEntry, 5)
+
+			for seqNo := uint64(21); seqNo <= 24; seqNo++ {
+				cs.commit(&msgs.QEntry{
+					SeqNo:  seqNo,
+					Digest: []byte{byte(seqNo)},
+				})
+			}
+		})
+
+		It("delivers every commit when unset", func() {
+			Expect(commits(cs.drain())).To(Equal([]uint64{21, 22, 23, 24}))
+			Expect(cs.persistWatermark().isEmpty()).To(BeTrue())
+		})
+
+		It("skips delivering the commits through it on startup", func() {
+			cs.myConfig.AppliedCommitWatermark = 22
+			Expect(commits(cs.drain())).To(Equal([]uint64{23, 24}))
+			Expect(cs.lastAppliedCommit).To(Equal(uint64(24)))
+			Expect(cs.lastAckedCommit).To(Equal(uint64(22)))
+		})
+
+		It("is ignored if it passes the checkpoint following the low watermark", func() {
+			cs.myConfig.AppliedCommitWatermark = 27
+			Expect(commits(cs.drain())).To(Equal([]uint64{21, 22, 23, 24}))
+			Expect(cs.lastAckedCommit).To(Equal(uint64(20)))
+		})
+
+		It("is reported no further than the checkpoint following the low watermark", func() {
+			cs.lastAckedCommit = 27
+			Expect(cs.persistWatermark()).To(Equal((&ActionList{}).PersistWatermark(25)))
+			Expect(cs.persistWatermark().isEmpty()).To(BeTrue())
+		})
+
+		It("is reported once, as the application acknowledges further commits", func() {
+			cs.drain()
+			cs.applyCommitsApplied(21, 23)
+			Expect(cs.persistWatermark()).To(Equal((&ActionList{}).PersistWatermark(23)))
+			Expect(cs.persistWatermark().isEmpty()).To(BeTrue())
+		})
+	})
+
+	Describe("the unapplied commit limit", func() {
+		commit := func(from, to uint64) {
+			for seqNo := from; seqNo <= to; seqNo++ {
+				cs.commit(&msgs.QEntry{
+					SeqNo:  seqNo,
+					Digest: []byte{byte(seqNo)},
+				})
+			}
+		}
+
+		BeforeEach(func() {
+			cs.myConfig = &state.EventInitialParameters{
+				MaxUnappliedCommits:    3,
+				DeliverCommitsPastGaps: true,
+			}
+			cs.activeState = &msgs.NetworkState{
+				Config: &msgs.NetworkState_Config{
+					CheckpointInterval: 5,
+				},
+			}
+			cs.lastAppliedCommit = 20
+			cs.highestCommit = 20
+			cs.stopAtSeqNo = 30
+			cs.lowerHalfCommits = make([]*msgs.QEntry, 5)
+			cs.upperHalfCommits = make([]*msgs.QEntry, 5)
+			cs.deliveredPastGaps = map[uint64]struct{}{}
+		})
+
+		It("counts the gaps of commits delivered past them", func() {
+			commit(21, 21)
+			Expect(commits(cs.drain())).To(Equal([]uint64{21}))
+
+			// Once the gap at 22 is delivered, 21 through 23 are unapplied.
+			Expect(commits(cs.deliverPastGaps(&msgs.QEntry{SeqNo: 23}, []uint64{22}))).To(Equal([]uint64{23}))
+			Expect(cs.deliverPastGaps(&msgs.QEntry{SeqNo: 24}, []uint64{22}).isEmpty()).To(BeTrue())
+
+			commit(22, 24)
+			Expect(commits(cs.drain())).To(Equal([]uint64{22}))
+
+			cs.applyCommitsApplied(21, 23)
+			Expect(commits(cs.drain())).To(Equal([]uint64{24}))
+		})
+	})
+
+	Describe("checkpoint requests", func() {
+		// checkpoints returns the sequences of the Checkpoint actions in actions.
+		checkpoints := func(actions *ActionList) []uint64 {
+			result := []uint64{}
+			iter := actions.Iterator()
+			for action := iter.Next(); action != nil; action = iter.Next() {
+				if checkpoint, ok := action.Type.(*state.Action_Checkpoint); ok {
+					result = append(result, checkpoint.Checkpoint.SeqNo)
+				}
+			}
+			return result
+		}
+
+		commit := func(from, to uint64) {
+			for seqNo := from; seqNo <= to; seqNo++ {
+				cs.commit(&msgs.QEntry{
+					SeqNo:  seqNo,
+					Digest: []byte{byte(seqNo)},
+				})
+			}
+		}
+
+		BeforeEach(func() {
+			cs.myConfig = &state.EventInitialParameters{}
+			cs.activeState = &msgs.NetworkState{
+				Config: &msgs.NetworkState_Config{
+					CheckpointInterval: 5,
+				},
+			}
+			cs.lastAppliedCommit = 20
+			cs.highestCommit = 20
+			cs.stopAtSeqNo = 30
+			cs.lowerHalfCommits = make([]*msgs.QEntry, 5)
+			cs.upperHalfCommits = make([]*msgs.QEntry, 5)
+		})
+
+		It("requests a single checkpoint per interval, however often it is triggered", func() {
+			commit(21, 25)
+			Expect(checkpoints(cs.drain())).To(Equal([]uint64{25}))
+			Expect(checkpoints(cs.drain())).To(BeEmpty())
+
+			// Until the pending checkpoint completes, and the watermarks
+			// move, reaching the next interval requests no checkpoint.
+			commit(26, 30)
+			Expect(checkpoints(cs.drain())).To(BeEmpty())
+			Expect(cs.lastAppliedCommit).To(Equal(uint64(30)))
+		})
+
+		It("emits the commits of an interval along with its checkpoint request when coalescing", func() {
+			cs.myConfig.CoalesceCommits = true
+
+			commit(21, 24)
+			Expect(cs.drain().isEmpty()).To(BeTrue())
+
+			commit(25, 27)
+			actions := cs.drain()
+			Expect(commits(actions)).To(Equal([]uint64{21, 22, 23, 24, 25}))
+			Expect(checkpoints(actions)).To(Equal([]uint64{25}))
+
+			// The next interval waits for the pending checkpoint as well.
+			commit(28, 30)
+			Expect(cs.drain().isEmpty()).To(BeTrue())
+			Expect(cs.lastAppliedCommit).To(Equal(uint64(25)))
+		})
+
+		It("delivers no commit past a gap when coalescing", func() {
+			cs.myConfig.CoalesceCommits = true
+			cs.myConfig.DeliverCommitsPastGaps = true
+			cs.deliveredPastGaps = map[uint64]struct{}{}
+
+			commit(21, 22)
+			Expect(cs.deliverPastGaps(&msgs.QEntry{SeqNo: 24}, []uint64{23}).isEmpty()).To(BeTrue())
+
+			commit(23, 25)
+			actions := cs.drain()
+			Expect(commits(actions)).To(Equal([]uint64{21, 22, 23, 24, 25}))
+			Expect(checkpoints(actions)).To(Equal([]uint64{25}))
+		})
+	})
+})
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package statemachine
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/hyperledger-labs/mirbft/pkg/logger"
 
@@ -31,6 +32,8 @@ type activeEpoch struct {
 	persisted       *persisted
 	commitState     *commitState
 
+	onSequenceAllocated SequenceAllocatedFunc
+
 	buckets   map[bucketID]nodeID
 	sequences [][]*sequence
 
@@ -41,9 +44,28 @@ type activeEpoch struct {
 
 	lastCommittedAtTick uint64
 	ticksSinceProgress  uint32
+
+	// committed is set once a sequence commits in this epoch.
+	committed bool
+
+	// steppingDown is set once this node stops proposing in its buckets,
+	// see stepDown.  steppedDown is set once it has suspected the epoch.
+	steppingDown bool
+	steppedDown  bool
+
+	// leading is set once this node allocates a sequence in a bucket it
+	// leads, and heard once a peer prepares or commits one.  Until then,
+	// peerQuorums counts the commit quorums the peers in quorumPeers formed
+	// among themselves, see checkAsymmetry.  asymmetric is set once network
+	// asymmetry has been reported for this epoch.
+	leading     bool
+	heard       bool
+	peerQuorums int
+	quorumPeers map[nodeID]struct{}
+	asymmetric  bool
 }
 
-func newActiveEpoch(epochConfig *msgs.EpochConfig, persisted *persisted, nodeBuffers *nodeBuffers, commitState *commitState, clientTracker *clientTracker, myConfig *state.EventInitialParameters, l logger.Logger) *activeEpoch {
+func newActiveEpoch(epochConfig *msgs.EpochConfig, persisted *persisted, nodeBuffers *nodeBuffers, commitState *commitState, clientTracker *clientTracker, myConfig *state.EventInitialParameters, batchOrderer BatchOrderer, batchValidator BatchValidator, onSequenceAllocated SequenceAllocatedFunc, l logger.Logger) *activeEpoch {
 	networkConfig := commitState.activeState.Config
 	startingSeqNo := commitState.highestCommit
 
@@ -51,24 +73,7 @@ func newActiveEpoch(epochConfig *msgs.EpochConfig, persisted *persisted, nodeBuf
 
 	outstandingReqs := newOutstandingReqs(clientTracker, commitState.activeState, l)
 
-	buckets := map[bucketID]nodeID{}
-
-	leaders := map[uint64]struct{}{}
-	for _, leader := range epochConfig.Leaders {
-		leaders[leader] = struct{}{}
-	}
-
-	overflowIndex := 0 // TODO, this should probably start after the last assigned node
-	for i := 0; i < int(networkConfig.NumberOfBuckets); i++ {
-		bucketID := bucketID(i)
-		leader := networkConfig.Nodes[(uint64(i)+epochConfig.Number)%uint64(len(networkConfig.Nodes))]
-		if _, ok := leaders[leader]; !ok {
-			buckets[bucketID] = nodeID(epochConfig.Leaders[overflowIndex%len(epochConfig.Leaders)])
-			overflowIndex++
-		} else {
-			buckets[bucketID] = nodeID(leader)
-		}
-	}
+	buckets := assignBuckets(epochConfig, networkConfig)
 
 	lowestUnallocated := make([]uint64, len(buckets))
 	for i := range lowestUnallocated {
@@ -84,6 +89,9 @@ func newActiveEpoch(epochConfig *msgs.EpochConfig, persisted *persisted, nodeBuf
 		myConfig,
 		clientTracker,
 		buckets,
+		batchOrderer,
+		batchValidator,
+		l,
 	)
 
 	preprepareBuffers := make([]*preprepareBuffer, len(lowestUnallocated))
@@ -106,19 +114,21 @@ func newActiveEpoch(epochConfig *msgs.EpochConfig, persisted *persisted, nodeBuf
 	}
 
 	return &activeEpoch{
-		buckets:           buckets,
-		myConfig:          myConfig,
-		epochConfig:       epochConfig,
-		networkConfig:     networkConfig,
-		persisted:         persisted,
-		commitState:       commitState,
-		proposer:          proposer,
-		preprepareBuffers: preprepareBuffers,
-		otherBuffers:      otherBuffers,
-		lowestUnallocated: lowestUnallocated,
-		lowestUncommitted: lowestUncommitted,
-		outstandingReqs:   outstandingReqs,
-		logger:            l,
+		buckets:             buckets,
+		myConfig:            myConfig,
+		epochConfig:         epochConfig,
+		networkConfig:       networkConfig,
+		persisted:           persisted,
+		commitState:         commitState,
+		proposer:            proposer,
+		preprepareBuffers:   preprepareBuffers,
+		otherBuffers:        otherBuffers,
+		lowestUnallocated:   lowestUnallocated,
+		lowestUncommitted:   lowestUncommitted,
+		outstandingReqs:     outstandingReqs,
+		onSequenceAllocated: onSequenceAllocated,
+		logger:              l,
+		quorumPeers:         map[nodeID]struct{}{},
 	}
 }
 
@@ -163,6 +173,14 @@ func (ae *activeEpoch) filter(source nodeID, msg *msgs.Msg) applyable {
 			return past
 		}
 
+		if owner == nodeID(ae.myConfig.Id) && ae.sequence(seqNo).state < sequencePreprepared {
+			// This is an echo of a preprepare we are yet to produce, for instance
+			// one sent before a restart or one received before we installed
+			// this epoch.  It is not misbehavior, so hold it in the bounded
+			// preprepare buffer and reconcile it once our own batch is hashed.
+			return future
+		}
+
 		nextPreprepare := ae.preprepareBuffers[int(bucketID)].nextSeqNo
 		switch {
 		case seqNo < nextPreprepare:
@@ -243,11 +261,29 @@ func (ae *activeEpoch) apply(source nodeID, msg *msgs.Msg) *ActionList {
 func (ae *activeEpoch) step(source nodeID, msg *msgs.Msg) *ActionList {
 	switch ae.filter(source, msg) {
 	case past:
+		if innerMsg, ok := msg.Type.(*msgs.Msg_Preprepare); ok {
+			return ae.checkRetransmittedPreprepare(source, innerMsg.Preprepare)
+		}
 	case future:
 		switch innerMsg := msg.Type.(type) {
 		case *msgs.Msg_Preprepare:
+			if ae.myConfig.StrictHighWatermark && innerMsg.Preprepare.SeqNo > ae.highWatermark() {
+				ae.logger.Log(logger.LevelWarn, "protocol violation, received preprepare beyond the high watermark, suspecting epoch", "source", source, "seq_no", innerMsg.Preprepare.SeqNo, "high_watermark", ae.highWatermark())
+				return ae.suspect(false)
+			}
+
 			bucket := ae.seqToBucket(innerMsg.Preprepare.SeqNo)
-			ae.preprepareBuffers[int(bucket)].buffer.store(msg)
+			preprepareBuffer := ae.preprepareBuffers[int(bucket)]
+			preprepareBuffer.buffer.store(msg)
+
+			if ae.myConfig.StrictOrdering && source != nodeID(ae.myConfig.Id) && innerMsg.Preprepare.SeqNo <= ae.highWatermark() {
+				// The leader proposes the sequences of its bucket in order, so
+				// over an ordered transport, a preprepare within the watermarks
+				// other than the next expected one means the leader skipped a
+				// sequence.  It is still buffered, in case the gap is filled.
+				ae.logger.Log(logger.LevelWarn, "transport violation, leader skipped a sequence of its bucket", "source", source, "seq_no", innerMsg.Preprepare.SeqNo, "expected_seq_no", preprepareBuffer.nextSeqNo)
+				return (&ActionList{}).Misbehavior(uint64(source), fmt.Sprintf("skipped seq_no=%d of bucket %d, proposing seq_no=%d", preprepareBuffer.nextSeqNo, bucket, innerMsg.Preprepare.SeqNo))
+			}
 		default:
 			ae.otherBuffers[source].store(msg)
 		}
@@ -259,6 +295,48 @@ func (ae *activeEpoch) step(source nodeID, msg *msgs.Msg) *ActionList {
 	return &ActionList{}
 }
 
+// checkRetransmittedPreprepare handles a preprepare for a sequence whose
+// preprepare was already applied, as a leader resends its preprepares after
+// restarting, reconstructing them from its log.  A resent preprepare
+// carrying the batch already accepted is ignored, while one carrying a
+// different batch proves the leader equivocated, and the epoch is suspected.
+func (e *activeEpoch) checkRetransmittedPreprepare(source nodeID, preprepare *msgs.Preprepare) *ActionList {
+	if !e.inWatermarks(preprepare.SeqNo) {
+		return &ActionList{}
+	}
+
+	seq := e.sequence(preprepare.SeqNo)
+	if seq.state < sequenceAllocated {
+		return &ActionList{}
+	}
+
+	if len(seq.batch) == len(preprepare.Batch) {
+		identical := true
+		for i, ack := range seq.batch {
+			if !sameRequest(ack, preprepare.Batch[i]) {
+				identical = false
+				break
+			}
+		}
+
+		if identical {
+			e.logger.Log(logger.LevelDebug, "ignoring retransmitted preprepare identical to the one accepted", "source", source, "seq_no", preprepare.SeqNo)
+			return &ActionList{}
+		}
+	}
+
+	e.logger.Log(logger.LevelWarn, "leader equivocated, preprepare differs from the one accepted, suspecting epoch", "source", source, "seq_no", preprepare.SeqNo)
+	return e.suspect(false)
+}
+
+// sameRequest returns whether two acks identify the same request by the same
+// digest.  Acks are compared by these fields alone, rather than as messages,
+// so that a leader resending its batch from another version of the software,
+// which may encode fields this node does not know, is not taken to equivocate.
+func sameRequest(a, b *msgs.RequestAck) bool {
+	return a.ClientId == b.ClientId && a.ReqNo == b.ReqNo && bytes.Equal(a.Digest, b.Digest)
+}
+
 func (e *activeEpoch) inWatermarks(seqNo uint64) bool {
 	return seqNo >= e.lowWatermark() && seqNo <= e.highWatermark()
 }
@@ -281,42 +359,146 @@ func (e *activeEpoch) applyPreprepareMsg(source nodeID, seqNo uint64, batch []*m
 	// outstanding requests before transitioning the sequence to preprepared
 	actions, err := e.outstandingReqs.applyAcks(bucketID, seq, batch)
 	if err != nil {
-		// TODO implement suspect on bad batch
-		panic(fmt.Sprintf("handle me, seq_no=%d we need to stop the bucket and suspect: %s", seqNo, err))
+		// The sequence is left unallocated, stalling the bucket until the
+		// epoch changes.
+		e.logger.Log(logger.LevelWarn, "rejecting invalid preprepare from bucket leader, suspecting epoch", "seq_no", seqNo, "source", source, "err", err)
+		return e.suspect(false)
 	}
 
+	e.sequenceAllocated(seqNo, bucketID)
+
 	return actions
 }
 
+// sequenceAllocated reports the allocation of sequence seqNo in bucket bid
+// to the OnSequenceAllocated hook, if any.
+func (e *activeEpoch) sequenceAllocated(seqNo uint64, bid bucketID) {
+	if e.buckets[bid] == nodeID(e.myConfig.Id) {
+		e.leading = true
+	}
+
+	if e.onSequenceAllocated == nil {
+		return
+	}
+
+	e.onSequenceAllocated(seqNo, uint32(bid), uint64(e.buckets[bid]))
+}
+
 func (e *activeEpoch) applyPrepareMsg(source nodeID, seqNo uint64, digest []byte) *ActionList {
 	seq := e.sequence(seqNo)
 
+	if source != nodeID(e.myConfig.Id) && seq.owner == nodeID(e.myConfig.Id) {
+		e.heard = true
+	}
+
 	return seq.applyPrepareMsg(source, digest)
 }
 
 func (e *activeEpoch) applyCommitMsg(source nodeID, seqNo uint64, digest []byte) *ActionList {
 	seq := e.sequence(seqNo)
 
+	peerCommits := seq.peerCommits(digest)
 	seq.applyCommitMsg(source, digest)
-	if seq.state != sequenceCommitted || seqNo != e.lowestUncommitted {
+
+	actions := &ActionList{}
+	if source != nodeID(e.myConfig.Id) {
+		if seq.owner == nodeID(e.myConfig.Id) {
+			e.heard = true
+		} else if peerCommits < intersectionQuorum(e.networkConfig) && seq.peerCommits(digest) >= intersectionQuorum(e.networkConfig) {
+			actions.concat(e.checkAsymmetry(seq.commitSources[string(digest)]))
+		}
+	}
+
+	if seq.state != sequenceCommitted || seqNo < e.lowestUncommitted {
+		return actions
+	}
+
+	if seqNo > e.lowestUncommitted {
+		if seq.inconsistent {
+			return &ActionList{}
+		}
+		if reason, ok := seq.checkEntries(); !ok {
+			return e.refuseCommit(seq, reason)
+		}
+
+		var gaps []uint64
+		for gapSeqNo := e.lowestUncommitted; gapSeqNo < seqNo; gapSeqNo++ {
+			if e.sequence(gapSeqNo).state != sequenceCommitted {
+				gaps = append(gaps, gapSeqNo)
+			}
+		}
+
+		return actions.concat(e.commitState.deliverPastGaps(seq.qEntry, gaps))
+	}
+
+	return actions.concat(e.commitInOrder())
+}
+
+// checkAsymmetry is invoked as the peers in sources form a commit quorum
+// among themselves, without this node's commit.  If this node has allocated
+// sequences in the buckets it leads, yet, while the peers formed as many such
+// quorums as there are buckets, none of them prepared or committed one of
+// those sequences, this node's messages are likely not reaching its peers,
+// and network asymmetry is reported.
+func (e *activeEpoch) checkAsymmetry(sources []nodeID) *ActionList {
+	if !e.leading || e.heard || e.asymmetric {
 		return &ActionList{}
 	}
 
+	for _, source := range sources {
+		if source != nodeID(e.myConfig.Id) {
+			e.quorumPeers[source] = struct{}{}
+		}
+	}
+
+	e.peerQuorums++
+	if e.peerQuorums < len(e.buckets) {
+		return &ActionList{}
+	}
+
+	sources = make([]nodeID, 0, len(e.quorumPeers))
+	for peer := range e.quorumPeers {
+		sources = append(sources, peer)
+	}
+	peers := sortedSources(sources)
+
+	e.asymmetric = true
+	e.logger.Log(logger.LevelWarn, "peers formed commit quorums without us but never prepared our sequences, outbound messages may not be reaching them", "epoch_no", e.epochConfig.Number, "peers", peers)
+	return (&ActionList{}).NetworkAsymmetry(e.epochConfig.Number, peers)
+}
+
+// commitInOrder commits the committed sequences from the lowest uncommitted
+// one onwards, stopping at the first which has not committed.
+func (e *activeEpoch) commitInOrder() *ActionList {
 	actions := &ActionList{}
 
 	for e.lowestUncommitted <= e.highWatermark() {
 		seq := e.sequence(e.lowestUncommitted)
-		if seq.state != sequenceCommitted {
+		if seq.state != sequenceCommitted || seq.inconsistent {
 			break
 		}
 
+		if reason, ok := seq.checkEntries(); !ok {
+			return actions.concat(e.refuseCommit(seq, reason))
+		}
+
 		e.commitState.commit(seq.qEntry)
 		e.lowestUncommitted++
+		e.committed = true
 	}
 
 	return actions
 }
 
+// refuseCommit marks seq as inconsistent, so that it is never delivered,
+// and raises the alarm once.  The sequences which follow are withheld too,
+// as commits are delivered in order.
+func (e *activeEpoch) refuseCommit(seq *sequence, reason string) *ActionList {
+	seq.inconsistent = true
+	e.logger.Log(logger.LevelError, "refusing to commit sequence whose persisted entries disagree", "seq_no", seq.seqNo, "reason", reason)
+	return (&ActionList{}).Unrecoverable(reason)
+}
+
 func (e *activeEpoch) moveLowWatermark(seqNo uint64) (*ActionList, bool) {
 	if seqNo == e.epochConfig.PlannedExpiration {
 		return &ActionList{}, true
@@ -328,7 +510,9 @@ func (e *activeEpoch) moveLowWatermark(seqNo uint64) (*ActionList, bool) {
 
 	actions := e.advance()
 
-	for seqNo > e.lowWatermark() {
+	// An epoch whose starting checkpoint leaves no sequences before its
+	// planned expiration has no sequences to garbage collect.
+	for len(e.sequences) > 0 && seqNo > e.lowWatermark() {
 		e.logger.Log(logger.LevelDebug, "moved active epoch low watermarks", "low_watermark", e.lowWatermark(), "high_watermark", e.highWatermark())
 
 		e.sequences = e.sequences[1:]
@@ -370,12 +554,12 @@ func (e *activeEpoch) advance() *ActionList {
 	actions := &ActionList{}
 
 	assertGreaterThanOrEqual(e.epochConfig.PlannedExpiration, e.highWatermark(), "high watermark should never extend beyond the planned epoch expiration")
-	assertGreaterThanOrEqual(e.commitState.stopAtSeqNo, e.highWatermark(), "high watermark should never extend beyond the stop at sequence")
+	assertGreaterThanOrEqual(e.lookaheadSeqNo(), e.highWatermark(), "high watermark should never extend beyond the bucket lookahead")
 
 	ci := int(e.networkConfig.CheckpointInterval)
 
 	for e.highWatermark() < e.epochConfig.PlannedExpiration &&
-		e.highWatermark() < e.commitState.stopAtSeqNo {
+		e.highWatermark() < e.lookaheadSeqNo() {
 		newSequences := make([]*sequence, ci)
 		actions.concat(e.persisted.addNEntry(&msgs.NEntry{
 			SeqNo:       e.highWatermark() + 1,
@@ -386,17 +570,20 @@ func (e *activeEpoch) advance() *ActionList {
 			epoch := e.epochConfig.Number
 			owner := e.buckets[e.seqToBucket(seqNo)]
 			newSequences[i] = newSequence(owner, epoch, seqNo, e.persisted, e.networkConfig, e.myConfig, e.logger)
+			newSequences[i].withheld = seqNo > e.commitState.stopAtSeqNo
 		}
 		e.sequences = append(e.sequences, newSequences)
 	}
 
+	actions.concat(e.releaseWithheld())
+
 	actions.concat(e.drainBuffers())
 
 	e.proposer.advance(e.lowestUncommitted)
 
 	for bid := bucketID(0); bid < bucketID(e.networkConfig.NumberOfBuckets); bid++ {
 		ownerID := e.buckets[bid]
-		if ownerID != nodeID(e.myConfig.Id) {
+		if ownerID != nodeID(e.myConfig.Id) || e.steppingDown {
 			continue
 		}
 
@@ -412,17 +599,54 @@ func (e *activeEpoch) advance() *ActionList {
 				break
 			}
 
+			clientReqs := prb.next()
+			if clientReqs == nil {
+				// The batch was vetoed, its requests will be batched
+				// again with the next heartbeat at the latest.
+				break
+			}
+
 			seq := e.sequence(seqNo)
 
-			actions.concat(seq.allocateAsOwner(prb.next()))
+			actions.concat(seq.allocateAsOwner(clientReqs))
+			e.sequenceAllocated(seqNo, bid)
 
 			e.lowestUnallocated[int(bid)] += uint64(len(e.buckets))
 		}
 	}
 
+	actions.concat(e.checkStepDown())
+
 	return actions
 }
 
+// lookaheadSeqNo returns the highest sequence the buckets may preprepare,
+// which lies BucketLookahead checkpoint intervals beyond the commit window.
+// Each bucket proposes up to it independently, while the checkpoints, which
+// require every bucket to commit, only move the commit window, and with it
+// the lookahead, at interval boundaries.
+func (e *activeEpoch) lookaheadSeqNo() uint64 {
+	return e.commitState.stopAtSeqNo + uint64(e.networkConfig.BucketLookahead)*uint64(e.networkConfig.CheckpointInterval)
+}
+
+// releaseWithheld releases the withheld sequences which the commit window
+// now covers, and commits those which gathered a commit quorum meanwhile.
+func (e *activeEpoch) releaseWithheld() *ActionList {
+	actions := &ActionList{}
+
+	for _, interval := range e.sequences {
+		for _, seq := range interval {
+			if !seq.withheld || seq.seqNo > e.commitState.stopAtSeqNo {
+				continue
+			}
+
+			actions.concat(seq.release())
+		}
+	}
+
+	return actions.concat(e.commitInOrder())
+}
+
 func (e *activeEpoch) applyBatchHashResult(seqNo uint64, digest []byte) *ActionList {
 	if !e.inWatermarks(seqNo) {
 		// this possibly could be logged, as it indicates consumer error possibly.
@@ -436,6 +660,59 @@ func (e *activeEpoch) applyBatchHashResult(seqNo uint64, digest []byte) *ActionL
 	return seq.applyBatchHashResult(digest)
 }
 
+// suspect persists and broadcasts this node's suspicion of the epoch.
+func (e *activeEpoch) suspect(stepDown bool) *ActionList {
+	suspect := &msgs.Suspect{
+		Epoch:    e.epochConfig.Number,
+		StepDown: stepDown,
+	}
+	return e.persisted.addSuspect(suspect).Send(e.networkConfig.Nodes, &msgs.Msg{
+		Type: &msgs.Msg_Suspect{
+			Suspect: suspect,
+		},
+	})
+}
+
+// stepDown stops this node from proposing in the buckets it leads.  Once the
+// sequences it has already allocated commit, it suspects the epoch, see
+// checkStepDown.
+func (e *activeEpoch) stepDown() *ActionList {
+	e.steppingDown = true
+	return e.checkStepDown()
+}
+
+func (e *activeEpoch) checkStepDown() *ActionList {
+	if !e.steppingDown || e.steppedDown {
+		return &ActionList{}
+	}
+
+	numBuckets := uint64(len(e.buckets))
+	for bid, unallocatedSeqNo := range e.lowestUnallocated {
+		if e.buckets[bucketID(bid)] != nodeID(e.myConfig.Id) {
+			continue
+		}
+
+		if unallocatedSeqNo >= e.lowestUncommitted+numBuckets {
+			// The last sequence allocated in this bucket has not committed.
+			return &ActionList{}
+		}
+	}
+
+	e.logger.Log(logger.LevelInfo, "in-flight sequences committed, suspecting epoch to step down", "epoch_no", e.epochConfig.Number)
+	e.steppedDown = true
+	return e.suspect(true)
+}
+
+// leads returns whether the given node leads any bucket of this epoch.
+func (e *activeEpoch) leads(id nodeID) bool {
+	for _, leader := range e.buckets {
+		if leader == id {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *activeEpoch) tick() *ActionList {
 	if e.lastCommittedAtTick < e.commitState.highestCommit {
 		e.lastCommittedAtTick = e.commitState.highestCommit
@@ -447,15 +724,7 @@ func (e *activeEpoch) tick() *ActionList {
 	actions := &ActionList{}
 
 	if e.ticksSinceProgress > e.myConfig.SuspectTicks {
-		suspect := &msgs.Suspect{
-			Epoch: e.epochConfig.Number,
-		}
-		actions.Send(e.networkConfig.Nodes, &msgs.Msg{
-			Type: &msgs.Msg_Suspect{
-				Suspect: suspect,
-			},
-		})
-		actions.concat(e.persisted.addSuspect(suspect))
+		actions.concat(e.suspect(false))
 		e.logger.Log(logger.LevelDebug, "suspect epoch to have failed due to lack of active progress", "epoch_no", e.epochConfig.Number)
 	}
 
@@ -468,7 +737,7 @@ func (e *activeEpoch) tick() *ActionList {
 			continue
 		}
 
-		if e.buckets[bucketID(bid)] != nodeID(e.myConfig.Id) {
+		if e.buckets[bucketID(bid)] != nodeID(e.myConfig.Id) || e.steppingDown {
 			continue
 		}
 
@@ -483,6 +752,7 @@ func (e *activeEpoch) tick() *ActionList {
 		}
 
 		actions.concat(seq.allocateAsOwner(clientReqs))
+		e.sequenceAllocated(unallocatedSeqNo, bucketID(bid))
 
 		e.lowestUnallocated[bid] += uint64(len(e.buckets))
 	}
@@ -490,6 +760,18 @@ func (e *activeEpoch) tick() *ActionList {
 	return actions
 }
 
+// clearBuffers discards the messages buffered for this epoch, once a
+// later epoch supersedes it.
+func (e *activeEpoch) clearBuffers() {
+	for _, preprepareBuffer := range e.preprepareBuffers {
+		preprepareBuffer.buffer.clear()
+	}
+
+	for _, otherBuffer := range e.otherBuffers {
+		otherBuffer.clear()
+	}
+}
+
 func (e *activeEpoch) lowWatermark() uint64 {
 	return e.sequences[0][0].seqNo
 }
@@ -504,6 +786,58 @@ func (e *activeEpoch) highWatermark() uint64 {
 	return interval[len(interval)-1].seqNo
 }
 
+// defaultLaggingFollowerThreshold is the number of sequences led by this node
+// which may commit without a follower's commit before the follower is reported
+// as lagging, when the initial parameters do not specify one.
+const defaultLaggingFollowerThreshold = 4
+
+// followerStatus reports, for every other node, how many of the committed
+// sequences this node leads within the watermarks lack a commit from it.
+// A follower which is merely slow catches up, as its commits are recorded
+// even once the sequence has committed, while an unresponsive one lags further
+// behind with every sequence committed, until the watermarks move.
+func (e *activeEpoch) followerStatus() []*status.Follower {
+	myID := nodeID(e.myConfig.Id)
+	if len(e.sequences) == 0 || !e.leads(myID) {
+		return nil
+	}
+
+	threshold := int(e.myConfig.LaggingFollowerThreshold)
+	if threshold == 0 {
+		threshold = defaultLaggingFollowerThreshold
+	}
+
+	missing := map[nodeID]int{}
+	for seqNo := e.lowWatermark(); seqNo <= e.highWatermark(); seqNo++ {
+		seq := e.sequence(seqNo)
+		if seq.owner != myID || seq.state != sequenceCommitted {
+			continue
+		}
+
+		for _, id := range e.networkConfig.Nodes {
+			choice, ok := seq.nodeChoices[nodeID(id)]
+			if !ok || choice.state < nodeSeqPrepared {
+				missing[nodeID(id)]++
+			}
+		}
+	}
+
+	followers := make([]*status.Follower, 0, len(e.networkConfig.Nodes)-1)
+	for _, id := range e.networkConfig.Nodes {
+		if nodeID(id) == myID {
+			continue
+		}
+
+		followers = append(followers, &status.Follower{
+			ID:      id,
+			Missing: missing[nodeID(id)],
+			Lagging: missing[nodeID(id)] >= threshold,
+		})
+	}
+
+	return followers
+}
+
 func (e *activeEpoch) status() []*status.Bucket {
 	if len(e.sequences) == 0 {
 		return []*status.Bucket{}
@@ -0,0 +1,48 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statemachine
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/mirbft/pkg/logger"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+)
+
+var _ = Describe("activeEpoch", func() {
+	Describe("moveLowWatermark", func() {
+		It("garbage collects nothing in an epoch which allocated no sequences", func() {
+			sm, _ := newInitializedStateMachine(0, standardNetworkState(4, 1))
+
+			// The epoch starts at its planned expiration, so that its
+			// watermarks leave no room for sequences.
+			e := newActiveEpoch(
+				&msgs.EpochConfig{
+					Number:            1,
+					Leaders:           []uint64{0, 1, 2, 3},
+					PlannedExpiration: sm.commitState.highestCommit,
+				},
+				sm.persisted,
+				sm.nodeBuffers,
+				sm.commitState,
+				sm.clientTracker,
+				sm.myConfig,
+				nil,
+				nil,
+				nil,
+				logger.ConsoleErrorLogger,
+			)
+			e.advance()
+			Expect(e.sequences).To(BeEmpty())
+
+			_, expired := e.moveLowWatermark(sm.commitState.highestCommit + 20)
+			Expect(expired).To(BeFalse())
+			Expect(e.sequences).To(BeEmpty())
+		})
+	})
+})
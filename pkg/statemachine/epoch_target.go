@@ -50,7 +50,10 @@ type epochTarget struct {
 	readies         map[*msgs.NewEpochConfig]map[nodeID]struct{}
 	activeEpoch     *activeEpoch
 	suspicions      map[nodeID]struct{}
-	myNewEpoch      *msgs.NewEpoch // The NewEpoch msg we computed from the epoch changes we know of
+	joinedSuspicion bool                // Set once we have echoed the suspicions of some correct node
+	steppedDown     map[nodeID]struct{} // Leaders of the epoch which suspected it to step down
+	doneReason      string              // Why the epoch ended, set along with state etDone
+	myNewEpoch      *msgs.NewEpoch      // The NewEpoch msg we computed from the epoch changes we know of
 	myEpochChange   *parsedEpochChange
 	myLeaderChoice  []uint64             // Set along with myEpochChange
 	leaderNewEpoch  *msgs.NewEpoch       // The NewEpoch msg we received directly from the leader
@@ -65,6 +68,9 @@ type epochTarget struct {
 	batchTracker           *batchTracker
 	networkConfig          *msgs.NetworkState_Config
 	myConfig               *state.EventInitialParameters
+	batchOrderer           BatchOrderer
+	batchValidator         BatchValidator
+	onSequenceAllocated    SequenceAllocatedFunc
 	logger                 logger.Logger
 }
 
@@ -78,6 +84,9 @@ func newEpochTarget(
 	batchTracker *batchTracker,
 	networkConfig *msgs.NetworkState_Config,
 	myConfig *state.EventInitialParameters,
+	batchOrderer BatchOrderer,
+	batchValidator BatchValidator,
+	onSequenceAllocated SequenceAllocatedFunc,
 	logger logger.Logger,
 ) *epochTarget {
 	prestartBuffers := map[nodeID]*msgBuffer{}
@@ -93,6 +102,7 @@ func newEpochTarget(
 		number:                 number,
 		commitState:            commitState,
 		suspicions:             map[nodeID]struct{}{},
+		steppedDown:            map[nodeID]struct{}{},
 		changes:                map[nodeID]*epochChange{},
 		strongChanges:          map[nodeID]*parsedEpochChange{},
 		echos:                  map[*msgs.NewEpochConfig]map[nodeID]struct{}{},
@@ -106,6 +116,9 @@ func newEpochTarget(
 		batchTracker:           batchTracker,
 		networkConfig:          networkConfig,
 		myConfig:               myConfig,
+		batchOrderer:           batchOrderer,
+		batchValidator:         batchValidator,
+		onSequenceAllocated:    onSequenceAllocated,
 		logger:                 logger,
 	}
 }
@@ -131,9 +144,10 @@ func (et *epochTarget) step(source nodeID, msg *msgs.Msg) *ActionList {
 func (et *epochTarget) constructNewEpoch(newLeaders []uint64, nc *msgs.NetworkState_Config) *msgs.NewEpoch {
 
 	// Sanity check.
-	assertGreaterThanOrEqualf(uint64(len(et.strongChanges)), uint64(intersectionQuorum(nc)),
+	changeQuorum, _ := epochQuorums(nc, et.myConfig)
+	assertGreaterThanOrEqualf(uint64(len(et.strongChanges)), uint64(changeQuorum),
 		"received %d acked epoch change messages, only received %d",
-		uint64(len(et.strongChanges)), uint64(intersectionQuorum(nc)))
+		uint64(len(et.strongChanges)), uint64(changeQuorum))
 
 	// Compute the new epoch configuration based on the received EpochChange messages.
 	newConfig := constructNewEpochConfig(nc, newLeaders, et.strongChanges)
@@ -466,14 +480,14 @@ func (et *epochTarget) tickPending() *ActionList {
 			suspect := &msgs.Suspect{
 				Epoch: et.myNewEpoch.NewConfig.Config.Number,
 			}
-			return (&ActionList{}).Send(
+			return et.persisted.addSuspect(suspect).Send(
 				et.networkConfig.Nodes,
 				&msgs.Msg{
 					Type: &msgs.Msg_Suspect{
 						Suspect: suspect,
 					},
 				},
-			).concat(et.persisted.addSuspect(suspect))
+			)
 		}
 		if pendingTicks%2 == 0 {
 			return et.repeatEpochChangeBroadcast()
@@ -482,6 +496,18 @@ func (et *epochTarget) tickPending() *ActionList {
 	return &ActionList{}
 }
 
+// agreesOnGenesis returns whether an epoch change references the same genesis
+// epoch config as our own.  Only the epoch changes for the first epoch after
+// genesis reference it, and a node bootstrapped differently than us may
+// not participate in the epoch change.
+func (et *epochTarget) agreesOnGenesis(epochChange *msgs.EpochChange) bool {
+	if et.myEpochChange == nil {
+		return true
+	}
+
+	return proto.Equal(et.myEpochChange.underlying.Genesis, epochChange.Genesis)
+}
+
 // Applying an EpochChange message only involves sending ACKs to all other nodes
 // and locally handling own ACK.
 func (et *epochTarget) applyEpochChangeMsg(source nodeID, msg *msgs.EpochChange) *ActionList {
@@ -533,31 +559,55 @@ func (et *epochTarget) applyEpochChangeAckMsg(source nodeID, origin nodeID, msg
 // information is contained in the HashOrigin, that is, in turn, part of the result
 // of hashing (the relevant parts of) the ACK message.
 func (et *epochTarget) applyEpochChangeDigest(processedChange *state.HashOrigin_EpochChange, digest []byte) *ActionList {
-	originNode := nodeID(processedChange.Origin)
-	sourceNode := nodeID(processedChange.Source)
+	// Persist the acknowledgment, so that, should we restart before the
+	// epoch change completes, we may resume it rather than starting over.
+	// The EpochChange is only stored along with its first acknowledgment.
+	eaEntry := &msgs.EAEntry{
+		Source: processedChange.Source,
+		Origin: processedChange.Origin,
+		Digest: digest,
+		Epoch:  et.number,
+	}
+	if change, ok := et.changes[nodeID(processedChange.Origin)]; !ok || change.parsedByDigest[string(digest)] == nil {
+		eaEntry.EpochChange = processedChange.EpochChange
+	}
+	actions := et.persisted.addEAEntry(eaEntry)
+
+	if et.addEpochChangeAck(nodeID(processedChange.Source), nodeID(processedChange.Origin), processedChange.EpochChange, digest) {
+		actions.concat(et.advanceState())
+	}
+
+	return actions
+}
 
+// addEpochChangeAck registers an acknowledgment of the EpochChange of origin
+// sent by source, and returns whether the EpochChange of origin just became
+// strong, in which case the state should be advanced.
+func (et *epochTarget) addEpochChangeAck(source, origin nodeID, epochChange *msgs.EpochChange, digest []byte) bool {
 	// If this is the first ACK for an EpochChange from this origin,
 	// create a new EpochChange data structure for tracking ACKs.
-	change, ok := et.changes[originNode]
+	change, ok := et.changes[origin]
 	if !ok {
 		change = newEpochChange(et.networkConfig)
-		et.changes[originNode] = change
+		et.changes[origin] = change
 	}
 
 	// Register reception of the acknowledgment.
-	change.addAck(sourceNode, processedChange.EpochChange, digest)
+	change.addAck(source, epochChange, digest)
 
 	// If the (parsed) EpochChange has been acknowledged
-	if change.strongCert != nil {
-		// and has not yet been considered,
-		if _, alreadyConsidered := et.strongChanges[originNode]; !alreadyConsidered {
-			// assign the EpochChange to its originating node and try to advance the state.
-			et.strongChanges[originNode] = change.parsedByDigest[string(change.strongCert)]
-			return et.advanceState()
-		}
+	if change.strongCert == nil {
+		return false
 	}
 
-	return &ActionList{}
+	// and has not yet been considered,
+	if _, alreadyConsidered := et.strongChanges[origin]; alreadyConsidered {
+		return false
+	}
+
+	// assign the EpochChange to its originating node.
+	et.strongChanges[origin] = change.parsedByDigest[string(change.strongCert)]
+	return true
 }
 
 // Checks whether enough EpochChange messages have been received and acknowledged.
@@ -566,7 +616,8 @@ func (et *epochTarget) checkEpochQuorum() *ActionList {
 
 	// Do nothing if not enough EpochChanges have been received/acknowledged
 	// or the node itself is not yet ready for an epoch change.
-	if len(et.strongChanges) < intersectionQuorum(et.networkConfig) || et.myEpochChange == nil {
+	changeQuorum, _ := epochQuorums(et.networkConfig, et.myConfig)
+	if len(et.strongChanges) < changeQuorum || et.myEpochChange == nil {
 		return &ActionList{}
 	}
 
@@ -740,7 +791,8 @@ func (et *epochTarget) applyNewEpochReadyMsg(source nodeID, msg *msgs.NewEpochCo
 }
 
 // TODO: Should we move part of the functionality of applyNewEpochReadyMst() inside checkNewEpochReadyQuorum()?
-//       It might make the code more readable by keeping the same pattern as the one used with echoes.
+//
+//	It might make the code more readable by keeping the same pattern as the one used with echoes.
 func (et *epochTarget) checkNewEpochReadyQuorum() {
 	for config, msgReadies := range et.readies {
 		if len(msgReadies) < intersectionQuorum(et.networkConfig) {
@@ -820,7 +872,7 @@ func (et *epochTarget) advanceState() *ActionList {
 			et.checkEpochResumed()
 		case etReady: // New epoch is ready to begin
 			// TODO, handle case where planned epoch expiration is now
-			et.activeEpoch = newActiveEpoch(et.networkNewEpoch.Config, et.persisted, et.nodeBuffers, et.commitState, et.clientTracker, et.myConfig, et.logger)
+			et.activeEpoch = newActiveEpoch(et.networkNewEpoch.Config, et.persisted, et.nodeBuffers, et.commitState, et.clientTracker, et.myConfig, et.batchOrderer, et.batchValidator, et.onSequenceAllocated, et.logger)
 
 			actions.concat(et.activeEpoch.advance())
 
@@ -860,18 +912,81 @@ func (et *epochTarget) moveLowWatermark(seqNo uint64) *ActionList {
 	if done {
 		et.logger.Log(logger.LevelDebug, "epoch gracefully transitioning from in progress to done", "epoch_no", et.number)
 		et.state = etDone
+		et.doneReason = EpochChangeExpired
 	}
 
 	return actions
 }
 
-func (et *epochTarget) applySuspectMsg(source nodeID) {
+func (et *epochTarget) applySuspectMsg(source nodeID, stepDown bool) *ActionList {
 	et.suspicions[source] = struct{}{}
+	if stepDown && et.activeEpoch != nil && et.activeEpoch.leads(source) {
+		et.steppedDown[source] = struct{}{}
+	}
 
-	if len(et.suspicions) >= intersectionQuorum(et.networkConfig) {
+	changeQuorum, joinQuorum := epochQuorums(et.networkConfig, et.myConfig)
+	if len(et.suspicions) >= changeQuorum {
 		et.logger.Log(logger.LevelDebug, "epoch ungracefully transitioning from in progress to done", "epoch_no", et.number)
 		et.state = etDone
+		_, suspected := et.suspicions[nodeID(et.myConfig.Id)]
+		switch {
+		case len(et.steppedDown) > 0:
+			et.doneReason = EpochChangeStepDown
+		case suspected && !et.joinedSuspicion:
+			et.doneReason = EpochChangeTimeout
+		default:
+			et.doneReason = EpochChangeSuspicion
+		}
+		return &ActionList{}
+	}
+
+	// Once F+1 nodes suspect the epoch, at least one correct node does, so rather
+	// than waiting for our own timer to expire, we join the suspicion ourselves.
+	// This way, the suspicions of correct nodes quickly reach a quorum.
+	if _, ok := et.suspicions[nodeID(et.myConfig.Id)]; ok || et.joinedSuspicion {
+		return &ActionList{}
 	}
+
+	// A leader stepping down has already committed its in-flight sequences,
+	// so there is no need to wait for a correct node to suspect the epoch.
+	// As a faulty leader could use this to force epoch changes at will, a
+	// leader which steps down is left out of the leaders this node chooses
+	// for the next epoch, see epochTracker.advanceState.
+	if len(et.suspicions) < joinQuorum && len(et.steppedDown) == 0 {
+		return &ActionList{}
+	}
+
+	et.logger.Log(logger.LevelDebug, "joining suspicion of epoch suspected by a correct node or a leader stepping down", "epoch_no", et.number, "suspicions", len(et.suspicions))
+	et.joinedSuspicion = true
+
+	suspect := &msgs.Suspect{
+		Epoch: et.number,
+	}
+	return et.persisted.addSuspect(suspect).Send(
+		et.networkConfig.Nodes,
+		&msgs.Msg{
+			Type: &msgs.Msg_Suspect{
+				Suspect: suspect,
+			},
+		},
+	)
+}
+
+// leaders returns the leaders of the epoch if it became active, and otherwise
+// its primary, which failed to make it active.
+func (et *epochTarget) leaders() []uint64 {
+	if et.activeEpoch != nil {
+		return et.activeEpoch.epochConfig.Leaders
+	}
+	return []uint64{et.number % uint64(len(et.networkConfig.Nodes))}
+}
+
+func (et *epochTarget) followerStatus() []*status.Follower {
+	if et.activeEpoch == nil {
+		return nil
+	}
+
+	return et.activeEpoch.followerStatus()
 }
 
 func (et *epochTarget) bucketStatus() (lowWatermark, highWatermark uint64, bucketStatus []*status.Bucket) {
@@ -956,6 +1071,19 @@ func (et *epochTarget) bucketStatus() (lowWatermark, highWatermark uint64, bucke
 	return
 }
 
+// clearBuffers discards the messages buffered for this epoch, once a
+// later epoch supersedes it, so they no longer count against the
+// buffer capacity of their senders.
+func (et *epochTarget) clearBuffers() {
+	for _, prestartBuffer := range et.prestartBuffers {
+		prestartBuffer.clear()
+	}
+
+	if et.activeEpoch != nil {
+		et.activeEpoch.clearBuffers()
+	}
+}
+
 func (et *epochTarget) status() *status.EpochTarget {
 	result := &status.EpochTarget{
 		Number:       et.number,
@@ -1003,5 +1131,7 @@ func (et *epochTarget) status() *status.EpochTarget {
 		result.Leaders = et.leaderNewEpoch.NewConfig.Config.Leaders
 	}
 
+	result.Fresh = et.state == etInProgress && !et.activeEpoch.committed
+
 	return result
 }
@@ -0,0 +1,202 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statemachine
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/mirbft/pkg/logger"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+)
+
+var _ = Describe("epochTarget", func() {
+	var (
+		et        *epochTarget
+		persisted *persisted
+	)
+
+	suspectMsg := &msgs.Msg{
+		Type: &msgs.Msg_Suspect{
+			Suspect: &msgs.Suspect{
+				Epoch: 3,
+			},
+		},
+	}
+
+	BeforeEach(func() {
+		myConfig := &state.EventInitialParameters{
+			Id:         1,
+			BufferSize: 1024 * 1024,
+		}
+
+		networkConfig := &msgs.NetworkState_Config{
+			Nodes:              []uint64{0, 1, 2, 3, 4, 5, 6},
+			F:                  2,
+			NumberOfBuckets:    7,
+			CheckpointInterval: 5,
+		}
+
+		persisted = newPersisted(logger.ConsoleErrorLogger)
+		persisted.appendInitialLoad(1, &msgs.Persistent{
+			Type: &msgs.Persistent_CEntry{
+				CEntry: &msgs.CEntry{
+					NetworkState: &msgs.NetworkState{
+						Config: networkConfig,
+					},
+				},
+			},
+		})
+
+		et = newEpochTarget(
+			3,
+			persisted,
+			newNodeBuffers(myConfig, logger.ConsoleErrorLogger),
+			nil,
+			nil,
+			nil,
+			nil,
+			networkConfig,
+			myConfig,
+			nil,
+			nil,
+			nil,
+			logger.ConsoleErrorLogger,
+		)
+		et.state = etInProgress
+	})
+
+	Describe("applySuspectMsg", func() {
+		It("joins the suspicion once F+1 nodes suspect the epoch", func() {
+			Expect(et.applySuspectMsg(2, false).isEmpty()).To(BeTrue())
+			Expect(et.applySuspectMsg(3, false).isEmpty()).To(BeTrue())
+
+			actions := et.applySuspectMsg(4, false)
+			Expect(actions).To(Equal((&ActionList{}).Persist(2, &msgs.Persistent{
+				Type: &msgs.Persistent_Suspect{
+					Suspect: suspectMsg.Type.(*msgs.Msg_Suspect).Suspect,
+				},
+			}).Send(
+				[]uint64{0, 1, 2, 3, 4, 5, 6},
+				suspectMsg,
+			)))
+			Expect(et.state).To(Equal(epochTargetState(etInProgress)))
+
+			// The suspicion is only joined once.
+			Expect(et.applySuspectMsg(5, false).isEmpty()).To(BeTrue())
+		})
+
+		It("ends the epoch once a quorum suspects it", func() {
+			for _, id := range []nodeID{2, 3, 4, 1} {
+				et.applySuspectMsg(id, false)
+			}
+			Expect(et.state).To(Equal(epochTargetState(etInProgress)))
+
+			et.applySuspectMsg(5, false)
+			Expect(et.state).To(Equal(epochTargetState(etDone)))
+		})
+
+		It("uses the configured quorums to join and end the epoch", func() {
+			et.myConfig.EpochChangeQuorum = 6
+			et.myConfig.EpochJoinQuorum = 4
+
+			for _, id := range []nodeID{2, 3, 4} {
+				Expect(et.applySuspectMsg(id, false).isEmpty()).To(BeTrue())
+			}
+			Expect(et.applySuspectMsg(5, false).isEmpty()).To(BeFalse())
+
+			et.applySuspectMsg(1, false)
+			Expect(et.state).To(Equal(epochTargetState(etInProgress)))
+
+			et.applySuspectMsg(6, false)
+			Expect(et.state).To(Equal(epochTargetState(etDone)))
+		})
+	})
+
+	Describe("checkEpochQuorum", func() {
+		var addStrongChange func(source nodeID)
+
+		BeforeEach(func() {
+			addStrongChange = func(source nodeID) {
+				epochChange := &msgs.EpochChange{
+					NewEpoch:    3,
+					Checkpoints: []*msgs.Checkpoint{{SeqNo: 0, Value: []byte("checkpoint")}},
+				}
+				digest := []byte(fmt.Sprintf("epoch-change-%d", source))
+				for _, ackSource := range []nodeID{0, 1, 2, 3, 4} {
+					et.addEpochChangeAck(ackSource, source, epochChange, digest)
+				}
+				Expect(et.strongChanges).To(HaveKey(source))
+				if source == nodeID(et.myConfig.Id) {
+					et.myEpochChange = et.strongChanges[source]
+					et.myLeaderChoice = et.networkConfig.Nodes
+				}
+			}
+			et.state = etPrepending
+		})
+
+		It("forms the new epoch once 2F+1 epoch changes are collected, and not before", func() {
+			for _, source := range []nodeID{1, 2, 3, 4} {
+				addStrongChange(source)
+				et.checkEpochQuorum()
+				Expect(et.state).To(Equal(epochTargetState(etPrepending)))
+			}
+
+			addStrongChange(5)
+			et.checkEpochQuorum()
+			Expect(et.state).To(Equal(epochTargetState(etPending)))
+			Expect(et.myNewEpoch).NotTo(BeNil())
+		})
+
+		It("uses the configured quorum when it is consistent with the network", func() {
+			et.myConfig.EpochChangeQuorum = 7
+			et.myConfig.EpochJoinQuorum = 3
+
+			for _, source := range []nodeID{1, 2, 3, 4, 5, 6} {
+				addStrongChange(source)
+				et.checkEpochQuorum()
+				Expect(et.state).To(Equal(epochTargetState(etPrepending)))
+			}
+
+			addStrongChange(0)
+			et.checkEpochQuorum()
+			Expect(et.state).To(Equal(epochTargetState(etPending)))
+		})
+
+		It("uses a configured change quorum even if the join quorum is not configured", func() {
+			et.myConfig.EpochChangeQuorum = 6
+
+			for _, source := range []nodeID{1, 2, 3, 4, 5} {
+				addStrongChange(source)
+				et.checkEpochQuorum()
+				Expect(et.state).To(Equal(epochTargetState(etPrepending)))
+			}
+
+			addStrongChange(6)
+			et.checkEpochQuorum()
+			Expect(et.state).To(Equal(epochTargetState(etPending)))
+		})
+
+		It("ignores a configured quorum below 2F+1", func() {
+			et.myConfig.EpochChangeQuorum = 3
+			et.myConfig.EpochJoinQuorum = 2
+
+			for _, source := range []nodeID{1, 2, 3, 4} {
+				addStrongChange(source)
+				et.checkEpochQuorum()
+				Expect(et.state).To(Equal(epochTargetState(etPrepending)))
+			}
+
+			addStrongChange(5)
+			et.checkEpochQuorum()
+			Expect(et.state).To(Equal(epochTargetState(etPending)))
+		})
+	})
+})
@@ -26,12 +26,40 @@ type epochTracker struct {
 	batchTracker           *batchTracker
 	clientTracker          *clientTracker
 	clientHashDisseminator *clientHashDisseminator
+	batchOrderer           BatchOrderer
+	batchValidator         BatchValidator
+	onSequenceAllocated    SequenceAllocatedFunc
+	leaderSelector         LeaderSelector
 	futureMsgs             map[nodeID]*msgBuffer
+	futureEpochChanges     map[nodeID]*msgBuffer
 	needsStateTransfer     bool
 
 	maxEpochs              map[nodeID]uint64
 	maxCorrectEpoch        uint64
+	maxJustifiedEpoch      uint64
 	ticksOutOfCorrectEpoch int
+
+	// lagging returns whether a node is behind this node, see step.
+	lagging func(source nodeID) bool
+
+	// steppedDown is set when this node steps down, so that it excludes
+	// itself from the leaders it chooses for the next epoch.
+	steppedDown bool
+
+	// suspicions counts, for each node, the epochs it led which ended
+	// because they were suspected, as passed to the leaderSelector.
+	suspicions map[uint64]uint64
+
+	// Bucket assignment of the last epoch observed to become active, used to
+	// report changes in this node's leadership.  Nil until an epoch becomes active.
+	lastActiveBuckets     map[bucketID]nodeID
+	lastActiveEpochNumber uint64
+
+	// Ticks elapsed, and the tick at which each epoch change within the
+	// alarm window began, used to report an unstable network.
+	ticks            uint64
+	epochChangeTicks []uint64
+	unstable         bool
 }
 
 func newEpochTracker(
@@ -44,6 +72,10 @@ func newEpochTracker(
 	batchTracker *batchTracker,
 	clientTracker *clientTracker,
 	clientHashDisseminator *clientHashDisseminator,
+	batchOrderer BatchOrderer,
+	batchValidator BatchValidator,
+	onSequenceAllocated SequenceAllocatedFunc,
+	leaderSelector LeaderSelector,
 ) *epochTracker {
 	return &epochTracker{
 		persisted:              persisted,
@@ -54,7 +86,12 @@ func newEpochTracker(
 		batchTracker:           batchTracker,
 		clientTracker:          clientTracker,
 		clientHashDisseminator: clientHashDisseminator,
+		batchOrderer:           batchOrderer,
+		batchValidator:         batchValidator,
+		onSequenceAllocated:    onSequenceAllocated,
+		leaderSelector:         leaderSelector,
 		maxEpochs:              map[nodeID]uint64{},
+		suspicions:             map[uint64]uint64{},
 	}
 }
 
@@ -74,6 +111,19 @@ func (et *epochTracker) reinitialize() *ActionList {
 	}
 	et.futureMsgs = newFutureMsgs
 
+	newFutureEpochChanges := map[nodeID]*msgBuffer{}
+	for _, id := range et.networkConfig.Nodes {
+		futureEpochChanges, ok := et.futureEpochChanges[nodeID(id)]
+		if !ok {
+			futureEpochChanges = newMsgBuffer(
+				"future-epoch-changes",
+				et.nodeBuffers.nodeBuffer(nodeID(id)),
+			)
+		}
+		newFutureEpochChanges[nodeID(id)] = futureEpochChanges
+	}
+	et.futureEpochChanges = newFutureEpochChanges
+
 	actions := &ActionList{}
 	var lastNEntry *msgs.NEntry
 	var lastECEntry *msgs.ECEntry
@@ -128,6 +178,9 @@ func (et *epochTracker) reinitialize() *ActionList {
 	case lastNEntry != nil && (lastECEntry == nil || lastECEntry.EpochNumber <= lastNEntry.EpochConfig.Number):
 		et.logger.Log(logger.LevelDebug, "reinitializing during a currently active epoch")
 
+		if et.currentEpoch != nil {
+			et.currentEpoch.clearBuffers()
+		}
 		et.currentEpoch = newEpochTarget(
 			lastNEntry.EpochConfig.Number,
 			et.persisted,
@@ -138,6 +191,9 @@ func (et *epochTracker) reinitialize() *ActionList {
 			et.batchTracker,
 			et.networkConfig,
 			et.myConfig,
+			et.batchOrderer,
+			et.batchValidator,
+			et.onSequenceAllocated,
 			et.logger,
 		)
 
@@ -185,6 +241,9 @@ func (et *epochTracker) reinitialize() *ActionList {
 		parsedEpochChange, err := newParsedEpochChange(epochChange)
 		assertEqualf(err, nil, "could not parse epoch change we generated: %s", err)
 
+		if et.currentEpoch != nil {
+			et.currentEpoch.clearBuffers()
+		}
 		et.currentEpoch = newEpochTarget(
 			epochChange.NewEpoch,
 			et.persisted,
@@ -195,6 +254,9 @@ func (et *epochTracker) reinitialize() *ActionList {
 			et.batchTracker,
 			et.networkConfig,
 			et.myConfig,
+			et.batchOrderer,
+			et.batchValidator,
+			et.onSequenceAllocated,
 			et.logger,
 		)
 
@@ -202,14 +264,48 @@ func (et *epochTracker) reinitialize() *ActionList {
 
 		// XXX this leader selection is wrong, but using while we modify the startup.
 		// instead base it on the lastEpochConfig and whether that epoch ended gracefully.
-		_, _ = lastEpochConfig, graceful
 		et.currentEpoch.myLeaderChoice = et.networkConfig.Nodes
+		if graceful && lastEpochConfig.Number == 0 {
+			// The network is bootstrapping, adopt the leaders of the
+			// genesis epoch config provided by the operator.
+			et.currentEpoch.myLeaderChoice = lastEpochConfig.Leaders
+		}
+
+		// Resume collecting the epoch change from the acknowledgments we
+		// persisted before restarting, rather than starting over.
+		resumedAcks := 0
+		epochChanges := map[string]*msgs.EpochChange{}
+		et.persisted.iterate(logIterator{
+			onEAEntry: func(eaEntry *msgs.EAEntry) {
+				if eaEntry.Epoch != lastECEntry.EpochNumber {
+					return
+				}
+				key := fmt.Sprintf("%d-%x", eaEntry.Origin, eaEntry.Digest)
+				if eaEntry.EpochChange != nil {
+					epochChanges[key] = eaEntry.EpochChange
+				}
+				epochChange, ok := epochChanges[key]
+				if !ok {
+					et.logger.Log(logger.LevelWarn, "ignoring persisted acknowledgement of an epoch change which was not persisted", "origin", eaEntry.Origin, "source", eaEntry.Source)
+					return
+				}
+				et.currentEpoch.addEpochChangeAck(nodeID(eaEntry.Source), nodeID(eaEntry.Origin), epochChange, eaEntry.Digest)
+				resumedAcks++
+			},
+		})
+		if resumedAcks > 0 {
+			et.logger.Log(logger.LevelInfo, "resuming epoch change from persisted acknowledgements", "epoch_no", lastECEntry.EpochNumber, "acks", resumedAcks)
+			actions.concat(et.currentEpoch.advanceState())
+		}
 	default:
 		// There's no active epoch, it did not end gracefully, or ungracefully
 		panic("no recorded active epoch, ended epoch, or epoch change in log")
 	}
 
 	for _, id := range et.networkConfig.Nodes {
+		et.futureEpochChanges[nodeID(id)].iterate(et.filter, func(source nodeID, msg *msgs.Msg) {
+			actions.concat(et.applyMsg(source, msg))
+		})
 		et.futureMsgs[nodeID(id)].iterate(et.filter, func(source nodeID, msg *msgs.Msg) {
 			actions.concat(et.applyMsg(source, msg))
 		})
@@ -218,9 +314,48 @@ func (et *epochTracker) reinitialize() *ActionList {
 	return actions
 }
 
+// defaultEpochChangeAlarmWindowTicks is the number of ticks over which epoch
+// changes are counted when the initial parameters do not specify one.
+const defaultEpochChangeAlarmWindowTicks = 100
+
+// checkInstability forgets the epoch changes which began before the alarm
+// window, and returns an EpochInstability action when the remaining ones
+// newly reach the alarm threshold.
+func (et *epochTracker) checkInstability() *ActionList {
+	threshold := int(et.myConfig.EpochChangeAlarmThreshold)
+	if threshold == 0 {
+		return &ActionList{}
+	}
+
+	window := uint64(et.myConfig.EpochChangeAlarmWindowTicks)
+	if window == 0 {
+		window = defaultEpochChangeAlarmWindowTicks
+	}
+
+	i := 0
+	for i < len(et.epochChangeTicks) && et.epochChangeTicks[i]+window <= et.ticks {
+		i++
+	}
+	et.epochChangeTicks = et.epochChangeTicks[i:]
+
+	if len(et.epochChangeTicks) < threshold {
+		et.unstable = false
+		return &ActionList{}
+	}
+
+	if et.unstable {
+		return &ActionList{}
+	}
+	et.unstable = true
+
+	et.logger.Log(logger.LevelWarn, "epoch changes exceed the alarm threshold, the network is unstable", "epoch_no", et.currentEpoch.number, "epoch_changes", len(et.epochChangeTicks), "window_ticks", window)
+
+	return (&ActionList{}).EpochInstability(et.currentEpoch.number, uint32(len(et.epochChangeTicks)), uint32(window))
+}
+
 func (et *epochTracker) advanceState() *ActionList {
 	if et.currentEpoch.state < etDone {
-		return et.currentEpoch.advanceState()
+		return et.currentEpoch.advanceState().concat(et.checkLeadershipChange())
 	}
 
 	if et.commitState.checkpointPending {
@@ -233,11 +368,25 @@ func (et *epochTracker) advanceState() *ActionList {
 	if et.maxCorrectEpoch > newEpochNumber {
 		newEpochNumber = et.maxCorrectEpoch
 	}
+	if maxJump := uint64(et.myConfig.MaxEpochJump); maxJump > 0 &&
+		newEpochNumber > et.currentEpoch.number+maxJump &&
+		newEpochNumber > et.maxJustifiedEpoch {
+		et.logger.Log(logger.LevelDebug, "limiting epoch change target to the maximum epoch jump", "epoch_no", et.currentEpoch.number, "max_correct_epoch", et.maxCorrectEpoch, "max_epoch_jump", maxJump)
+		newEpochNumber = et.currentEpoch.number + maxJump
+	}
 	epochChange := et.persisted.constructEpochChange(newEpochNumber)
 
 	myEpochChange, err := newParsedEpochChange(epochChange)
 	assertEqualf(err, nil, "could not parse epoch change we generated: %s", err)
 
+	previousEpoch, reason := et.currentEpoch.number, et.currentEpoch.doneReason
+	previousSteppedDown := et.currentEpoch.steppedDown
+	if reason == EpochChangeSuspicion || reason == EpochChangeTimeout {
+		for _, id := range et.currentEpoch.leaders() {
+			et.suspicions[id]++
+		}
+	}
+	et.currentEpoch.clearBuffers()
 	et.currentEpoch = newEpochTarget(
 		newEpochNumber,
 		et.persisted,
@@ -248,10 +397,35 @@ func (et *epochTracker) advanceState() *ActionList {
 		et.batchTracker,
 		et.networkConfig,
 		et.myConfig,
+		et.batchOrderer,
+		et.batchValidator,
+		et.onSequenceAllocated,
 		et.logger,
 	)
 	et.currentEpoch.myEpochChange = myEpochChange
 	et.currentEpoch.myLeaderChoice = []uint64{et.myConfig.Id} // XXX, wrong
+	if et.leaderSelector != nil {
+		et.currentEpoch.myLeaderChoice = et.leaderSelector(newEpochNumber, et.networkConfig.Nodes, et.suspicions)
+	}
+	excluded := map[nodeID]struct{}{}
+	if et.steppedDown {
+		excluded[nodeID(et.myConfig.Id)] = struct{}{}
+		et.steppedDown = false
+	}
+	if reason == EpochChangeStepDown {
+		for id := range previousSteppedDown {
+			excluded[id] = struct{}{}
+		}
+	}
+	if len(excluded) > 0 {
+		myLeaderChoice := excludeLeaders(et.currentEpoch.myLeaderChoice, excluded)
+		if len(myLeaderChoice) == 0 {
+			myLeaderChoice = excludeLeaders(et.networkConfig.Nodes, excluded)
+		}
+		if len(myLeaderChoice) > 0 {
+			et.currentEpoch.myLeaderChoice = myLeaderChoice
+		}
+	}
 
 	actions := et.persisted.addECEntry(&msgs.ECEntry{
 		EpochNumber: newEpochNumber,
@@ -264,7 +438,16 @@ func (et *epochTracker) advanceState() *ActionList {
 		},
 	)
 
+	et.logger.Log(logger.LevelInfo, "beginning epoch change", "previous_epoch", previousEpoch, "epoch_no", newEpochNumber, "reason", reason)
+	actions.EpochChanged(newEpochNumber, previousEpoch, reason)
+
+	et.epochChangeTicks = append(et.epochChangeTicks, et.ticks)
+	actions.concat(et.checkInstability())
+
 	for _, id := range et.networkConfig.Nodes {
+		et.futureEpochChanges[nodeID(id)].iterate(et.filter, func(source nodeID, msg *msgs.Msg) {
+			actions.concat(et.applyMsg(source, msg))
+		})
 		et.futureMsgs[nodeID(id)].iterate(et.filter, func(source nodeID, msg *msgs.Msg) {
 			actions.concat(et.applyMsg(source, msg))
 		})
@@ -273,6 +456,32 @@ func (et *epochTracker) advanceState() *ActionList {
 	return actions
 }
 
+// checkLeadershipChange returns a LeadershipChanged action the first time it observes
+// a newly active epoch in which the set of buckets led by this node differs from
+// the previously active epoch.
+func (et *epochTracker) checkLeadershipChange() *ActionList {
+	activeEpoch := et.currentEpoch.activeEpoch
+	if activeEpoch == nil {
+		return &ActionList{}
+	}
+
+	if et.lastActiveBuckets != nil && et.lastActiveEpochNumber == activeEpoch.epochConfig.Number {
+		return &ActionList{}
+	}
+
+	gained, lost := bucketLeadershipDiff(nodeID(et.myConfig.Id), et.lastActiveBuckets, activeEpoch.buckets)
+	et.lastActiveBuckets = activeEpoch.buckets
+	et.lastActiveEpochNumber = activeEpoch.epochConfig.Number
+
+	if len(gained) == 0 && len(lost) == 0 {
+		return &ActionList{}
+	}
+
+	et.logger.Log(logger.LevelInfo, "bucket leadership changed", "epoch_no", activeEpoch.epochConfig.Number, "gained", gained, "lost", lost)
+
+	return (&ActionList{}).LeadershipChanged(activeEpoch.epochConfig.Number, gained, lost)
+}
+
 func epochForMsg(msg *msgs.Msg) uint64 {
 	switch innerMsg := msg.Type.(type) {
 	case *msgs.Msg_Preprepare:
@@ -298,6 +507,69 @@ func epochForMsg(msg *msgs.Msg) uint64 {
 	}
 }
 
+// defaultMaxBufferedEpochChanges is the number of future epochs for which epoch
+// change messages are buffered per node when the initial parameters do not
+// specify one.
+const defaultMaxBufferedEpochChanges = 4
+
+func (et *epochTracker) maxBufferedEpochChanges() int {
+	if et.myConfig.MaxBufferedEpochChanges == 0 {
+		return defaultMaxBufferedEpochChanges
+	}
+	return int(et.myConfig.MaxBufferedEpochChanges)
+}
+
+// checkEpochChangeBuffer returns whether an epoch change message for the
+// future epoch epochNumber may be added to buffer, the epoch change buffer of
+// its source, and otherwise a description of the excess.  A correct node sends
+// an epoch change, and acknowledges that of every node, per epoch, so rather
+// than the messages, the distinct epochs they are for are bounded.
+func (et *epochTracker) checkEpochChangeBuffer(buffer *msgBuffer, epochNumber uint64) (string, bool) {
+	epochs := map[uint64]struct{}{}
+	for e := buffer.buffer.Front(); e != nil; e = e.Next() {
+		epochs[epochForMsg(e.Value.(*msgs.Msg))] = struct{}{}
+	}
+
+	if _, ok := epochs[epochNumber]; ok || len(epochs) < et.maxBufferedEpochChanges() {
+		return "", true
+	}
+
+	return fmt.Sprintf("sent epoch change messages for more than %d future epochs", et.maxBufferedEpochChanges()), false
+}
+
+// defaultFutureEpochLookahead is the number of epochs beyond the current one
+// for which ordering messages are buffered when the initial parameters do not
+// specify one.
+const defaultFutureEpochLookahead = 4
+
+func (et *epochTracker) futureEpochLookahead() uint64 {
+	if et.myConfig.FutureEpochLookahead == 0 {
+		return defaultFutureEpochLookahead
+	}
+	return uint64(et.myConfig.FutureEpochLookahead)
+}
+
+// isOrderingMsg returns whether msg is a preprepare, prepare or commit.
+func isOrderingMsg(msg *msgs.Msg) bool {
+	switch msg.Type.(type) {
+	case *msgs.Msg_Preprepare, *msgs.Msg_Prepare, *msgs.Msg_Commit:
+		return true
+	default:
+		return false
+	}
+}
+
+// isEpochChangeMsg returns whether msg is an epoch change, or an
+// acknowledgement of one, both of which carry the sender's P and Q sets.
+func isEpochChangeMsg(msg *msgs.Msg) bool {
+	switch msg.Type.(type) {
+	case *msgs.Msg_EpochChange, *msgs.Msg_EpochChangeAck:
+		return true
+	default:
+		return false
+	}
+}
+
 func (et *epochTracker) filter(_ nodeID, msg *msgs.Msg) applyable {
 	epochNumber := epochForMsg(msg)
 
@@ -320,11 +592,36 @@ func (et *epochTracker) step(source nodeID, msg *msgs.Msg) *ActionList {
 		return &ActionList{}
 	case epochNumber > et.currentEpoch.number:
 		// future
+		buffer := et.futureMsgs[source]
+		if isEpochChangeMsg(msg) {
+			buffer = et.futureEpochChanges[source]
+			if reason, ok := et.checkEpochChangeBuffer(buffer, epochNumber); !ok {
+				if et.lagging(source) {
+					// A node cut off from the network keeps
+					// starting epoch changes on its own, so
+					// once back, it is behind, yet sends them
+					// for many epochs ahead of ours.
+					et.logger.Log(logger.LevelDebug, "dropping epoch change message from a lagging node, too many are buffered", "source", source, "epoch_no", epochNumber)
+					return &ActionList{}
+				}
+				et.logger.Log(logger.LevelWarn, "dropping epoch change message, too many are buffered", "source", source, "epoch_no", epochNumber)
+				return (&ActionList{}).Misbehavior(uint64(source), reason)
+			}
+		}
+
+		// Whether or not we buffer the message, the epoch it references
+		// is a sign that we may be behind, see tick.
 		maxEpoch := et.maxEpochs[source]
 		if maxEpoch < epochNumber {
 			et.maxEpochs[source] = epochNumber
 		}
-		et.futureMsgs[source].store(msg)
+
+		if isOrderingMsg(msg) && epochNumber > et.currentEpoch.number+et.futureEpochLookahead() {
+			et.logger.Log(logger.LevelDebug, "dropping message for an epoch beyond the lookahead", "source", source, "epoch_no", epochNumber, "current_epoch", et.currentEpoch.number)
+			return &ActionList{}
+		}
+
+		buffer.store(msg)
 		return &ActionList{}
 	default:
 		// current
@@ -343,11 +640,18 @@ func (et *epochTracker) applyMsg(source nodeID, msg *msgs.Msg) *ActionList {
 	case *msgs.Msg_Commit:
 		return target.step(source, msg)
 	case *msgs.Msg_Suspect:
-		target.applySuspectMsg(source)
-		return &ActionList{}
+		return target.applySuspectMsg(source, innerMsg.Suspect.StepDown)
 	case *msgs.Msg_EpochChange:
+		if !target.agreesOnGenesis(innerMsg.EpochChange) {
+			et.logger.Log(logger.LevelWarn, "ignoring epoch change from node bootstrapped with a different genesis epoch config", "source", source, "epoch_no", innerMsg.EpochChange.NewEpoch)
+			return &ActionList{}
+		}
 		return target.applyEpochChangeMsg(source, innerMsg.EpochChange)
 	case *msgs.Msg_EpochChangeAck:
+		if !target.agreesOnGenesis(innerMsg.EpochChangeAck.EpochChange) {
+			et.logger.Log(logger.LevelWarn, "ignoring epoch change ack for node bootstrapped with a different genesis epoch config", "source", source, "originator", innerMsg.EpochChangeAck.Originator, "epoch_no", innerMsg.EpochChangeAck.EpochChange.NewEpoch)
+			return &ActionList{}
+		}
 		return target.applyEpochChangeAckMsg(source, nodeID(innerMsg.EpochChangeAck.Originator), innerMsg.EpochChangeAck.EpochChange)
 	case *msgs.Msg_NewEpoch:
 		// Ignore NewEpoch message if not sent by the epoch primary.
@@ -374,12 +678,17 @@ func (et *epochTracker) applyBatchHashResult(epoch, seqNo uint64, digest []byte)
 	return et.currentEpoch.activeEpoch.applyBatchHashResult(seqNo, digest)
 }
 
+// defaultCatchUpTicks is the number of ticks a node waits before catching up
+// to a later epoch when the initial parameters do not specify one.
+const defaultCatchUpTicks = 10
+
 func (et *epochTracker) tick() *ActionList {
+	changeQuorum, joinQuorum := epochQuorums(et.networkConfig, et.myConfig)
 	for _, maxEpoch := range et.maxEpochs {
-		if maxEpoch <= et.maxCorrectEpoch {
+		if maxEpoch <= et.maxJustifiedEpoch {
 			continue
 		}
-		matches := 1
+		matches := 0
 		for _, matchingEpoch := range et.maxEpochs {
 			if matchingEpoch < maxEpoch {
 				continue
@@ -387,23 +696,76 @@ func (et *epochTracker) tick() *ActionList {
 			matches++
 		}
 
-		if matches < someCorrectQuorum(et.networkConfig) {
-			continue
+		if matches >= joinQuorum && maxEpoch > et.maxCorrectEpoch {
+			et.maxCorrectEpoch = maxEpoch
 		}
 
-		et.maxCorrectEpoch = maxEpoch
+		if matches >= changeQuorum {
+			et.maxJustifiedEpoch = maxEpoch
+		}
 	}
 
 	if et.maxCorrectEpoch > et.currentEpoch.number {
 		et.ticksOutOfCorrectEpoch++
 
-		// TODO make this configurable
-		if et.ticksOutOfCorrectEpoch > 10 {
+		catchUpTicks := int(et.myConfig.CatchUpTicks)
+		if catchUpTicks == 0 {
+			catchUpTicks = defaultCatchUpTicks
+		}
+
+		if et.ticksOutOfCorrectEpoch > catchUpTicks && et.currentEpoch.state != etDone {
+			et.logger.Log(logger.LevelInfo, "abandoning epoch to catch up with a later epoch referenced by f+1 nodes", "current_epoch", et.currentEpoch.number, "max_correct_epoch", et.maxCorrectEpoch)
 			et.currentEpoch.state = etDone
+			et.currentEpoch.doneReason = EpochChangeCatchUp
+			et.ticksOutOfCorrectEpoch = 0
 		}
+	} else {
+		et.ticksOutOfCorrectEpoch = 0
 	}
 
-	return et.currentEpoch.tick()
+	et.ticks++
+
+	return et.checkInstability().concat(et.currentEpoch.tick())
+}
+
+// excludeLeaders returns the candidates which are not in excluded.
+func excludeLeaders(candidates []uint64, excluded map[nodeID]struct{}) []uint64 {
+	result := []uint64{}
+	for _, id := range candidates {
+		if _, ok := excluded[nodeID(id)]; !ok {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// stepDown relinquishes this node's leadership of the buckets of the epoch in
+// progress, completing its in-flight sequences first.  The epoch configuration
+// fixes the leader of every bucket, so the buckets cannot be handed over alone:
+// the whole epoch ends, for every node, see epochTarget.applySuspectMsg.
+func (et *epochTracker) stepDown() *ActionList {
+	if et.currentEpoch.state != etInProgress {
+		et.logger.Log(logger.LevelWarn, "ignoring request to step down, as no epoch is in progress", "epoch_no", et.currentEpoch.number)
+		return &ActionList{}
+	}
+
+	et.logger.Log(logger.LevelInfo, "stepping down from leading buckets", "epoch_no", et.currentEpoch.number)
+	et.steppedDown = true
+	return et.currentEpoch.activeEpoch.stepDown()
+}
+
+// endEpochForReconfiguration records that the active epoch ended gracefully at
+// a stable reconfiguration checkpoint, so that once reinitialized under the new
+// network configuration, the state machine starts an epoch change for the next
+// epoch.
+func (et *epochTracker) endEpochForReconfiguration() *ActionList {
+	if et.currentEpoch.activeEpoch == nil {
+		return &ActionList{}
+	}
+
+	return et.persisted.addFEntry(&msgs.FEntry{
+		EndsEpochConfig: et.currentEpoch.activeEpoch.epochConfig,
+	})
 }
 
 func (et *epochTracker) moveLowWatermark(seqNo uint64) *ActionList {
@@ -426,5 +788,19 @@ func (et *epochTracker) applyEpochChangeDigest(origin *state.HashOrigin_EpochCha
 func (et *epochTracker) status() *status.EpochTracker {
 	return &status.EpochTracker{
 		ActiveEpoch: et.currentEpoch.status(),
+		Unstable:    et.unstable,
 	}
 }
+
+// progressQuorum returns the number of nodes which must be responsive for
+// the current epoch to advance.  The three-phase commit of an active epoch
+// waits on an intersection quorum, the epoch change protocol on the epoch
+// change quorum.
+func (et *epochTracker) progressQuorum() int {
+	if et.currentEpoch.state == etInProgress {
+		return intersectionQuorum(et.networkConfig)
+	}
+
+	changeQuorum, _ := epochQuorums(et.networkConfig, et.myConfig)
+	return changeQuorum
+}
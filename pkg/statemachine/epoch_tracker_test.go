@@ -0,0 +1,140 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statemachine
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hyperledger-labs/mirbft/pkg/logger"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+)
+
+var _ = Describe("epochTracker", func() {
+	var (
+		et            *epochTracker
+		networkConfig *msgs.NetworkState_Config
+	)
+
+	activate := func(epochConfig *msgs.EpochConfig) {
+		et.currentEpoch = &epochTarget{
+			number: epochConfig.Number,
+			activeEpoch: &activeEpoch{
+				epochConfig: epochConfig,
+				buckets:     assignBuckets(epochConfig, networkConfig),
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		networkConfig = &msgs.NetworkState_Config{
+			Nodes:           []uint64{0, 1, 2, 3},
+			F:               1,
+			NumberOfBuckets: 4,
+		}
+
+		et = &epochTracker{
+			myConfig: &state.EventInitialParameters{
+				Id: 0,
+			},
+			networkConfig: networkConfig,
+			logger:        logger.ConsoleErrorLogger,
+		}
+	})
+
+	Describe("checkLeadershipChange", func() {
+		It("reports the buckets gained when the node becomes a leader", func() {
+			activate(&msgs.EpochConfig{
+				Number:  1,
+				Leaders: []uint64{1, 2, 3},
+			})
+			Expect(et.checkLeadershipChange().isEmpty()).To(BeTrue())
+
+			activate(&msgs.EpochConfig{
+				Number:  2,
+				Leaders: []uint64{0, 1, 2, 3},
+			})
+			Expect(et.checkLeadershipChange()).To(Equal((&ActionList{}).LeadershipChanged(2, []uint64{2}, nil)))
+
+			// The change is only reported once per epoch.
+			Expect(et.checkLeadershipChange().isEmpty()).To(BeTrue())
+		})
+
+		It("reports the buckets lost when the node stops being a leader", func() {
+			activate(&msgs.EpochConfig{
+				Number:  2,
+				Leaders: []uint64{0, 1, 2, 3},
+			})
+			Expect(et.checkLeadershipChange()).To(Equal((&ActionList{}).LeadershipChanged(2, []uint64{2}, nil)))
+
+			activate(&msgs.EpochConfig{
+				Number:  3,
+				Leaders: []uint64{1, 2, 3},
+			})
+			Expect(et.checkLeadershipChange()).To(Equal((&ActionList{}).LeadershipChanged(3, nil, []uint64{2})))
+		})
+	})
+})
+
+var _ = Describe("epochForMsg", func() {
+	epochChange := &msgs.EpochChange{
+		NewEpoch: 5,
+		Checkpoints: []*msgs.Checkpoint{
+			{SeqNo: 20, Value: []byte("value")},
+		},
+		PSet: []*msgs.EpochChange_SetEntry{
+			{Epoch: 4, SeqNo: 21, Digest: []byte("p-digest")},
+		},
+		QSet: []*msgs.EpochChange_SetEntry{
+			{Epoch: 4, SeqNo: 21, Digest: []byte("q-digest")},
+		},
+	}
+
+	DescribeTable("survives a marshaling round trip and yields the target epoch",
+		func(msg *msgs.Msg) {
+			data, err := proto.Marshal(msg)
+			Expect(err).NotTo(HaveOccurred())
+			decoded := &msgs.Msg{}
+			Expect(proto.Unmarshal(data, decoded)).To(Succeed())
+			Expect(proto.Equal(msg, decoded)).To(BeTrue())
+			Expect(epochForMsg(decoded)).To(Equal(uint64(5)))
+		},
+		Entry("EpochChange", &msgs.Msg{
+			Type: &msgs.Msg_EpochChange{
+				EpochChange: epochChange,
+			},
+		}),
+		Entry("EpochChangeAck", &msgs.Msg{
+			Type: &msgs.Msg_EpochChangeAck{
+				EpochChangeAck: &msgs.EpochChangeAck{
+					Originator:  2,
+					EpochChange: epochChange,
+				},
+			},
+		}),
+		Entry("NewEpoch", &msgs.Msg{
+			Type: &msgs.Msg_NewEpoch{
+				NewEpoch: &msgs.NewEpoch{
+					NewConfig: &msgs.NewEpochConfig{
+						Config: &msgs.EpochConfig{
+							Number:  5,
+							Leaders: []uint64{0, 1, 2, 3},
+						},
+						StartingCheckpoint: &msgs.Checkpoint{SeqNo: 20, Value: []byte("value")},
+						FinalPreprepares:   [][]byte{[]byte("q-digest")},
+					},
+					EpochChanges: []*msgs.NewEpoch_RemoteEpochChange{
+						{NodeId: 2, Digest: []byte("ec-digest")},
+					},
+				},
+			},
+		}),
+	)
+})
@@ -51,11 +51,12 @@ func (el *EventList) Len() int {
 	return el.list.Len()
 }
 
-func (el *EventList) ClientRequest(clientID uint64, reqNo uint64, data []byte) *EventList {
+func (el *EventList) ClientRequest(clientID uint64, reqNo uint64, data, metadata []byte) *EventList {
 	el.PushBack(&state.Event{Type: &state.Event_Request{Request: &msgs.Request{
 		ClientId: clientID,
 		ReqNo:    reqNo,
 		Data:     data,
+		Metadata: metadata,
 	}}})
 	return el
 }
@@ -144,6 +145,11 @@ func (el *EventList) RequestPersisted(ack *msgs.RequestAck) *EventList {
 	return el
 }
 
+// EventRequestPersisted notifies the state machine that the request
+// identified by ack has been persisted.  The state machine only ever retains
+// the acks of requests, their payloads are held by the RequestStore, keyed by
+// client, request number and digest, and are retrieved from it when forwarding
+// requests or applying committed batches.
 func EventRequestPersisted(ack *msgs.RequestAck) *state.Event {
 	return &state.Event{
 		Type: &state.Event_RequestPersisted{
@@ -154,6 +160,48 @@ func EventRequestPersisted(ack *msgs.RequestAck) *state.Event {
 	}
 }
 
+func (el *EventList) RequestRejected(ack *msgs.RequestAck, reason string) *EventList {
+	el.PushBack(EventRequestRejected(ack, reason))
+	return el
+}
+
+// EventRequestRejected notifies the state machine that preprocessing the
+// request identified by ack failed, and that it was not persisted.  The state
+// machine will neither ack nor proactively fetch the request.  Notifying the
+// client, if desired, is left to the caller.
+func EventRequestRejected(ack *msgs.RequestAck, reason string) *state.Event {
+	return &state.Event{
+		Type: &state.Event_RequestPersisted{
+			RequestPersisted: &state.EventRequestPersisted{
+				RequestAck:   ack,
+				Rejected:     true,
+				RejectReason: reason,
+			},
+		},
+	}
+}
+
+func (el *EventList) ForwardedRequestRejected(source uint64, ack *msgs.RequestAck, reason string) *EventList {
+	el.PushBack(EventForwardedRequestRejected(source, ack, reason))
+	return el
+}
+
+// EventForwardedRequestRejected is as EventRequestRejected, but for a request
+// forwarded by source, which is reported as misbehaving.
+func EventForwardedRequestRejected(source uint64, ack *msgs.RequestAck, reason string) *state.Event {
+	return &state.Event{
+		Type: &state.Event_RequestPersisted{
+			RequestPersisted: &state.EventRequestPersisted{
+				RequestAck:   ack,
+				Rejected:     true,
+				RejectReason: reason,
+				Forwarded:    true,
+				Source:       source,
+			},
+		},
+	}
+}
+
 func (el *EventList) StateTransferComplete(networkState *msgs.NetworkState, actionStateTransfer *state.ActionStateTarget) *EventList {
 	el.PushBack(EventStateTransferComplete(networkState, actionStateTransfer))
 	return el
@@ -229,6 +277,108 @@ func EventActionsReceived() *state.Event {
 	}
 }
 
+// CommitsApplied acknowledges, in a single event, that all commits in the
+// inclusive range [from, to] have been applied by the application.
+func (el *EventList) CommitsApplied(from, to uint64) *EventList {
+	el.PushBack(EventCommitsApplied(from, to))
+	return el
+}
+
+func EventCommitsApplied(from, to uint64) *state.Event {
+	return &state.Event{
+		Type: &state.Event_CommitsApplied{
+			CommitsApplied: &state.EventCommitsApplied{
+				From: from,
+				To:   to,
+			},
+		},
+	}
+}
+
+func (el *EventList) Compact() *EventList {
+	el.PushBack(EventCompact())
+	return el
+}
+
+func EventCompact() *state.Event {
+	return &state.Event{
+		Type: &state.Event_Compact{
+			Compact: &state.EventCompact{},
+		},
+	}
+}
+
+func (el *EventList) StepDown() *EventList {
+	el.PushBack(EventStepDown())
+	return el
+}
+
+func EventStepDown() *state.Event {
+	return &state.Event{
+		Type: &state.Event_StepDown{
+			StepDown: &state.EventStepDown{},
+		},
+	}
+}
+
+func (el *EventList) AuditDigest(seqNo uint64) *EventList {
+	el.PushBack(EventAuditDigest(seqNo))
+	return el
+}
+
+func EventAuditDigest(seqNo uint64) *state.Event {
+	return &state.Event{
+		Type: &state.Event_AuditDigest{
+			AuditDigest: &state.EventAuditDigest{
+				SeqNo: seqNo,
+			},
+		},
+	}
+}
+
+func (el *EventList) ImportClientState(clientState *state.ClientState) *EventList {
+	el.PushBack(EventImportClientState(clientState))
+	return el
+}
+
+func EventImportClientState(clientState *state.ClientState) *state.Event {
+	return &state.Event{
+		Type: &state.Event_ImportClientState{
+			ImportClientState: &state.EventImportClientState{
+				ClientState: clientState,
+			},
+		},
+	}
+}
+
+func (el *EventList) ResetMisbehavior(nodeID uint64) *EventList {
+	el.PushBack(EventResetMisbehavior(nodeID))
+	return el
+}
+
+func EventResetMisbehavior(nodeID uint64) *state.Event {
+	return &state.Event{
+		Type: &state.Event_ResetMisbehavior{
+			ResetMisbehavior: &state.EventResetMisbehavior{
+				NodeId: nodeID,
+			},
+		},
+	}
+}
+
+func (el *EventList) RequestCheckpoint() *EventList {
+	el.PushBack(EventRequestCheckpoint())
+	return el
+}
+
+func EventRequestCheckpoint() *state.Event {
+	return &state.Event{
+		Type: &state.Event_RequestCheckpoint{
+			RequestCheckpoint: &state.EventRequestCheckpoint{},
+		},
+	}
+}
+
 type EventListIterator struct {
 	currentElement *list.Element
 }
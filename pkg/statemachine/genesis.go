@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statemachine
+
+import (
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+
+	"github.com/pkg/errors"
+)
+
+// GenesisLog returns the write-ahead log entries with which every node of a
+// brand-new network is bootstrapped, before its first initialization.
+// A new network has no prior epoch to derive its first epoch from, so the
+// operator provides the genesis epoch config, epoch 0, whose leaders lead
+// the first epoch, along with the initial network state and the checkpoint
+// value of that state.  All three must be identical across nodes.  The
+// genesis epoch config is included in the first epoch change of each node,
+// and nodes ignore the epoch changes of nodes bootstrapped with a different one.
+func GenesisLog(networkState *msgs.NetworkState, checkpointValue []byte, genesis *msgs.EpochConfig) ([]*msgs.Persistent, error) {
+	if err := validateGenesis(networkState.Config, genesis); err != nil {
+		return nil, err
+	}
+
+	return []*msgs.Persistent{
+		{
+			Type: &msgs.Persistent_CEntry{
+				CEntry: &msgs.CEntry{
+					SeqNo:           0,
+					CheckpointValue: checkpointValue,
+					NetworkState:    networkState,
+				},
+			},
+		},
+		{
+			Type: &msgs.Persistent_FEntry{
+				FEntry: &msgs.FEntry{
+					EndsEpochConfig: genesis,
+				},
+			},
+		},
+	}, nil
+}
+
+func validateGenesis(nc *msgs.NetworkState_Config, genesis *msgs.EpochConfig) error {
+	if len(nc.Nodes) == 0 {
+		return errors.Errorf("network configuration must have at least one node")
+	}
+
+	if err := faultToleranceError(nc); err != nil {
+		return err
+	}
+
+	if err := bucketLimitError(nc); err != nil {
+		return err
+	}
+
+	if err := learnerError(nc); err != nil {
+		return err
+	}
+
+	if genesis.Number != 0 {
+		return errors.Errorf("genesis epoch config must be for epoch 0, not epoch %d", genesis.Number)
+	}
+
+	if len(genesis.Leaders) == 0 {
+		return errors.Errorf("genesis epoch config must have at least one leader")
+	}
+
+	nodes := map[uint64]struct{}{}
+	for _, id := range nc.Nodes {
+		nodes[id] = struct{}{}
+	}
+
+	leaders := map[uint64]struct{}{}
+	for _, leader := range genesis.Leaders {
+		if _, ok := nodes[leader]; !ok {
+			return errors.Errorf("genesis epoch config leader %d is not a node of the network", leader)
+		}
+		if _, ok := leaders[leader]; ok {
+			return errors.Errorf("genesis epoch config lists leader %d more than once", leader)
+		}
+		leaders[leader] = struct{}{}
+	}
+
+	return nil
+}
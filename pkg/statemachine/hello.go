@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statemachine
+
+import (
+	"bytes"
+
+	"github.com/hyperledger-labs/mirbft/pkg/logger"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+
+	"github.com/pkg/errors"
+)
+
+// ErrConfigMismatch is the reason reported by a ConfigMismatch action, when a
+// peer announces a network configuration differing from this node's.
+var ErrConfigMismatch = errors.New("network configuration differs from that of a peer")
+
+// hello announces the configuration of this node's stable checkpoint to the
+// other nodes of the network.  It is sent on every reinitialization, and on
+// every new stable checkpoint, so that a peer eventually receives it for a
+// checkpoint which is stable for the peer as well.
+func (sm *StateMachine) hello() *ActionList {
+	var targets []uint64
+	for _, id := range sm.commitState.activeState.Config.Nodes {
+		if id != sm.myConfig.Id {
+			targets = append(targets, id)
+		}
+	}
+
+	if len(targets) == 0 {
+		return &ActionList{}
+	}
+
+	return (&ActionList{}).Send(
+		targets,
+		&msgs.Msg{
+			Type: &msgs.Msg_Hello{
+				Hello: &msgs.Hello{
+					SeqNo:        sm.commitState.lowWatermark,
+					ConfigDigest: ConfigDigest(sm.commitState.activeState.Config),
+				},
+			},
+		},
+	)
+}
+
+// applyHello compares the configuration a peer announced with this node's,
+// reporting a ConfigMismatch if they differ.  The messages of a mismatched
+// peer are ignored until it announces a matching configuration, as it would
+// otherwise count towards quorums of a network it is not part of.
+// Announcements for checkpoints other than this node's stable checkpoint
+// cannot be compared, and are ignored: whichever of the two nodes lags
+// behind announces its configuration again once it reaches the checkpoint of
+// the other, or passes it via state transfer, and the two are compared then.
+func (sm *StateMachine) applyHello(source nodeID, hello *msgs.Hello) *ActionList {
+	if hello.SeqNo != sm.commitState.lowWatermark {
+		sm.Logger.Log(logger.LevelDebug, "ignoring hello for a different checkpoint", "source", source, "seq_no", hello.SeqNo, "low_watermark", sm.commitState.lowWatermark)
+		return &ActionList{}
+	}
+
+	digest := ConfigDigest(sm.commitState.activeState.Config)
+	if bytes.Equal(digest, hello.ConfigDigest) {
+		delete(sm.mismatchedPeers, source)
+		return &ActionList{}
+	}
+
+	sm.mismatchedPeers[source] = struct{}{}
+
+	err := errors.WithMessagef(ErrConfigMismatch, "node %d has config digest %x at seq_no=%d, but ours is %x", source, hello.ConfigDigest, hello.SeqNo, digest)
+	sm.Logger.Log(logger.LevelError, "peer started from a different network configuration", "source", source, "err", err)
+
+	return (&ActionList{}).ConfigMismatch(uint64(source), hello.SeqNo, err.Error())
+}
@@ -176,6 +176,14 @@ func (mb *msgBuffer) remove(e *list.Element) *msgs.Msg {
 	return msg
 }
 
+// clear removes every message from the buffer, once the component
+// owning it no longer has any use for them.
+func (mb *msgBuffer) clear() {
+	for e := mb.buffer.Front(); e != nil; e = mb.buffer.Front() {
+		mb.remove(e)
+	}
+}
+
 func (mb *msgBuffer) next(filter func(source nodeID, msg *msgs.Msg) applyable) *msgs.Msg {
 	e := mb.buffer.Front()
 	if e == nil {
@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statemachine
+
+import (
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+)
+
+// msgCounts counts the messages a node has received and sent by type, so
+// that operators may spot anomalies such as a flood of epoch changes.
+// Types are named after the field of the msgs.Msg oneof, e.g. "preprepare"
+// or "epoch_change".  Alongside, it counts the misbehaviors flagged for each
+// node.  Counts are cumulative, and survive reinitialization.
+type msgCounts struct {
+	received     map[string]uint64
+	sent         map[string]uint64
+	misbehaviors map[uint64]int
+}
+
+func newMsgCounts() *msgCounts {
+	return &msgCounts{
+		received:     map[string]uint64{},
+		sent:         map[string]uint64{},
+		misbehaviors: map[uint64]int{},
+	}
+}
+
+func msgType(msg *msgs.Msg) string {
+	m := msg.ProtoReflect()
+	fd := m.WhichOneof(m.Descriptor().Oneofs().ByName("type"))
+	if fd == nil {
+		return "unknown"
+	}
+	return string(fd.Name())
+}
+
+func (mc *msgCounts) countReceived(msg *msgs.Msg) {
+	mc.received[msgType(msg)]++
+}
+
+// countSent counts the messages of each send in actions, once per target.
+func (mc *msgCounts) countSent(actions *ActionList) {
+	iter := actions.Iterator()
+	for action := iter.Next(); action != nil; action = iter.Next() {
+		send, ok := action.Type.(*state.Action_Send)
+		if !ok {
+			continue
+		}
+
+		mc.sent[msgType(send.Send.Msg)] += uint64(len(send.Send.Targets))
+	}
+}
+
+// countMisbehaviors counts the Misbehavior actions in actions, by node.
+func (mc *msgCounts) countMisbehaviors(actions *ActionList) {
+	iter := actions.Iterator()
+	for action := iter.Next(); action != nil; action = iter.Next() {
+		misbehavior, ok := action.Type.(*state.Action_Misbehavior)
+		if !ok {
+			continue
+		}
+
+		mc.misbehaviors[misbehavior.Misbehavior.NodeId]++
+	}
+}
+
+func (mc *msgCounts) status() (received, sent map[string]uint64) {
+	received = make(map[string]uint64, len(mc.received))
+	for msgType, count := range mc.received {
+		received[msgType] = count
+	}
+
+	sent = make(map[string]uint64, len(mc.sent))
+	for msgType, count := range mc.sent {
+		sent[msgType] = count
+	}
+
+	return received, sent
+}
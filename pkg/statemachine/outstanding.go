@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package statemachine
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/hyperledger-labs/mirbft/pkg/logger"
 
@@ -27,12 +28,18 @@ func ackToKey(ack *msgs.RequestAck) ackKey {
 	}
 }
 
+type reqKey struct {
+	clientID uint64
+	reqNo    uint64
+}
+
 func newOutstandingReqs(clientTracker *clientTracker, networkState *msgs.NetworkState, l logger.Logger) *allOutstandingReqs {
 	clientTracker.availableList.resetIterator()
 
 	ao := &allOutstandingReqs{
 		buckets:             map[bucketID]*bucketOutstandingReqs{},
 		correctRequests:     map[ackKey]*msgs.RequestAck{},
+		correctDigests:      map[reqKey][]byte{},
 		outstandingRequests: map[ackKey]*sequence{},
 		availableIterator:   clientTracker.availableList,
 	}
@@ -77,6 +84,10 @@ type allOutstandingReqs struct {
 	availableIterator   *availableList
 	correctRequests     map[ackKey]*msgs.RequestAck
 	outstandingRequests map[ackKey]*sequence
+
+	// correctDigests holds the digest of the request data stored locally
+	// for each of the correctRequests, see applyAcks.
+	correctDigests map[reqKey][]byte
 }
 
 type bucketOutstandingReqs struct {
@@ -112,6 +123,9 @@ func (ao *allOutstandingReqs) advanceRequests() *ActionList {
 		}
 
 		ao.correctRequests[key] = ack
+		if len(ack.Digest) > 0 {
+			ao.correctDigests[reqKey{clientID: ack.ClientId, reqNo: ack.ReqNo}] = ack.Digest
+		}
 	}
 
 	return actions
@@ -122,23 +136,21 @@ func (ao *allOutstandingReqs) applyAcks(bucket bucketID, seq *sequence, batch []
 	bo, ok := ao.buckets[bucket]
 	assertTruef(ok, "told to apply acks for bucket %d which does not exist", bucket)
 
+	if err := ao.validateAcks(bucket, bo, batch); err != nil {
+		return nil, err
+	}
+
 	outstandingReqs := map[ackKey]struct{}{}
 
 	for _, req := range batch {
-		co, ok := bo.clients[req.ClientId]
-		if !ok {
-			return nil, fmt.Errorf("no such client")
-		}
-
-		if co.nextReqNo != req.ReqNo {
-			return nil, fmt.Errorf("expected ClientId=%d next request for Bucket=%d to have ReqNo=%d but got ReqNo=%d", req.ClientId, bucket, co.nextReqNo, req.ReqNo)
-		}
+		co := bo.clients[req.ClientId]
 
 		// TODO, return an error if the request proposed is for a seqno before this request is valid
 
 		key := ackToKey(req)
 		if _, ok := ao.correctRequests[key]; ok {
 			delete(ao.correctRequests, key)
+			delete(ao.correctDigests, reqKey{clientID: req.ClientId, reqNo: req.ReqNo})
 		} else {
 			ao.outstandingRequests[key] = seq
 			outstandingReqs[key] = struct{}{}
@@ -150,3 +162,39 @@ func (ao *allOutstandingReqs) applyAcks(bucket bucketID, seq *sequence, batch []
 
 	return seq.allocate(batch, outstandingReqs), nil
 }
+
+// validateAcks checks a proposed batch without modifying any state.  Besides
+// the requests following in order for each client, the digest claimed for a
+// request must match the digest of the request data stored locally, if any.
+// A null request may always be proposed in place of a stored one.
+func (ao *allOutstandingReqs) validateAcks(bucket bucketID, bo *bucketOutstandingReqs, batch []*msgs.RequestAck) error {
+	nextReqNos := map[uint64]uint64{}
+	for _, req := range batch {
+		co, ok := bo.clients[req.ClientId]
+		if !ok {
+			return fmt.Errorf("no such client")
+		}
+
+		nextReqNo, ok := nextReqNos[req.ClientId]
+		if !ok {
+			nextReqNo = co.nextReqNo
+		}
+
+		if nextReqNo != req.ReqNo {
+			return fmt.Errorf("expected ClientId=%d next request for Bucket=%d to have ReqNo=%d but got ReqNo=%d", req.ClientId, bucket, nextReqNo, req.ReqNo)
+		}
+
+		nextReqNo += co.numBuckets
+		for isCommitted(nextReqNo, co.client) {
+			nextReqNo += co.numBuckets
+		}
+		nextReqNos[req.ClientId] = nextReqNo
+
+		storedDigest, ok := ao.correctDigests[reqKey{clientID: req.ClientId, reqNo: req.ReqNo}]
+		if ok && len(req.Digest) > 0 && !bytes.Equal(storedDigest, req.Digest) {
+			return fmt.Errorf("digest %x claimed for ClientId=%d ReqNo=%d does not match the digest %x of the stored request data", req.Digest, req.ClientId, req.ReqNo, storedDigest)
+		}
+	}
+
+	return nil
+}
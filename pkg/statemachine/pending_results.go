@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statemachine
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+)
+
+// pendingResults tracks the hash and checkpoint actions this node has
+// emitted, but whose results the consumer has yet to return.  A result which
+// does not correspond to any of them was fabricated, by a buggy or malicious
+// consumer, and must not be acted upon.  Hash actions are identified by
+// their origin, and checkpoint actions by their sequence number.
+type pendingResults struct {
+	hashes      map[string]int
+	checkpoints map[uint64]int
+}
+
+func newPendingResults() *pendingResults {
+	return &pendingResults{
+		hashes:      map[string]int{},
+		checkpoints: map[uint64]int{},
+	}
+}
+
+func hashOriginKey(origin *state.HashOrigin) string {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(origin)
+	assertEqualf(err, nil, "could not marshal hash origin: %s", err)
+	return string(data)
+}
+
+// track records the hash and checkpoint actions in actions as pending.
+func (pr *pendingResults) track(actions *ActionList) {
+	iter := actions.Iterator()
+	for action := iter.Next(); action != nil; action = iter.Next() {
+		switch t := action.Type.(type) {
+		case *state.Action_Hash:
+			pr.hashes[hashOriginKey(t.Hash.Origin)]++
+		case *state.Action_Checkpoint:
+			pr.checkpoints[t.Checkpoint.SeqNo]++
+		}
+	}
+}
+
+// acceptHash returns whether a hash action with the given origin is pending,
+// in which case it is no longer.
+func (pr *pendingResults) acceptHash(origin *state.HashOrigin) bool {
+	key := hashOriginKey(origin)
+	if pr.hashes[key] == 0 {
+		return false
+	}
+
+	pr.hashes[key]--
+	if pr.hashes[key] == 0 {
+		delete(pr.hashes, key)
+	}
+	return true
+}
+
+// acceptCheckpoint returns whether a checkpoint action for seqNo is pending,
+// in which case it is no longer.
+func (pr *pendingResults) acceptCheckpoint(seqNo uint64) bool {
+	if pr.checkpoints[seqNo] == 0 {
+		return false
+	}
+
+	pr.checkpoints[seqNo]--
+	if pr.checkpoints[seqNo] == 0 {
+		delete(pr.checkpoints, seqNo)
+	}
+	return true
+}
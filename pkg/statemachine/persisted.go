@@ -22,6 +22,7 @@ type logIterator struct {
 	onECEntry  func(*msgs.ECEntry)
 	onTEntry   func(*msgs.TEntry)
 	onSuspect  func(*msgs.Suspect)
+	onEAEntry  func(*msgs.EAEntry)
 	shouldExit func() bool
 	// TODO, suspect_ready
 }
@@ -37,6 +38,10 @@ type persisted struct {
 	logHead   *logEntry
 	logTail   *logEntry
 
+	// compactedIndex is the index to which the write-ahead log is known to be
+	// truncated, see compact.
+	compactedIndex uint64
+
 	logger logger.Logger
 }
 
@@ -52,6 +57,7 @@ func newPersisted(logger logger.Logger) *persisted {
 func (p *persisted) appendInitialLoad(index uint64, data *msgs.Persistent) {
 	if p.logHead == nil {
 		p.nextIndex = index
+		p.compactedIndex = index
 		p.logHead = &logEntry{
 			index: index,
 			entry: data,
@@ -115,6 +121,16 @@ func (p *persisted) addNEntry(nEntry *msgs.NEntry) *ActionList {
 	return p.appendLogEntry(d)
 }
 
+func (p *persisted) addFEntry(fEntry *msgs.FEntry) *ActionList {
+	d := &msgs.Persistent{
+		Type: &msgs.Persistent_FEntry{
+			FEntry: fEntry,
+		},
+	}
+
+	return p.appendLogEntry(d)
+}
+
 func (p *persisted) addCEntry(cEntry *msgs.CEntry) *ActionList {
 	assertNotEqual(cEntry.NetworkState, nil, "network config must be set")
 
@@ -147,6 +163,16 @@ func (p *persisted) addECEntry(ecEntry *msgs.ECEntry) *ActionList {
 	return p.appendLogEntry(d)
 }
 
+func (p *persisted) addEAEntry(eaEntry *msgs.EAEntry) *ActionList {
+	d := &msgs.Persistent{
+		Type: &msgs.Persistent_EAEntry{
+			EAEntry: eaEntry,
+		},
+	}
+
+	return p.appendLogEntry(d)
+}
+
 func (p *persisted) addTEntry(tEntry *msgs.TEntry) *ActionList {
 	d := &msgs.Persistent{
 		Type: &msgs.Persistent_TEntry{
@@ -185,6 +211,18 @@ func (p *persisted) truncate(lowWatermark uint64) *ActionList {
 	return &ActionList{}
 }
 
+// compact produces a Truncate action for the head of the log, if the log has
+// been truncated in memory beyond the index last compacted to.
+func (p *persisted) compact() *ActionList {
+	if p.logHead.index <= p.compactedIndex {
+		return &ActionList{}
+	}
+
+	p.logger.Log(logger.LevelDebug, "compacting WAL", "index", p.logHead.index)
+	p.compactedIndex = p.logHead.index
+	return (&ActionList{}).Truncate(p.logHead.index)
+}
+
 // staticcheck hack
 var _ = (&persisted{}).logEntries
 
@@ -232,6 +270,10 @@ func (p *persisted) iterate(li logIterator) {
 			if li.onSuspect != nil {
 				li.onSuspect(d.Suspect)
 			}
+		case *msgs.Persistent_EAEntry:
+			if li.onEAEntry != nil {
+				li.onEAEntry(d.EAEntry)
+			}
 			// TODO, suspect_ready
 		default:
 			panic(fmt.Sprintf("unsupported log entry type '%T'", logEntry.entry.Type))
@@ -298,6 +340,9 @@ func (p *persisted) constructEpochChange(newEpoch uint64) *msgs.EpochChange {
 		},
 		onFEntry: func(fEntry *msgs.FEntry) {
 			logEpoch = &fEntry.EndsEpochConfig.Number
+			if fEntry.EndsEpochConfig.Number == 0 && newEpoch == 1 {
+				newEpochChange.Genesis = fEntry.EndsEpochConfig
+			}
 		},
 		onCEntry: func(cEntry *msgs.CEntry) {
 			newEpochChange.Checkpoints = append(newEpochChange.Checkpoints, &msgs.Checkpoint{
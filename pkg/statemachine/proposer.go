@@ -10,9 +10,66 @@ import (
 	"container/list"
 	"encoding/binary"
 
+	"github.com/hyperledger-labs/mirbft/pkg/logger"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
 	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
 )
 
+// BatchOrderer may reorder the requests of a batch before this node proposes it,
+// for instance to group related requests for locality.  It must deterministically
+// return a permutation of its input which preserves the relative order of requests
+// from the same client.  Results violating this are discarded in favor of the original order.
+type BatchOrderer func(pending []*msgs.RequestAck) []*msgs.RequestAck
+
+// BatchValidator may veto a batch this node is about to propose, for instance
+// because its requests conflict with application invariants, by returning an
+// error.  The requests of a vetoed batch are returned, in order, to the front
+// of the bucket's queue to be batched again later, so a validator should not
+// veto the same requests indefinitely.  It is only ever invoked for batches this
+// node proposes as a leader, followers cannot veto batches without breaking safety.
+type BatchValidator func(batch []*msgs.RequestAck) error
+
+// SequenceAllocatedFunc is invoked with sequence seqNo once the active epoch
+// allocates it to a batch, along with the bucket of the sequence and the
+// leader of the bucket which cut the batch, whether that is this node or
+// another whose preprepare this node accepted.  Allocations are reported in
+// order within each bucket, so a gap in the sequences reported for a bucket
+// indicates a batch which was never cut.
+type SequenceAllocatedFunc func(seqNo uint64, bucket uint32, leader uint64)
+
+// LeaderSelector chooses the leaders this node proposes for epoch newEpoch,
+// should it be the primary of that epoch, from nodes.  suspicions counts, for
+// each node, the epochs it led which ended because they were suspected.  It
+// must deterministically return a non-empty subset of nodes, see
+// DefaultLeaderSelector.
+type LeaderSelector func(newEpoch uint64, nodes []uint64, suspicions map[uint64]uint64) []uint64
+
+// DefaultLeaderSelector rotates through nodes, starting at the node at index
+// newEpoch, and returns those suspected the fewest times, so that nodes which
+// led suspected epochs are left out until every node has been suspected as often.
+func DefaultLeaderSelector(newEpoch uint64, nodes []uint64, suspicions map[uint64]uint64) []uint64 {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	fewest := suspicions[nodes[0]]
+	for _, id := range nodes[1:] {
+		if suspicions[id] < fewest {
+			fewest = suspicions[id]
+		}
+	}
+
+	leaders := make([]uint64, 0, len(nodes))
+	for i := range nodes {
+		id := nodes[(newEpoch+uint64(i))%uint64(len(nodes))]
+		if suspicions[id] == fewest {
+			leaders = append(leaders, id)
+		}
+	}
+
+	return leaders
+}
+
 func uint64ToBytes(value uint64) []byte {
 	byteValue := make([]byte, 8)
 	binary.BigEndian.PutUint64(byteValue, value)
@@ -31,6 +88,15 @@ type proposalBucket struct {
 	pending            []*clientRequest
 	bucketID           bucketID
 	checkpointInterval uint64
+	orderer            BatchOrderer
+	validator          BatchValidator
+	logger             logger.Logger
+
+	// adaptive is set when requestCount varies with the backlog of the
+	// bucket, between minRequestCount and maxRequestCount, see adapt.
+	adaptive        bool
+	minRequestCount uint32
+	maxRequestCount uint32
 
 	// currentCheckpoint is initially set to the base checkpoint value.  It is incremented by
 	// the caller when querying for available batches, as the caller supplies the current sequence
@@ -47,7 +113,7 @@ type proposalBucket struct {
 	nextReadyList *list.List
 }
 
-func newProposer(baseCheckpoint uint64, checkpointInterval uint64, myConfig *state.EventInitialParameters, clientTracker *clientTracker, buckets map[bucketID]nodeID) *proposer {
+func newProposer(baseCheckpoint uint64, checkpointInterval uint64, myConfig *state.EventInitialParameters, clientTracker *clientTracker, buckets map[bucketID]nodeID, orderer BatchOrderer, validator BatchValidator, logger logger.Logger) *proposer {
 	proposalBuckets := map[bucketID]*proposalBucket{}
 	for bucketID, id := range buckets {
 		if id != nodeID(myConfig.Id) {
@@ -57,11 +123,28 @@ func newProposer(baseCheckpoint uint64, checkpointInterval uint64, myConfig *sta
 			currentCheckpoint:  baseCheckpoint,
 			checkpointInterval: checkpointInterval,
 			bucketID:           bucketID,
+			orderer:            orderer,
+			validator:          validator,
+			logger:             logger,
 			readyList:          list.New(),
 			nextReadyList:      list.New(),
 			requestCount:       myConfig.BatchSize,
 			pending:            make([]*clientRequest, 0, 1), // TODO, might be interesting to play with not preallocating for performance reasons
 		}
+
+		if myConfig.AdaptiveBatching {
+			prb := proposalBuckets[bucketID]
+			prb.adaptive = true
+			prb.minRequestCount = myConfig.MinBatchSize
+			if prb.minRequestCount == 0 {
+				prb.minRequestCount = 1
+			}
+			prb.maxRequestCount = myConfig.MaxBatchSize
+			if prb.maxRequestCount < prb.minRequestCount {
+				prb.maxRequestCount = prb.minRequestCount
+			}
+			prb.requestCount = prb.minRequestCount
+		}
 	}
 
 	clientTracker.readyList.resetIterator()
@@ -155,7 +238,92 @@ func (prb *proposalBucket) hasPending(forSeqNo uint64) bool {
 }
 
 func (prb *proposalBucket) next() []*clientRequest {
-	result := prb.pending
+	cut := prb.pending
+	if prb.adaptive {
+		prb.adapt(len(cut))
+	}
 	prb.pending = make([]*clientRequest, 0, prb.requestCount)
+
+	result := cut
+	if prb.orderer != nil && len(result) >= 2 {
+		result = prb.order(result)
+	}
+
+	if prb.validator != nil && len(result) > 0 && !prb.validate(result) {
+		// Return the requests in their original order, so that the
+		// requests of each client are still proposed in order.
+		for i := len(cut) - 1; i >= 0; i-- {
+			prb.readyList.PushFront(cut[i])
+		}
+		return nil
+	}
+
+	return result
+}
+
+// validate applies the bucket's BatchValidator to the batch, returning
+// whether the batch may be proposed.
+func (prb *proposalBucket) validate(batch []*clientRequest) bool {
+	acks := make([]*msgs.RequestAck, len(batch))
+	for i, cr := range batch {
+		acks[i] = cr.ack
+	}
+
+	if err := prb.validator(acks); err != nil {
+		prb.logger.Log(logger.LevelInfo, "batch validator rejected batch, requeuing its requests", "bucket_id", prb.bucketID, "requests", len(batch), "err", err)
+		return false
+	}
+
+	return true
+}
+
+// adapt adjusts the batch size of an adaptive bucket after a batch of cut
+// requests is taken.  The size doubles while at least a full batch remains
+// ready, and halves when the batch was cut before filling, within the
+// configured bounds.
+func (prb *proposalBucket) adapt(cut int) {
+	switch {
+	case uint32(prb.readyList.Len()) >= prb.requestCount:
+		prb.requestCount *= 2
+		if prb.requestCount > prb.maxRequestCount {
+			prb.requestCount = prb.maxRequestCount
+		}
+	case uint32(cut) < prb.requestCount:
+		prb.requestCount /= 2
+		if prb.requestCount < prb.minRequestCount {
+			prb.requestCount = prb.minRequestCount
+		}
+	}
+}
+
+// order applies the bucket's BatchOrderer to the batch, returning the batch
+// unchanged if the orderer does not return a valid ordering.
+func (prb *proposalBucket) order(batch []*clientRequest) []*clientRequest {
+	acks := make([]*msgs.RequestAck, len(batch))
+	clientQueues := map[uint64][]*clientRequest{}
+	for i, cr := range batch {
+		acks[i] = cr.ack
+		clientQueues[cr.ack.ClientId] = append(clientQueues[cr.ack.ClientId], cr)
+	}
+
+	ordered := prb.orderer(acks)
+	if len(ordered) != len(batch) {
+		prb.logger.Log(logger.LevelError, "batch orderer changed the batch size, ignoring its ordering", "bucket_id", prb.bucketID, "expected", len(batch), "got", len(ordered))
+		return batch
+	}
+
+	// Each request must be the next one, in the original order, of its client.
+	// As the lengths match, this also ensures ordered is a permutation of the batch.
+	result := make([]*clientRequest, len(ordered))
+	for i, ack := range ordered {
+		queue := clientQueues[ack.ClientId]
+		if len(queue) == 0 || queue[0].ack != ack {
+			prb.logger.Log(logger.LevelError, "batch orderer did not preserve the request order of a client, ignoring its ordering", "bucket_id", prb.bucketID, "client_id", ack.ClientId, "req_no", ack.ReqNo)
+			return batch
+		}
+		clientQueues[ack.ClientId] = queue[1:]
+		result[i] = queue[0]
+	}
+
 	return result
 }
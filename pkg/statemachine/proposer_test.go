@@ -0,0 +1,273 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statemachine
+
+import (
+	"container/list"
+	"fmt"
+	"sort"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hyperledger-labs/mirbft/pkg/logger"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+)
+
+var _ = Describe("proposalBucket", func() {
+	var (
+		prb *proposalBucket
+	)
+
+	clientReq := func(clientID, reqNo uint64) *clientRequest {
+		return &clientRequest{
+			ack: &msgs.RequestAck{
+				ClientId: clientID,
+				ReqNo:    reqNo,
+				Digest:   []byte{byte(clientID), byte(reqNo)},
+			},
+		}
+	}
+
+	acksOf := func(batch []*clientRequest) []*msgs.RequestAck {
+		acks := make([]*msgs.RequestAck, len(batch))
+		for i, cr := range batch {
+			acks[i] = cr.ack
+		}
+		return acks
+	}
+
+	BeforeEach(func() {
+		prb = &proposalBucket{
+			requestCount: 4,
+			logger:       logger.ConsoleErrorLogger,
+			pending: []*clientRequest{
+				clientReq(2, 0),
+				clientReq(1, 0),
+				clientReq(2, 1),
+				clientReq(1, 1),
+			},
+		}
+	})
+
+	When("a batch orderer groups requests by client", func() {
+		BeforeEach(func() {
+			prb.orderer = func(pending []*msgs.RequestAck) []*msgs.RequestAck {
+				result := append([]*msgs.RequestAck{}, pending...)
+				sort.SliceStable(result, func(i, j int) bool {
+					return result[i].ClientId < result[j].ClientId
+				})
+				return result
+			}
+		})
+
+		It("cuts the batch in the grouped order", func() {
+			Expect(acksOf(prb.next())).To(Equal(acksOf([]*clientRequest{
+				clientReq(1, 0),
+				clientReq(1, 1),
+				clientReq(2, 0),
+				clientReq(2, 1),
+			})))
+			Expect(prb.pending).To(BeEmpty())
+		})
+	})
+
+	When("a batch orderer reorders the requests of a client", func() {
+		BeforeEach(func() {
+			prb.orderer = func(pending []*msgs.RequestAck) []*msgs.RequestAck {
+				result := make([]*msgs.RequestAck, len(pending))
+				for i, ack := range pending {
+					result[len(pending)-1-i] = ack
+				}
+				return result
+			}
+		})
+
+		It("ignores the ordering", func() {
+			Expect(acksOf(prb.next())).To(Equal(acksOf([]*clientRequest{
+				clientReq(2, 0),
+				clientReq(1, 0),
+				clientReq(2, 1),
+				clientReq(1, 1),
+			})))
+		})
+	})
+
+	When("a batch orderer drops a request", func() {
+		BeforeEach(func() {
+			prb.orderer = func(pending []*msgs.RequestAck) []*msgs.RequestAck {
+				return pending[1:]
+			}
+		})
+
+		It("ignores the ordering", func() {
+			Expect(prb.next()).To(HaveLen(4))
+		})
+	})
+
+	When("a batch validator vetoes a batch", func() {
+		var vetoes int
+
+		BeforeEach(func() {
+			vetoes = 1
+			prb.checkpointInterval = 100
+			prb.readyList = list.New()
+			prb.nextReadyList = list.New()
+			prb.readyList.PushBack(clientReq(2, 2))
+			prb.orderer = func(pending []*msgs.RequestAck) []*msgs.RequestAck {
+				result := append([]*msgs.RequestAck{}, pending...)
+				sort.SliceStable(result, func(i, j int) bool {
+					return result[i].ClientId < result[j].ClientId
+				})
+				return result
+			}
+			prb.validator = func(batch []*msgs.RequestAck) error {
+				if vetoes == 0 {
+					return nil
+				}
+				vetoes--
+				return fmt.Errorf("conflicting requests")
+			}
+		})
+
+		It("returns the requests to the queue in order to be batched again", func() {
+			Expect(prb.next()).To(BeNil())
+			Expect(prb.pending).To(BeEmpty())
+
+			Expect(prb.hasPending(0)).To(BeTrue())
+			Expect(acksOf(prb.next())).To(Equal(acksOf([]*clientRequest{
+				clientReq(1, 0),
+				clientReq(1, 1),
+				clientReq(2, 0),
+				clientReq(2, 1),
+			})))
+
+			Expect(prb.hasOutstanding(0)).To(BeTrue())
+			Expect(acksOf(prb.next())).To(Equal(acksOf([]*clientRequest{
+				clientReq(2, 2),
+			})))
+		})
+	})
+
+	When("batching is adaptive", func() {
+		BeforeEach(func() {
+			prb = &proposalBucket{
+				requestCount:       1,
+				adaptive:           true,
+				minRequestCount:    1,
+				maxRequestCount:    8,
+				checkpointInterval: 100,
+				logger:             logger.ConsoleErrorLogger,
+				readyList:          list.New(),
+				nextReadyList:      list.New(),
+			}
+		})
+
+		queue := func(count int) {
+			for i := 0; i < count; i++ {
+				prb.queueRequest(0, clientReq(0, uint64(prb.readyList.Len())))
+			}
+		}
+
+		It("grows the batch size while the backlog grows", func() {
+			queue(40)
+
+			sizes := []int{}
+			reqNos := []uint64{}
+			for prb.hasPending(0) {
+				batch := prb.next()
+				sizes = append(sizes, len(batch))
+				for _, cr := range batch {
+					reqNos = append(reqNos, cr.ack.ReqNo)
+				}
+			}
+
+			Expect(sizes).To(Equal([]int{1, 2, 4, 8, 8, 8, 8}))
+			Expect(sort.SliceIsSorted(reqNos, func(i, j int) bool { return reqNos[i] < reqNos[j] })).To(BeTrue())
+		})
+
+		It("shrinks the batch size once batches are cut before filling", func() {
+			queue(40)
+			for prb.hasPending(0) {
+				prb.next()
+			}
+			Expect(prb.requestCount).To(Equal(uint32(8)))
+
+			Expect(prb.hasOutstanding(0)).To(BeTrue())
+			Expect(prb.next()).To(HaveLen(1))
+			Expect(prb.requestCount).To(Equal(uint32(4)))
+		})
+	})
+
+	When("two leaders hold the same pending requests", func() {
+		newBucket := func() *proposalBucket {
+			prb := &proposalBucket{
+				requestCount:       1,
+				adaptive:           true,
+				minRequestCount:    1,
+				maxRequestCount:    8,
+				checkpointInterval: 10,
+				logger:             logger.ConsoleErrorLogger,
+				readyList:          list.New(),
+				nextReadyList:      list.New(),
+				orderer: func(pending []*msgs.RequestAck) []*msgs.RequestAck {
+					result := append([]*msgs.RequestAck{}, pending...)
+					sort.SliceStable(result, func(i, j int) bool {
+						return result[i].ClientId < result[j].ClientId
+					})
+					return result
+				},
+			}
+
+			// Requests of three clients, some of them only valid after the
+			// next checkpoint.
+			for reqNo := uint64(0); reqNo < 10; reqNo++ {
+				for clientID := uint64(3); clientID > 0; clientID-- {
+					validAfterSeqNo := uint64(0)
+					if reqNo >= 5 {
+						validAfterSeqNo = 10
+					}
+					prb.queueRequest(validAfterSeqNo, clientReq(clientID, reqNo))
+				}
+			}
+
+			return prb
+		}
+
+		// cut returns the encoded preprepares of the batches cut by prb,
+		// for sequences 1 through 20, and the number of requests they hold.
+		cut := func(prb *proposalBucket) ([][]byte, int) {
+			var result [][]byte
+			requests := 0
+			for seqNo := uint64(1); seqNo <= 20; seqNo++ {
+				if !prb.hasOutstanding(seqNo) {
+					continue
+				}
+
+				batch := acksOf(prb.next())
+				requests += len(batch)
+				data, err := proto.MarshalOptions{Deterministic: true}.Marshal(&msgs.Preprepare{
+					SeqNo: seqNo,
+					Batch: batch,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				result = append(result, data)
+			}
+			return result, requests
+		}
+
+		It("cuts byte-identical batches", func() {
+			batches, requests := cut(newBucket())
+			Expect(requests).To(Equal(30))
+
+			otherBatches, _ := cut(newBucket())
+			Expect(otherBatches).To(Equal(batches))
+		})
+	})
+})
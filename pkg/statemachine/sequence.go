@@ -8,8 +8,10 @@ package statemachine
 
 import (
 	"bytes"
-	"github.com/hyperledger-labs/mirbft/pkg/logger"
+	"fmt"
+	"sort"
 
+	"github.com/hyperledger-labs/mirbft/pkg/logger"
 	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
 	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
 )
@@ -55,6 +57,18 @@ type sequence struct {
 	// qEntry is unset until after state >= sequencePreprepared
 	qEntry *msgs.QEntry
 
+	// pEntry is unset until after state >= sequencePrepared
+	pEntry *msgs.PEntry
+
+	// inconsistent is set once the digests of qEntry and pEntry are found
+	// to disagree, see checkEntries.
+	inconsistent bool
+
+	// withheld is set while the sequence lies beyond the commit window, see
+	// activeEpoch.advance.  It may preprepare, but it neither prepares nor
+	// commits until it is released.
+	withheld bool
+
 	// clientRequests is set along with batch when sequence >= sequenceAllocated only
 	// if we are the owner who is proposing this batch
 	clientRequests []*clientRequest
@@ -73,24 +87,43 @@ type sequence struct {
 
 	prepares map[string]int
 	commits  map[string]int
+
+	// prepareSources and commitSources record, by digest, which nodes the
+	// prepares and commits counted towards the quorums came from, for audits.
+	prepareSources map[string][]nodeID
+	commitSources  map[string][]nodeID
 }
 
 func newSequence(owner nodeID, epoch, seqNo uint64, persisted *persisted, networkConfig *msgs.NetworkState_Config, myConfig *state.EventInitialParameters, logger logger.Logger) *sequence {
 	return &sequence{
-		owner:         owner,
-		seqNo:         seqNo,
-		epoch:         epoch,
-		myConfig:      myConfig,
-		logger:        logger,
-		networkConfig: networkConfig,
-		persisted:     persisted,
-		state:         sequenceUninitialized,
-		nodeChoices:   map[nodeID]*nodeSeqChoice{},
-		prepares:      map[string]int{},
-		commits:       map[string]int{},
+		owner:          owner,
+		seqNo:          seqNo,
+		epoch:          epoch,
+		myConfig:       myConfig,
+		logger:         logger,
+		networkConfig:  networkConfig,
+		persisted:      persisted,
+		state:          sequenceUninitialized,
+		nodeChoices:    map[nodeID]*nodeSeqChoice{},
+		prepares:       map[string]int{},
+		commits:        map[string]int{},
+		prepareSources: map[string][]nodeID{},
+		commitSources:  map[string][]nodeID{},
 	}
 }
 
+// sortedSources returns the ids of sources in ascending order.
+func sortedSources(sources []nodeID) []uint64 {
+	result := make([]uint64, len(sources))
+	for i, source := range sources {
+		result[i] = uint64(source)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i] < result[j]
+	})
+	return result
+}
+
 func (s *sequence) nodeChoice(source nodeID) *nodeSeqChoice {
 	choice, ok := s.nodeChoices[source]
 	if !ok {
@@ -153,13 +186,8 @@ func (s *sequence) allocate(requestAcks []*msgs.RequestAck, outstandingReqs map[
 		return s.applyBatchHashResult(nil)
 	}
 
-	data := make([][]byte, len(requestAcks))
-	for i, ack := range requestAcks {
-		data[i] = ack.Digest
-	}
-
 	actions := (&ActionList{}).Hash(
-		data,
+		batchHashData(requestAcks),
 		&state.HashOrigin{
 			Type: &state.HashOrigin_Batch_{
 				Batch: &state.HashOrigin_Batch{
@@ -201,6 +229,45 @@ func (s *sequence) applyBatchHashResult(digest []byte) *ActionList {
 	return s.applyPrepareMsg(s.owner, digest)
 }
 
+// forwardRequests forwards the requests of the batch to the nodes which have
+// not acknowledged them.  The requests missing at the same set of nodes are
+// forwarded together, so that a node lacking many requests of the batch
+// receives them in a single message.  The owner proposing the batch never
+// forwards requests to itself, even those it has not acknowledged.
+func (s *sequence) forwardRequests() *ActionList {
+	var targetSets [][]uint64
+	acks := map[string][]*msgs.RequestAck{}
+	for _, cr := range s.clientRequests {
+		nodes := []uint64{}
+		for _, id := range s.networkConfig.Nodes {
+			if id == s.myConfig.Id {
+				continue
+			}
+
+			if _, ok := cr.agreements[nodeID(id)]; !ok {
+				nodes = append(nodes, id)
+			}
+		}
+
+		if len(nodes) == 0 {
+			continue
+		}
+
+		key := fmt.Sprint(nodes)
+		if _, ok := acks[key]; !ok {
+			targetSets = append(targetSets, nodes)
+		}
+		acks[key] = append(acks[key], cr.ack)
+	}
+
+	actions := &ActionList{}
+	for _, nodes := range targetSets {
+		actions.ForwardRequest(nodes, acks[fmt.Sprint(nodes)]...)
+	}
+
+	return actions
+}
+
 func (s *sequence) prepare() *ActionList {
 	s.qEntry = &msgs.QEntry{
 		SeqNo:    s.seqNo,
@@ -213,17 +280,8 @@ func (s *sequence) prepare() *ActionList {
 	actions := s.persisted.addQEntry(s.qEntry)
 
 	if uint64(s.owner) == s.myConfig.Id {
-		for _, cr := range s.clientRequests {
-			nodes := []uint64{}
-			for _, id := range s.networkConfig.Nodes {
-				if _, ok := cr.agreements[nodeID(id)]; !ok {
-					nodes = append(nodes, id)
-				}
-			}
-			actions.ForwardRequest(
-				nodes,
-				cr.ack,
-			)
+		if !s.myConfig.DisableForwarding {
+			actions.concat(s.forwardRequests())
 		}
 		actions.Send(
 			s.networkConfig.Nodes,
@@ -255,6 +313,14 @@ func (s *sequence) prepare() *ActionList {
 	return actions
 }
 
+// release lets a withheld sequence prepare, and commit, once the commit
+// window covers it.  The prepares and commits it received while withheld
+// count towards its quorums.
+func (s *sequence) release() *ActionList {
+	s.withheld = false
+	return s.advanceState()
+}
+
 func (s *sequence) applyPrepareMsg(source nodeID, digest []byte) *ActionList {
 	choice := s.nodeChoice(source)
 
@@ -270,11 +336,18 @@ func (s *sequence) applyPrepareMsg(source nodeID, digest []byte) *ActionList {
 	choice.digest = digest
 
 	s.prepares[string(digest)] = s.prepares[string(digest)] + 1
+	s.prepareSources[string(digest)] = append(s.prepareSources[string(digest)], source)
 
 	return s.advanceState()
 }
 
 func (s *sequence) checkPrepareQuorum() *ActionList {
+	if s.withheld {
+		// No PEntry may be persisted beyond the commit window, so that
+		// an epoch change never selects a sequence which could not commit.
+		return &ActionList{}
+	}
+
 	agreements := s.prepares[string(s.digest)]
 
 	// Do not prepare unless we have sent our prepare as well
@@ -303,6 +376,7 @@ func (s *sequence) checkPrepareQuorum() *ActionList {
 		SeqNo:  s.seqNo,
 		Digest: s.digest,
 	}
+	s.pEntry = pEntry
 
 	return s.persisted.addPEntry(pEntry).Send(
 		s.networkConfig.Nodes,
@@ -325,18 +399,52 @@ func (s *sequence) applyCommitMsg(source nodeID, digest []byte) *ActionList {
 		return &ActionList{}
 	}
 
-	choice.state = nodeSeqPrepared
+	if choice.state == nodeSeqUninitialized && source != s.owner {
+		// The commit arrived before the prepare from the same node.  (We skip the
+		// owner, whose prepare is only recorded once we have hashed its preprepare.)
+		if s.myConfig.StrictOrdering {
+			s.logger.Log(logger.LevelWarn, "transport violation, received commit before prepare", "source", source, "seq_no", s.seqNo, "epoch", s.epoch)
+			return &ActionList{}
+		}
 
-	if choice.state == nodeSeqUninitialized {
-		// We also count a commit as an implicit prepare if we have not gotten one
-		s.prepares[string(digest)] = s.prepares[string(digest)]
+		// Sending a commit implies having sent a prepare for the same digest,
+		// so we count the commit as an implicit prepare, as if it had arrived first.
+		choice.digest = digest
+		s.prepares[string(digest)] = s.prepares[string(digest)] + 1
+		s.prepareSources[string(digest)] = append(s.prepareSources[string(digest)], source)
 	}
 
+	choice.state = nodeSeqPrepared
+
 	s.commits[string(digest)] = s.commits[string(digest)] + 1
+	s.commitSources[string(digest)] = append(s.commitSources[string(digest)], source)
 
 	return s.advanceState()
 }
 
+// peerCommits returns the number of nodes other than this one which sent a
+// commit for digest.
+func (s *sequence) peerCommits(digest []byte) int {
+	count := 0
+	for _, source := range s.commitSources[string(digest)] {
+		if source != nodeID(s.myConfig.Id) {
+			count++
+		}
+	}
+	return count
+}
+
+// checkEntries returns a description of the disagreement if the QEntry and
+// PEntry of a committed sequence reference different digests, which can only
+// result from a bug or a corrupted log, so the sequence must not be delivered.
+func (s *sequence) checkEntries() (string, bool) {
+	if bytes.Equal(s.qEntry.Digest, s.pEntry.Digest) {
+		return "", true
+	}
+
+	return fmt.Sprintf("seq_no=%d has a QEntry with digest %x but a PEntry with digest %x", s.seqNo, s.qEntry.Digest, s.pEntry.Digest), false
+}
+
 func (s *sequence) checkCommitQuorum() {
 	agreements := s.commits[string(s.digest)]
 	// Do not commit unless we have sent a commit
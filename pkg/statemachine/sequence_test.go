@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/hyperledger-labs/mirbft/pkg/logger"
 	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
 	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
 )
@@ -28,12 +29,14 @@ var _ = XDescribe("sequence", func() {
 				Nodes: []uint64{0, 1, 2, 3},
 				F:     1,
 			},
-			epoch:       4,
-			seqNo:       5,
-			owner:       0,
-			nodeChoices: map[nodeID]*nodeSeqChoice{},
-			prepares:    map[string]int{},
-			commits:     map[string]int{},
+			epoch:          4,
+			seqNo:          5,
+			owner:          0,
+			nodeChoices:    map[nodeID]*nodeSeqChoice{},
+			prepares:       map[string]int{},
+			commits:        map[string]int{},
+			prepareSources: map[string][]nodeID{},
+			commitSources:  map[string][]nodeID{},
 		}
 	})
 
@@ -263,3 +266,232 @@ var _ = XDescribe("sequence", func() {
 		})
 	})
 })
+
+type countingLogger struct {
+	counts map[logger.LogLevel]int
+}
+
+func (cl *countingLogger) Log(level logger.LogLevel, text string, args ...interface{}) {
+	cl.counts[level]++
+}
+
+var _ = Describe("sequence message ordering", func() {
+	var (
+		s   *sequence
+		log *countingLogger
+	)
+
+	BeforeEach(func() {
+		log = &countingLogger{counts: map[logger.LogLevel]int{}}
+		s = newSequence(
+			0,
+			4,
+			5,
+			nil,
+			&msgs.NetworkState_Config{
+				Nodes: []uint64{0, 1, 2, 3},
+				F:     1,
+			},
+			&state.EventInitialParameters{
+				Id: 1,
+			},
+			log,
+		)
+		s.state = sequencePreprepared
+		s.digest = []byte("digest")
+	})
+
+	When("ordering is lenient", func() {
+		It("applies a commit arriving before its prepare as if the prepare came first", func() {
+			s.applyCommitMsg(2, []byte("digest"))
+			Expect(s.prepares["digest"]).To(Equal(1))
+			Expect(s.commits["digest"]).To(Equal(1))
+			Expect(s.nodeChoice(2).state).To(Equal(nodeSeqPrepared))
+
+			s.applyPrepareMsg(2, []byte("digest"))
+			Expect(s.prepares["digest"]).To(Equal(1))
+			Expect(s.commits["digest"]).To(Equal(1))
+			Expect(log.counts[logger.LevelWarn]).To(Equal(0))
+		})
+	})
+
+	When("ordering is strict", func() {
+		BeforeEach(func() {
+			s.myConfig.StrictOrdering = true
+		})
+
+		It("flags a commit arriving before its prepare as a transport violation", func() {
+			s.applyCommitMsg(2, []byte("digest"))
+			Expect(log.counts[logger.LevelWarn]).To(Equal(1))
+			Expect(s.prepares["digest"]).To(Equal(0))
+			Expect(s.commits["digest"]).To(Equal(0))
+
+			s.applyPrepareMsg(2, []byte("digest"))
+			s.applyCommitMsg(2, []byte("digest"))
+			Expect(log.counts[logger.LevelWarn]).To(Equal(1))
+			Expect(s.prepares["digest"]).To(Equal(1))
+			Expect(s.commits["digest"]).To(Equal(1))
+		})
+	})
+})
+
+var _ = Describe("sequence action ordering", func() {
+	var (
+		s         *sequence
+		persisted *persisted
+	)
+
+	networkConfig := &msgs.NetworkState_Config{
+		Nodes: []uint64{0, 1, 2, 3},
+		F:     1,
+	}
+
+	BeforeEach(func() {
+		persisted = newPersisted(logger.ConsoleErrorLogger)
+		persisted.appendInitialLoad(1, &msgs.Persistent{
+			Type: &msgs.Persistent_CEntry{
+				CEntry: &msgs.CEntry{
+					NetworkState: &msgs.NetworkState{
+						Config: networkConfig,
+					},
+				},
+			},
+		})
+
+		s = newSequence(
+			0,
+			4,
+			5,
+			persisted,
+			networkConfig,
+			&state.EventInitialParameters{
+				Id: 1,
+			},
+			logger.ConsoleErrorLogger,
+		)
+		s.state = sequenceReady
+		s.digest = []byte("digest")
+		s.batch = []*msgs.RequestAck{}
+	})
+
+	It("persists the QEntry before sending the prepare", func() {
+		Expect(s.prepare()).To(Equal((&ActionList{}).Persist(
+			2,
+			&msgs.Persistent{
+				Type: &msgs.Persistent_QEntry{
+					QEntry: &msgs.QEntry{
+						SeqNo:    5,
+						Digest:   []byte("digest"),
+						Requests: []*msgs.RequestAck{},
+					},
+				},
+			},
+		).Send(
+			[]uint64{0, 1, 2, 3},
+			&msgs.Msg{
+				Type: &msgs.Msg_Prepare{
+					Prepare: &msgs.Prepare{
+						SeqNo:  5,
+						Epoch:  4,
+						Digest: []byte("digest"),
+					},
+				},
+			},
+		)))
+	})
+
+	It("persists the PEntry before sending the commit", func() {
+		s.prepare()
+		s.applyPrepareMsg(1, []byte("digest"))
+		s.applyPrepareMsg(0, []byte("digest"))
+
+		Expect(s.applyPrepareMsg(2, []byte("digest"))).To(Equal((&ActionList{}).Persist(
+			3,
+			&msgs.Persistent{
+				Type: &msgs.Persistent_PEntry{
+					PEntry: &msgs.PEntry{
+						SeqNo:  5,
+						Digest: []byte("digest"),
+					},
+				},
+			},
+		).Send(
+			[]uint64{0, 1, 2, 3},
+			&msgs.Msg{
+				Type: &msgs.Msg_Commit{
+					Commit: &msgs.Commit{
+						SeqNo:  5,
+						Epoch:  4,
+						Digest: []byte("digest"),
+					},
+				},
+			},
+		)))
+	})
+})
+
+var _ = Describe("sequence of a four node network", func() {
+	var s *sequence
+
+	BeforeEach(func() {
+		s = newSequence(
+			0,
+			4,
+			5,
+			nil,
+			&msgs.NetworkState_Config{
+				Nodes: []uint64{0, 1, 2, 3},
+				F:     1,
+			},
+			&state.EventInitialParameters{
+				Id: 1,
+			},
+			&countingLogger{counts: map[logger.LogLevel]int{}},
+		)
+	})
+
+	Describe("quorum sources", func() {
+		BeforeEach(func() {
+			s.state = sequencePreprepared
+			s.digest = []byte("digest")
+		})
+
+		It("records which nodes each prepare and commit came from", func() {
+			s.applyPrepareMsg(3, []byte("digest"))
+			s.applyPrepareMsg(0, []byte("digest"))
+			s.applyPrepareMsg(2, []byte("other-digest"))
+			s.applyPrepareMsg(3, []byte("digest"))
+			s.applyCommitMsg(3, []byte("digest"))
+
+			Expect(sortedSources(s.prepareSources["digest"])).To(Equal([]uint64{0, 3}))
+			Expect(sortedSources(s.prepareSources["other-digest"])).To(Equal([]uint64{2}))
+			Expect(sortedSources(s.commitSources["digest"])).To(Equal([]uint64{3}))
+		})
+	})
+
+	Describe("request forwarding", func() {
+		BeforeEach(func() {
+			// This node proposes the sequence.
+			s.owner = 1
+		})
+
+		It("never forwards the requests of its own proposal to itself", func() {
+			ack := &msgs.RequestAck{
+				ClientId: 9,
+				ReqNo:    7,
+				Digest:   []byte("digest"),
+			}
+			s.clientRequests = []*clientRequest{
+				{
+					ack: ack,
+					agreements: map[nodeID]struct{}{
+						0: {},
+						2: {},
+					},
+				},
+			}
+
+			Expect(s.forwardRequests()).To(Equal((&ActionList{}).ForwardRequest([]uint64{3}, ack)))
+		})
+	})
+})
@@ -0,0 +1,318 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statemachine
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+)
+
+// simulation drives the nodes of a testNetwork in lockstep over a virtual
+// clock, through a network model whose latencies and losses are drawn from
+// a seeded source of randomness.  Each step elapses one tick on every node,
+// then delivers the messages due by the new time, in the order they were
+// sent.  Hash and checkpoint requests are answered immediately, as by the
+// testNetwork.  Runs with the same seed therefore produce the same trace.
+type simulation struct {
+	*testNetwork
+
+	rand *rand.Rand
+
+	// maxLatency is the largest number of ticks a message spends in
+	// transit, each taking at least one.  Messages a node sends to
+	// itself are delivered within the tick.
+	maxLatency int
+
+	// loss is the probability with which each transmission of a message is
+	// lost.  As the protocol assumes links which eventually deliver, a lost
+	// message is retransmitted after a further maxLatency ticks.
+	loss float64
+
+	// groups, if set, partitions the network, see partition.  Messages
+	// between partitions are dropped, and never retransmitted.
+	groups map[uint64]int
+
+	inFlight []*simulatedMsg
+	sent     int
+
+	// trace records every message delivered, lost or dropped, with the
+	// tick at which it happened.
+	trace []string
+}
+
+type simulatedMsg struct {
+	due    int
+	sent   int
+	source uint64
+	target uint64
+	msg    *msgs.Msg
+}
+
+// newSimulation returns a simulation of a fresh network of nodeCount nodes
+// tolerating f faults, whose network model is drawn from seed.
+func newSimulation(nodeCount, f int, seed int64) *simulation {
+	return &simulation{
+		testNetwork: newTestNetwork(nodeCount, f),
+		rand:        rand.New(rand.NewSource(seed)),
+		maxLatency:  3,
+		loss:        0.05,
+	}
+}
+
+// partition splits the network into the given groups of nodes, so that
+// messages are only delivered within a group.  Nodes in no group are
+// isolated.
+func (s *simulation) partition(groups ...[]uint64) {
+	s.groups = map[uint64]int{}
+	for i, group := range groups {
+		for _, id := range group {
+			s.groups[id] = i
+		}
+	}
+}
+
+// heal removes any partition of the network.
+func (s *simulation) heal() {
+	s.groups = nil
+}
+
+func (s *simulation) connected(source, target uint64) bool {
+	if s.groups == nil {
+		return true
+	}
+
+	sourceGroup, ok := s.groups[source]
+	if !ok {
+		return false
+	}
+	targetGroup, ok := s.groups[target]
+	return ok && sourceGroup == targetGroup
+}
+
+// apply applies an event to every node, then runs the network until no
+// message is due.
+func (s *simulation) apply(event *state.Event) {
+	for i, sm := range s.nodes {
+		if s.crashed[i] {
+			continue
+		}
+		s.pending[i].concat(sm.ApplyEvent(event))
+	}
+	s.run()
+}
+
+// step advances the virtual clock by one tick.
+func (s *simulation) step() {
+	s.ticks++
+	s.apply(EventTickElapsed())
+}
+
+// stepUntil steps the simulation until condition is satisfied, failing if
+// it is not within maxTicks.
+func (s *simulation) stepUntil(maxTicks int, condition func() bool) {
+	for i := 0; !condition(); i++ {
+		Expect(i).To(BeNumerically("<", maxTicks), "condition not satisfied after %d ticks", maxTicks)
+		s.step()
+	}
+}
+
+func (s *simulation) run() {
+	for {
+		s.dispatch()
+
+		sort.SliceStable(s.inFlight, func(i, j int) bool {
+			if s.inFlight[i].due != s.inFlight[j].due {
+				return s.inFlight[i].due < s.inFlight[j].due
+			}
+			return s.inFlight[i].sent < s.inFlight[j].sent
+		})
+
+		due := 0
+		for due < len(s.inFlight) && s.inFlight[due].due <= s.ticks {
+			due++
+		}
+		if due == 0 {
+			return
+		}
+
+		for _, m := range s.inFlight[:due] {
+			if s.crashed[m.target] {
+				continue
+			}
+			s.trace = append(s.trace, fmt.Sprintf("%d: %d -> %d %s", s.ticks, m.source, m.target, msgType(m.msg)))
+			s.pending[m.target].concat(s.nodes[m.target].ApplyEvent(EventStep(m.source, m.msg)))
+		}
+		s.inFlight = s.inFlight[due:]
+	}
+}
+
+// dispatch satisfies the pending actions of every node until none remain,
+// scheduling the messages sent for delivery.
+func (s *simulation) dispatch() {
+	for {
+		quiescent := true
+		for i := range s.pending {
+			actions := s.pending[i]
+			s.pending[i] = &ActionList{}
+
+			events := &EventList{}
+			iter := actions.Iterator()
+			for action := iter.Next(); action != nil; action = iter.Next() {
+				quiescent = false
+				s.observed[i] = append(s.observed[i], action)
+				send, ok := action.Type.(*state.Action_Send)
+				if !ok {
+					s.consumer.consume(uint64(i), action, events)
+					continue
+				}
+				for _, target := range send.Send.Targets {
+					s.schedule(uint64(i), target, send.Send.Msg)
+				}
+			}
+
+			if s.crashed[i] {
+				continue
+			}
+			eventIter := events.Iterator()
+			for event := eventIter.Next(); event != nil; event = eventIter.Next() {
+				s.pending[i].concat(s.nodes[i].ApplyEvent(event))
+			}
+		}
+
+		if quiescent {
+			return
+		}
+	}
+}
+
+func (s *simulation) schedule(source, target uint64, msg *msgs.Msg) {
+	s.sent++
+	due := s.ticks
+	if source != target {
+		if !s.connected(source, target) {
+			s.trace = append(s.trace, fmt.Sprintf("%d: %d -> %d %s dropped", s.ticks, source, target, msgType(msg)))
+			return
+		}
+		for s.rand.Float64() < s.loss {
+			s.trace = append(s.trace, fmt.Sprintf("%d: %d -> %d %s lost", s.ticks, source, target, msgType(msg)))
+			due += s.maxLatency
+		}
+		due += 1 + s.rand.Intn(s.maxLatency)
+	}
+
+	s.inFlight = append(s.inFlight, &simulatedMsg{
+		due:    due,
+		sent:   s.sent,
+		source: source,
+		target: target,
+		msg:    msg,
+	})
+}
+
+// commits returns the digests of the batches node i committed, by sequence.
+func (s *simulation) commits(i int) map[uint64][]byte {
+	result := map[uint64][]byte{}
+	for _, action := range s.observed[i] {
+		if commit, ok := action.Type.(*state.Action_Commit); ok {
+			result[commit.Commit.Batch.SeqNo] = commit.Commit.Batch.Digest
+		}
+	}
+	return result
+}
+
+// committedRequests returns the number of requests node i committed.
+func (s *simulation) committedRequests(i int) int {
+	count := 0
+	for _, action := range s.observed[i] {
+		if commit, ok := action.Type.(*state.Action_Commit); ok {
+			count += len(commit.Commit.Batch.Requests)
+		}
+	}
+	return count
+}
+
+var _ = Describe("simulation", func() {
+	const requests = 20
+
+	// simulate runs a network of 7 nodes tolerating 2 faults through rounds
+	// of random partitions, each splitting the nodes into two groups for 10
+	// ticks, then heals the network and waits for every request to commit
+	// everywhere.
+	simulate := func(seed int64) *simulation {
+		s := newSimulation(7, 2, seed)
+		s.stepUntil(50, s.inProgress)
+
+		for reqNo := uint64(0); reqNo < requests; reqNo++ {
+			s.apply(EventRequestPersisted(&msgs.RequestAck{
+				ClientId: 0,
+				ReqNo:    reqNo,
+				Digest:   []byte(fmt.Sprintf("request-digest-%d", reqNo)),
+			}))
+		}
+
+		for round := 0; round < 5; round++ {
+			nodes := s.rand.Perm(len(s.nodes))
+			split := 1 + s.rand.Intn(len(nodes)-1)
+			var left, right []uint64
+			for i, id := range nodes {
+				if i < split {
+					left = append(left, uint64(id))
+				} else {
+					right = append(right, uint64(id))
+				}
+			}
+			s.partition(left, right)
+			for i := 0; i < 10; i++ {
+				s.step()
+			}
+		}
+
+		s.heal()
+		s.stepUntil(500, func() bool {
+			for i := range s.nodes {
+				if s.committedRequests(i) < requests {
+					return false
+				}
+			}
+			return true
+		})
+
+		return s
+	}
+
+	It("reaches agreement despite random partitions", func() {
+		s := simulate(7)
+
+		commits := make([]map[uint64][]byte, len(s.nodes))
+		for i := range s.nodes {
+			Expect(s.committedRequests(i)).To(Equal(requests))
+			commits[i] = s.commits(i)
+		}
+
+		for i := range s.nodes {
+			for seqNo, digest := range commits[i] {
+				for j := range s.nodes {
+					if other, ok := commits[j][seqNo]; ok {
+						Expect(other).To(Equal(digest), "nodes %d and %d disagree on seq_no=%d", i, j, seqNo)
+					}
+				}
+			}
+		}
+	})
+
+	It("produces the same trace for the same seed", func() {
+		Expect(simulate(11).trace).To(Equal(simulate(11).trace))
+		Expect(simulate(11).trace).NotTo(Equal(simulate(12).trace))
+	})
+})
@@ -0,0 +1,122 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statemachine
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+)
+
+// PreprepareFunc is invoked for request reqNo of client clientID, which
+// preprepared at sequence seqNo.  Preprepared requests have not committed,
+// and the notification is purely speculative: the sequence may be lost in an
+// epoch change, in which case the same request is reported again through
+// OnPreprepareDiscarded, and possibly later preprepares at another sequence.
+// Applications must therefore be able to undo any work begun optimistically.
+type PreprepareFunc func(clientID, reqNo, seqNo uint64)
+
+// speculativeNotifier reports the requests of the batches this node
+// preprepares, and of those which an epoch change discards before they
+// commit.  Preprepared batches are recognized by the QEntries written to the
+// write-ahead log, and an epoch change by the NEntry of a new epoch, which
+// is followed by the QEntries of the sequences the new epoch retains.
+type speculativeNotifier struct {
+	onPreprepared PreprepareFunc
+	onDiscarded   PreprepareFunc
+
+	epoch       uint64
+	preprepared map[uint64]*msgs.QEntry
+}
+
+func newSpeculativeNotifier(onPreprepared, onDiscarded PreprepareFunc) *speculativeNotifier {
+	return &speculativeNotifier{
+		onPreprepared: onPreprepared,
+		onDiscarded:   onDiscarded,
+		preprepared:   map[uint64]*msgs.QEntry{},
+	}
+}
+
+// apply notifies the preprepares and discards which the write-ahead log
+// entries of actions imply, then forgets the sequences through highestCommit.
+func (sn *speculativeNotifier) apply(actions *ActionList, highestCommit uint64) {
+	// unconfirmed holds the sequences preprepared in a previous epoch
+	// which the new epoch has not yet retained.
+	var unconfirmed map[uint64]*msgs.QEntry
+
+	iter := actions.Iterator()
+	for action := iter.Next(); action != nil; action = iter.Next() {
+		appendWriteAhead, ok := action.Type.(*state.Action_AppendWriteAhead)
+		if !ok {
+			continue
+		}
+
+		switch entry := appendWriteAhead.AppendWriteAhead.Data.Type.(type) {
+		case *msgs.Persistent_NEntry:
+			epoch := entry.NEntry.EpochConfig.Number
+			if epoch == sn.epoch {
+				continue
+			}
+			sn.epoch = epoch
+
+			unconfirmed = map[uint64]*msgs.QEntry{}
+			for seqNo, qEntry := range sn.preprepared {
+				if seqNo >= entry.NEntry.SeqNo {
+					unconfirmed[seqNo] = qEntry
+				}
+			}
+		case *msgs.Persistent_QEntry:
+			qEntry := entry.QEntry
+			delete(unconfirmed, qEntry.SeqNo)
+
+			previous, ok := sn.preprepared[qEntry.SeqNo]
+			if ok && bytes.Equal(previous.Digest, qEntry.Digest) {
+				continue
+			}
+			if ok {
+				sn.discard(previous)
+			}
+
+			sn.preprepared[qEntry.SeqNo] = qEntry
+			if sn.onPreprepared != nil {
+				for _, ack := range qEntry.Requests {
+					sn.onPreprepared(ack.ClientId, ack.ReqNo, qEntry.SeqNo)
+				}
+			}
+		}
+	}
+
+	discarded := make([]uint64, 0, len(unconfirmed))
+	for seqNo := range unconfirmed {
+		discarded = append(discarded, seqNo)
+	}
+	sort.Slice(discarded, func(i, j int) bool {
+		return discarded[i] < discarded[j]
+	})
+	for _, seqNo := range discarded {
+		delete(sn.preprepared, seqNo)
+		sn.discard(unconfirmed[seqNo])
+	}
+
+	for seqNo := range sn.preprepared {
+		if seqNo <= highestCommit {
+			delete(sn.preprepared, seqNo)
+		}
+	}
+}
+
+func (sn *speculativeNotifier) discard(qEntry *msgs.QEntry) {
+	if sn.onDiscarded == nil {
+		return
+	}
+
+	for _, ack := range qEntry.Requests {
+		sn.onDiscarded(ack.ClientId, ack.ReqNo, qEntry.SeqNo)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hyperledger-labs/mirbft/pkg/logger"
 
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
 	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
@@ -100,6 +101,41 @@ const (
 type StateMachine struct {
 	Logger logger.Logger
 
+	// BatchOrderer, if set, is invoked to order the requests of each batch this node proposes.
+	BatchOrderer BatchOrderer
+
+	// BatchValidator, if set, is invoked to vet each batch this node proposes.
+	BatchValidator BatchValidator
+
+	// OnSequenceAllocated, if set, is invoked for each sequence the active
+	// epoch allocates to a batch, see SequenceAllocatedFunc.
+	OnSequenceAllocated SequenceAllocatedFunc
+
+	// LeaderSelector, if set, is invoked to choose the leaders this node
+	// proposes for a new epoch, see LeaderSelector.
+	LeaderSelector LeaderSelector
+
+	// BatchCodec, if set, is used to compress preprepare batches when the
+	// network configuration names it as its batch compression.
+	BatchCodec BatchCodec
+
+	// HashFunc, if set, is used to recompute the digests of committed batches
+	// when the node configuration asks for commit digests to be verified.
+	HashFunc HashFunc
+
+	// OnPreprepared, if set, is invoked for each request once it preprepares,
+	// before it commits.  The notification is speculative, see PreprepareFunc.
+	OnPreprepared PreprepareFunc
+
+	// OnPreprepareDiscarded, if set, is invoked for each request previously
+	// reported to OnPreprepared whose sequence an epoch change discarded.
+	OnPreprepareDiscarded PreprepareFunc
+
+	// ActionsObserver, if set, is invoked with a copy of the actions returned
+	// by each ApplyEvent which returns any, for monitoring.  Modifying the
+	// copy does not affect the actions returned to the consumer.
+	ActionsObserver func(actions *ActionList)
+
 	state stateMachineState
 
 	myConfig               *state.EventInitialParameters
@@ -112,6 +148,15 @@ type StateMachine struct {
 	checkpointTracker *checkpointTracker
 	epochTracker      *epochTracker
 	persisted         *persisted
+	msgCounts         *msgCounts
+	speculative       *speculativeNotifier
+	pendingResults    *pendingResults
+	transferBuffer    *transferBuffer
+
+	// mismatchedPeers are the nodes which announced a network
+	// configuration differing from ours, whose messages are ignored until
+	// they announce a matching one.
+	mismatchedPeers map[nodeID]struct{}
 }
 
 func (sm *StateMachine) initialize(parameters *state.EventInitialParameters) {
@@ -120,6 +165,10 @@ func (sm *StateMachine) initialize(parameters *state.EventInitialParameters) {
 	sm.myConfig = parameters
 	sm.state = smLoadingPersisted
 	sm.persisted = newPersisted(sm.Logger)
+	sm.msgCounts = newMsgCounts()
+	sm.pendingResults = newPendingResults()
+	sm.mismatchedPeers = map[nodeID]struct{}{}
+	sm.speculative = newSpeculativeNotifier(sm.OnPreprepared, sm.OnPreprepareDiscarded)
 
 	// we use a dummy initial state for components to allow us to use
 	// a common 'reconfiguration'/'state transfer' path for initialization.
@@ -135,8 +184,10 @@ func (sm *StateMachine) initialize(parameters *state.EventInitialParameters) {
 	sm.nodeBuffers = newNodeBuffers(sm.myConfig, sm.Logger)
 	sm.checkpointTracker = newCheckpointTracker(0, dummyInitialState, sm.persisted, sm.nodeBuffers, sm.myConfig, sm.Logger)
 	sm.clientTracker = newClientTracker(sm.myConfig, sm.Logger)
-	sm.commitState = newCommitState(sm.persisted, sm.Logger)
+	sm.commitState = newCommitState(sm.persisted, sm.HashFunc, sm.myConfig, sm.Logger)
 	sm.clientHashDisseminator = newClientHashDisseminator(sm.nodeBuffers, sm.myConfig, sm.Logger, sm.clientTracker)
+	sm.clientHashDisseminator.bucketLeader = sm.bucketLeader
+	sm.transferBuffer = newTransferBuffer(sm.nodeBuffers, sm.myConfig, sm.Logger)
 	sm.batchTracker = newBatchTracker(sm.persisted)
 	sm.epochTracker = newEpochTracker(
 		sm.persisted,
@@ -148,7 +199,12 @@ func (sm *StateMachine) initialize(parameters *state.EventInitialParameters) {
 		sm.batchTracker,
 		sm.clientTracker,
 		sm.clientHashDisseminator,
+		sm.BatchOrderer,
+		sm.BatchValidator,
+		sm.OnSequenceAllocated,
+		sm.LeaderSelector,
 	)
+	sm.epochTracker.lagging = sm.checkpointTracker.lagging
 
 }
 
@@ -167,7 +223,70 @@ func (sm *StateMachine) completeInitialization() *ActionList {
 
 // Public wrapper for StateMachine.applyEvent()
 func (sm *StateMachine) ApplyEvent(stateEvent *state.Event) *ActionList {
-	return sm.applyEvent(stateEvent)
+	actions := sm.applyEvent(stateEvent)
+	if sm.msgCounts != nil {
+		sm.msgCounts.countSent(actions)
+	}
+	if sm.state != smInitialized {
+		return actions
+	}
+	if sm.myConfig.DisableSelfEcho {
+		actions = sm.deliverToSelf(actions)
+	}
+	sm.msgCounts.countMisbehaviors(actions)
+	sm.pendingResults.track(actions)
+	if sm.OnPreprepared != nil || sm.OnPreprepareDiscarded != nil {
+		sm.speculative.apply(actions, sm.commitState.highestCommit)
+	}
+	actions = sm.compressSends(actions)
+	if sm.ActionsObserver != nil && !actions.isEmpty() {
+		sm.ActionsObserver(actions.clone())
+	}
+	return actions
+}
+
+// deliverToSelf removes this node from the targets of the sends in actions,
+// and instead applies the messages to this node directly, in the order they
+// were sent, including any further messages to itself which result.
+func (sm *StateMachine) deliverToSelf(actions *ActionList) *ActionList {
+	result := &ActionList{}
+	var selfMsgs []*msgs.Msg
+
+	for {
+		iter := actions.Iterator()
+		for action := iter.Next(); action != nil; action = iter.Next() {
+			send, ok := action.Type.(*state.Action_Send)
+			if !ok {
+				result.PushBack(action)
+				continue
+			}
+
+			targets := make([]uint64, 0, len(send.Send.Targets))
+			for _, target := range send.Send.Targets {
+				if target == sm.myConfig.Id {
+					selfMsgs = append(selfMsgs, send.Send.Msg)
+					continue
+				}
+				targets = append(targets, target)
+			}
+
+			switch {
+			case len(targets) == len(send.Send.Targets):
+				result.PushBack(action)
+			case len(targets) > 0:
+				result.Send(targets, send.Send.Msg)
+			}
+		}
+
+		if len(selfMsgs) == 0 {
+			return result
+		}
+
+		msg := selfMsgs[0]
+		selfMsgs = selfMsgs[1:]
+		actions = sm.applyEvent(EventStep(sm.myConfig.Id, msg))
+		sm.msgCounts.countSent(actions)
+	}
 }
 
 // Applies an external event, such as a message, a tick, or a result of an action, to the state machine.
@@ -189,22 +308,48 @@ func (sm *StateMachine) applyEvent(stateEvent *state.Event) *ActionList {
 		return sm.completeInitialization()
 	case *state.Event_TickElapsed:
 		assertInitialized()
+		if sm.learning() {
+			break
+		}
 		actions.concat(sm.clientHashDisseminator.tick())
 		actions.concat(sm.epochTracker.tick())
 	case *state.Event_Step:
 		assertInitialized()
+		sm.msgCounts.countReceived(event.Step.Msg)
+		msg, err := sm.decompressStep(event.Step.Msg)
+		if err != nil {
+			sm.Logger.Log(logger.LevelWarn, "dropping message with undecodable batch", "source", event.Step.Source, "err", err)
+			break
+		}
 		actions.concat(sm.step(
 			nodeID(event.Step.Source),
-			event.Step.Msg,
+			msg,
 		))
 	case *state.Event_HashResult:
 		assertInitialized()
+		if !sm.pendingResults.acceptHash(event.HashResult.Origin) {
+			sm.Logger.Log(logger.LevelError, "ignoring hash result for which no hash action is pending", "digest", event.HashResult.Digest)
+			break
+		}
 		actions.concat(sm.processHashResult(event.HashResult))
 	case *state.Event_CheckpointResult:
 		assertInitialized()
+		if !sm.pendingResults.acceptCheckpoint(event.CheckpointResult.SeqNo) {
+			sm.Logger.Log(logger.LevelError, "ignoring checkpoint result for which no checkpoint action is pending", "seq_no", event.CheckpointResult.SeqNo)
+			break
+		}
 		actions.concat(sm.processCheckpointResult(event.CheckpointResult))
 	case *state.Event_RequestPersisted:
 		assertInitialized()
+		if sm.learning() {
+			sm.Logger.Log(logger.LevelDebug, "ignoring request persisted by a learner", "client_id", event.RequestPersisted.RequestAck.ClientId, "req_no", event.RequestPersisted.RequestAck.ReqNo)
+			break
+		}
+		if event.RequestPersisted.Rejected {
+			actions.concat(sm.clientHashDisseminator.rejectRequest(event.RequestPersisted))
+			break
+		}
+
 		actions.concat(sm.clientHashDisseminator.applyNewRequest(
 			event.RequestPersisted.RequestAck,
 		))
@@ -222,6 +367,64 @@ func (sm *StateMachine) applyEvent(stateEvent *state.Event) *ActionList {
 			NetworkState:    event.StateTransferComplete.NetworkState,
 		}))
 		actions.concat(sm.reinitialize())
+
+		members := map[nodeID]struct{}{}
+		for _, id := range sm.commitState.activeState.Config.Nodes {
+			members[nodeID(id)] = struct{}{}
+		}
+		sm.transferBuffer.drain(func(source nodeID, msg *msgs.Msg) {
+			if _, ok := members[source]; !ok {
+				// The node was removed by the transferred state.
+				return
+			}
+			actions.concat(sm.step(source, msg))
+		})
+
+		if sm.learning() {
+			// Let the nodes know how far this learner has caught up.
+			actions.Send(
+				sm.commitState.activeState.Config.Nodes,
+				&msgs.Msg{
+					Type: &msgs.Msg_Checkpoint{
+						Checkpoint: &msgs.Checkpoint{
+							SeqNo: event.StateTransferComplete.SeqNo,
+							Value: event.StateTransferComplete.CheckpointValue,
+						},
+					},
+				},
+			)
+		}
+	case *state.Event_CommitsApplied:
+		assertInitialized()
+		sm.commitState.applyCommitsApplied(event.CommitsApplied.From, event.CommitsApplied.To)
+	case *state.Event_Compact:
+		assertInitialized()
+		actions.concat(sm.compact())
+	case *state.Event_StepDown:
+		assertInitialized()
+		actions.concat(sm.epochTracker.stepDown())
+	case *state.Event_AuditDigest:
+		assertInitialized()
+		seqNo := event.AuditDigest.SeqNo
+		uncommitted := seqNo > sm.commitState.highestCommit
+		if uncommitted {
+			sm.Logger.Log(logger.LevelWarn, "audit digest requested for uncommitted sequence", "seq_no", seqNo, "highest_commit", sm.commitState.highestCommit)
+		}
+		actions.AuditDigest(seqNo, uncommitted)
+	case *state.Event_ResetMisbehavior:
+		assertInitialized()
+		delete(sm.msgCounts.misbehaviors, event.ResetMisbehavior.NodeId)
+	case *state.Event_RequestCheckpoint:
+		assertInitialized()
+		actions.concat(sm.clientHashDisseminator.requestCheckpoint())
+	case *state.Event_ImportClientState:
+		assertInitialized()
+		imported, err := sm.clientHashDisseminator.importClientState(event.ImportClientState.ClientState)
+		if err != nil {
+			sm.Logger.Log(logger.LevelWarn, "ignoring imported client state", "err", err)
+			break
+		}
+		actions.concat(imported)
 	case *state.Event_ActionsReceived:
 		// This is a bit odd, in that it's a no-op, but it's harmless
 		// and allows for much more insightful playback events (allowing
@@ -241,6 +444,10 @@ func (sm *StateMachine) applyEvent(stateEvent *state.Event) *ActionList {
 		newLow := sm.checkpointTracker.garbageCollect()
 		sm.Logger.Log(logger.LevelDebug, "garbage collecting through", "seq_no", newLow)
 
+		if clients := sm.prunedClients(newLow); len(clients) > 0 {
+			actions.ClientStatePruned(newLow, clients)
+		}
+
 		sm.persisted.truncate(newLow)
 
 		if newLow > uint64(sm.checkpointTracker.networkConfig.CheckpointInterval) {
@@ -249,6 +456,16 @@ func (sm *StateMachine) applyEvent(stateEvent *state.Event) *ActionList {
 			sm.batchTracker.truncate(newLow - uint64(sm.checkpointTracker.networkConfig.CheckpointInterval))
 		}
 		actions.concat(sm.epochTracker.moveLowWatermark(newLow))
+
+		if sm.reconfigurationStable(newLow) {
+			sm.Logger.Log(logger.LevelInfo, "reconfiguration checkpoint is stable, adopting new network state", "seq_no", newLow)
+			actions.concat(sm.epochTracker.endEpochForReconfiguration())
+			actions.concat(sm.reinitialize())
+		} else {
+			// Peers which announced their configuration at an earlier
+			// checkpoint could not be compared, so announce ours anew.
+			actions.concat(sm.hello())
+		}
 	}
 
 	for {
@@ -267,24 +484,99 @@ func (sm *StateMachine) applyEvent(stateEvent *state.Event) *ActionList {
 		actions.concat(loopActions)
 	}
 
+	actions.concat(sm.commitState.persistWatermark())
+
 	return actions
 }
 
 // reinitialize causes the components to reinitialize themselves from the logs.
 // varying from component to component, useful state will be retained.  For instance,
 // the clientTracker retains in-window ACKs for still-extant clients.  The checkpointTracker
-// retains checkpoint messages sent by other replicas, etc.
+// retains checkpoint messages sent by other replicas, etc.  Once reinitialized,
+// the configuration of the new stable checkpoint is announced to the network.
 func (sm *StateMachine) reinitialize() *ActionList {
 	defer sm.Logger.Log(logger.LevelInfo, "state machine reinitialized (either due to start, state transfer, or reconfiguration)")
 
+	previousState := sm.commitState.activeState
+
 	actions := sm.recoverLog()
 	actions.concat(sm.commitState.reinitialize())
+
+	if previousState != nil && configChanged(previousState, sm.commitState.activeState) {
+		// Peers were judged against the previous configuration, they
+		// must announce their configuration anew to be compared.
+		sm.mismatchedPeers = map[nodeID]struct{}{}
+	}
+
+	if err := faultToleranceError(sm.commitState.activeState.Config); err != nil {
+		sm.Logger.Log(logger.LevelError, "network configuration cannot make progress", "err", err)
+		actions.Unrecoverable(err.Error())
+	}
+	if err := epochQuorumError(sm.commitState.activeState.Config, sm.myConfig); err != nil {
+		sm.Logger.Log(logger.LevelError, "ignoring epoch quorum overrides invalid for the network configuration", "err", err)
+	}
 	sm.clientTracker.reinitialize(sm.commitState.activeState)
 	actions.concat(sm.clientHashDisseminator.reinitialize(sm.commitState.lowWatermark, sm.commitState.activeState))
 
 	sm.checkpointTracker.reinitialize()
 	sm.batchTracker.reinitialize()
-	return actions.concat(sm.epochTracker.reinitialize())
+	actions.concat(sm.epochTracker.reinitialize())
+	return actions.concat(sm.hello())
+}
+
+// prunedClients returns the state, as of the checkpoint at seqNo, of each
+// client whose low watermark advanced since the earliest checkpoint in the
+// log, a client added since counting as starting from zero.  It must be
+// invoked before the log is truncated to seqNo.
+func (sm *StateMachine) prunedClients(seqNo uint64) []*msgs.NetworkState_Client {
+	var earliest, stable *msgs.CEntry
+	sm.persisted.iterate(logIterator{
+		onCEntry: func(cEntry *msgs.CEntry) {
+			if earliest == nil {
+				earliest = cEntry
+			}
+			if cEntry.SeqNo == seqNo {
+				stable = cEntry
+			}
+		},
+	})
+
+	if earliest == nil || stable == nil || earliest == stable {
+		return nil
+	}
+
+	previous := map[uint64]uint64{}
+	for _, client := range earliest.NetworkState.Clients {
+		previous[client.Id] = client.LowWatermark
+	}
+
+	var pruned []*msgs.NetworkState_Client
+	for _, client := range stable.NetworkState.Clients {
+		if client.LowWatermark <= previous[client.Id] {
+			continue
+		}
+		pruned = append(pruned, client)
+	}
+
+	return pruned
+}
+
+// compact truncates the write-ahead log to the current stable checkpoint.
+// Nothing above the stable checkpoint is released, as the entries above it may
+// still be needed to preserve safety.
+func (sm *StateMachine) compact() *ActionList {
+	stableSeqNo := sm.checkpointTracker.lowWatermark()
+	sm.Logger.Log(logger.LevelInfo, "compacting to the stable checkpoint", "seq_no", stableSeqNo)
+
+	sm.persisted.truncate(stableSeqNo)
+	return sm.persisted.compact()
+}
+
+// reconfigurationStable returns whether the checkpoint at stableSeqNo has
+// made the checkpoint applying a change of network config stable, at which
+// point the state machine adopts the new network state.
+func (sm *StateMachine) reconfigurationStable(stableSeqNo uint64) bool {
+	return sm.commitState.reconfigSeqNo != 0 && stableSeqNo >= sm.commitState.reconfigSeqNo
 }
 
 // Truncates the WAL based on the last FEntry found.
@@ -305,18 +597,63 @@ func (sm *StateMachine) recoverLog() *ActionList {
 
 	assertTruef(lastCEntry != nil, "found no checkpoints in the log")
 
+	if !actions.isEmpty() {
+		// The write-ahead log is truncated to the head of the log, so there
+		// is nothing left for compact to truncate.
+		sm.persisted.compactedIndex = sm.persisted.logHead.index
+	}
+
 	return actions
 }
 
 func (sm *StateMachine) step(source nodeID, msg *msgs.Msg) *ActionList {
 	actions := &ActionList{}
-	switch msg.Type.(type) {
+	config := sm.commitState.activeState.Config
+	if !isNode(config, source) {
+		// Learners only report how far they have caught up, and count
+		// towards no quorum.
+		if cp, ok := msg.Type.(*msgs.Msg_Checkpoint); ok && isLearner(config, source) {
+			sm.checkpointTracker.applyLearnerCheckpoint(source, cp.Checkpoint.SeqNo, cp.Checkpoint.Value)
+		}
+		return actions
+	}
+
+	if _, ok := sm.mismatchedPeers[source]; ok {
+		if _, ok := msg.Type.(*msgs.Msg_Hello); !ok {
+			sm.Logger.Log(logger.LevelDebug, "ignoring message from peer with a different network configuration", "source", source)
+			return actions
+		}
+	}
+
+	if sm.learning() {
+		// A learner only follows the checkpoints of the network.
+		if _, ok := msg.Type.(*msgs.Msg_Checkpoint); !ok {
+			return actions
+		}
+		sm.checkpointTracker.step(source, msg)
+		return sm.catchUp()
+	}
+
+	if sm.commitState.transferring && sm.transferBuffer.hold(source, msg, sm.commitState.transferSeqNo) {
+		return actions
+	}
+
+	switch innerMsg := msg.Type.(type) {
 	case *msgs.Msg_RequestAck:
 		return actions.concat(sm.clientHashDisseminator.step(source, msg))
 	case *msgs.Msg_FetchRequest:
 		return actions.concat(sm.clientHashDisseminator.step(source, msg))
 	case *msgs.Msg_ForwardRequest:
 		return actions.concat(sm.clientHashDisseminator.step(source, msg))
+	case *msgs.Msg_ForwardRequests:
+		for _, forward := range innerMsg.ForwardRequests.Requests {
+			actions.concat(sm.step(source, &msgs.Msg{
+				Type: &msgs.Msg_ForwardRequest{
+					ForwardRequest: forward,
+				},
+			}))
+		}
+		return actions
 	case *msgs.Msg_Checkpoint:
 		sm.checkpointTracker.step(source, msg)
 		return &ActionList{}
@@ -344,6 +681,8 @@ func (sm *StateMachine) step(source nodeID, msg *msgs.Msg) *ActionList {
 		return sm.epochTracker.step(source, msg)
 	case *msgs.Msg_Commit:
 		return sm.epochTracker.step(source, msg)
+	case *msgs.Msg_Hello:
+		return sm.applyHello(source, innerMsg.Hello)
 	default:
 		panic(fmt.Sprintf("unexpected bad message type %T", msg.Type))
 	}
@@ -366,6 +705,13 @@ func (sm *StateMachine) processHashResult(hashResult *state.EventHashResult) *Ac
 			actions.concat(sm.epochTracker.currentEpoch.fetchNewEpochState())
 		}
 		return actions
+	case *state.HashOrigin_CheckpointRequest_:
+		checkpointRequest := hashType.CheckpointRequest
+		return sm.clientHashDisseminator.applyNewRequest(&msgs.RequestAck{
+			ClientId: checkpointRequest.ClientId,
+			ReqNo:    checkpointRequest.ReqNo,
+			Digest:   hashResult.Digest,
+		})
 	default:
 		panic("no hash result type set")
 	}
@@ -383,6 +729,48 @@ func (sm *StateMachine) processCheckpointResult(checkpointResult *state.EventChe
 	expectedSeqNo := sm.commitState.lowWatermark + uint64(sm.commitState.activeState.Config.CheckpointInterval)
 	assertEqual(expectedSeqNo, checkpointResult.SeqNo, "new checkpoint results muts be exactly one checkpoint interval after the last")
 
+	// Any new network configuration takes effect at the checkpoint after
+	// this one, reject those whose checkpoint interval cannot, those
+	// whose nodes cannot lead every bucket, those whose learners are
+	// already nodes, and those adding nodes which were not learners.
+	effectiveSeqNo := checkpointResult.SeqNo + uint64(sm.commitState.activeState.Config.CheckpointInterval)
+	var reconfigurations []*msgs.Reconfiguration
+	for _, reconfig := range checkpointResult.NetworkState.PendingReconfigurations {
+		if rc, ok := reconfig.Type.(*msgs.Reconfiguration_NewConfig); ok {
+			if err := checkpointIntervalError(effectiveSeqNo, rc.NewConfig); err != nil {
+				sm.Logger.Log(logger.LevelError, "ignoring invalid network configuration", "seq_no", checkpointResult.SeqNo, "err", err)
+				continue
+			}
+			if err := bucketLimitError(rc.NewConfig); err != nil {
+				sm.Logger.Log(logger.LevelError, "ignoring invalid network configuration", "seq_no", checkpointResult.SeqNo, "err", err)
+				continue
+			}
+			if err := learnerError(rc.NewConfig); err != nil {
+				sm.Logger.Log(logger.LevelError, "ignoring invalid network configuration", "seq_no", checkpointResult.SeqNo, "err", err)
+				continue
+			}
+			if err := promotionError(checkpointResult.NetworkState.Config, rc.NewConfig); err != nil {
+				sm.Logger.Log(logger.LevelError, "ignoring invalid network configuration", "seq_no", checkpointResult.SeqNo, "err", err)
+				continue
+			}
+		}
+		reconfigurations = append(reconfigurations, reconfig)
+	}
+
+	// The event belongs to the caller, so the checkpoint is taken from a
+	// copy with only the valid reconfigurations pending.
+	checkpointResult = &state.EventCheckpointResult{
+		SeqNo: checkpointResult.SeqNo,
+		Value: checkpointResult.Value,
+		NetworkState: &msgs.NetworkState{
+			Config:                  checkpointResult.NetworkState.Config,
+			Clients:                 checkpointResult.NetworkState.Clients,
+			PendingReconfigurations: reconfigurations,
+			Reconfigured:            checkpointResult.NetworkState.Reconfigured,
+		},
+		Reconfigured: checkpointResult.Reconfigured,
+	}
+
 	var epochConfig *msgs.EpochConfig
 	if sm.epochTracker.currentEpoch.activeEpoch != nil {
 		// Of course this means epochConfig may be nil, and that's okay
@@ -401,6 +789,254 @@ func (sm *StateMachine) processCheckpointResult(checkpointResult *state.EventChe
 	return actions
 }
 
+// CommittedSince returns an iterator over the committed batches, in order, from
+// seqNo through the highest sequence committed so far.  Only the sequences above
+// the low watermark are retained, for earlier ones an error is returned and the
+// caller must instead catch up via state transfer.  It implements
+// modules.CommitReader, so that consumers may read the batches through their
+// serializer, e.g. ReadyNode.CommittedSince.
+func (sm *StateMachine) CommittedSince(seqNo uint64) (*CommitIterator, error) {
+	if sm.state != smInitialized {
+		return nil, errors.Errorf("state machine is not initialized")
+	}
+
+	return sm.commitState.committedSince(seqNo)
+}
+
+// learning returns whether this node is not one of the nodes of the active
+// network configuration, but a learner being added to it.  A learner neither
+// votes nor acks requests, it only catches up from the checkpoints of the
+// network, see catchUp.
+func (sm *StateMachine) learning() bool {
+	return !isNode(sm.commitState.activeState.Config, nodeID(sm.myConfig.Id))
+}
+
+// catchUp transfers the state of a learner to the highest checkpoint which
+// f+1 nodes of the network agree on, if it is ahead of the learner.
+func (sm *StateMachine) catchUp() *ActionList {
+	if sm.commitState.transferring {
+		return &ActionList{}
+	}
+
+	seqNo, value := sm.checkpointTracker.networkCheckpoint()
+	if seqNo <= sm.commitState.lowWatermark {
+		return &ActionList{}
+	}
+
+	sm.Logger.Log(logger.LevelInfo, "learner catching up to network checkpoint", "seq_no", seqNo)
+	return sm.commitState.transferTo(seqNo, value)
+}
+
+// IsCaughtUp returns whether this node has committed every sequence through
+// the highest checkpoint which f+1 nodes of the network agree on.  A node
+// which is behind its peers, or which is transferring state, is not caught up.
+// Like the other methods of the state machine, it must be invoked from the
+// serializing go routine, consumers should instead read it from the status
+// served by their serializer.
+func (sm *StateMachine) IsCaughtUp() bool {
+	if sm.state != smInitialized || sm.commitState.transferring {
+		return false
+	}
+
+	return sm.commitState.highestCommit >= sm.checkpointTracker.highestNetworkCheckpoint()
+}
+
+// QuorumSources returns the ids of the nodes whose prepares and commits for
+// the digest of sequence seqNo this node has applied in the active epoch, in
+// ascending order.  Sources are only retained for the sequences between the
+// watermarks, for others, or while no epoch is active, an error is returned.
+// It implements modules.QuorumSourcer, so that consumers may query it through
+// their serializer, e.g. ReadyNode.QuorumSources.
+func (sm *StateMachine) QuorumSources(seqNo uint64) (prepares, commits []uint64, err error) {
+	if sm.state != smInitialized {
+		return nil, nil, errors.Errorf("state machine is not initialized")
+	}
+
+	activeEpoch := sm.epochTracker.currentEpoch.activeEpoch
+	if activeEpoch == nil || len(activeEpoch.sequences) == 0 {
+		return nil, nil, errors.Errorf("no epoch is active")
+	}
+
+	if seqNo < activeEpoch.lowWatermark() || seqNo > activeEpoch.highWatermark() {
+		return nil, nil, errors.Errorf("seq_no=%d is outside the watermarks [%d, %d]", seqNo, activeEpoch.lowWatermark(), activeEpoch.highWatermark())
+	}
+
+	seq := activeEpoch.sequence(seqNo)
+	return sortedSources(seq.prepareSources[string(seq.digest)]), sortedSources(seq.commitSources[string(seq.digest)]), nil
+}
+
+// PreparedCertificate is the evidence that a sequence prepared, for
+// verification outside of the state machine: the PEntry this node persisted,
+// along with the preprepare of the bucket leader and the prepares of the other
+// nodes, indexed by source, which formed the quorum for the digest of the
+// PEntry.  The preprepare counts towards the quorum as the leader's prepare.
+//
+// Messages are not signed, so the preprepare and prepares are rebuilt from
+// the digest and the sources this node counted.  The certificate is an
+// attestation by this node alone, and a verifier must trust it to have
+// reported the sources faithfully.
+type PreparedCertificate struct {
+	PEntry     *msgs.PEntry
+	Preprepare *msgs.Preprepare
+	Prepares   map[uint64]*msgs.Prepare
+}
+
+// PreparedCertificate returns the prepared certificate of sequence seqNo in
+// the active epoch.  As for QuorumSources, the contributing prepares are only
+// retained for the sequences between the watermarks.  For other sequences,
+// or one which has not yet prepared, an error is returned.
+func (sm *StateMachine) PreparedCertificate(seqNo uint64) (*PreparedCertificate, error) {
+	if sm.state != smInitialized {
+		return nil, errors.Errorf("state machine is not initialized")
+	}
+
+	activeEpoch := sm.epochTracker.currentEpoch.activeEpoch
+	if activeEpoch == nil || len(activeEpoch.sequences) == 0 {
+		return nil, errors.Errorf("no epoch is active")
+	}
+
+	if seqNo < activeEpoch.lowWatermark() || seqNo > activeEpoch.highWatermark() {
+		return nil, errors.Errorf("seq_no=%d is outside the watermarks [%d, %d]", seqNo, activeEpoch.lowWatermark(), activeEpoch.highWatermark())
+	}
+
+	seq := activeEpoch.sequence(seqNo)
+	if seq.state < sequencePrepared {
+		return nil, errors.Errorf("seq_no=%d has not prepared", seqNo)
+	}
+
+	if seq.pEntry == nil {
+		return nil, errors.Errorf("seq_no=%d prepared without a PEntry", seqNo)
+	}
+
+	prepares := map[uint64]*msgs.Prepare{}
+	for _, source := range seq.prepareSources[string(seq.digest)] {
+		if source == seq.owner {
+			continue
+		}
+
+		prepares[uint64(source)] = &msgs.Prepare{
+			SeqNo:  seqNo,
+			Epoch:  seq.epoch,
+			Digest: seq.digest,
+		}
+	}
+
+	return &PreparedCertificate{
+		PEntry: seq.pEntry,
+		Preprepare: &msgs.Preprepare{
+			SeqNo: seqNo,
+			Epoch: seq.epoch,
+			Batch: seq.batch,
+		},
+		Prepares: prepares,
+	}, nil
+}
+
+// HighestPrepared returns the highest sequence number for which this node
+// has persisted a PEntry, that is, holds a prepared certificate, as reported
+// in its epoch change messages.  Before the state machine is initialized, it
+// returns zero.  Like the other methods of the state machine, it must be
+// invoked from the serializing go routine, consumers should instead read it
+// from the status served by their serializer.
+func (sm *StateMachine) HighestPrepared() uint64 {
+	if sm.state != smInitialized {
+		return 0
+	}
+
+	var highestPrepared uint64
+	sm.persisted.iterate(logIterator{
+		onPEntry: func(pEntry *msgs.PEntry) {
+			if pEntry.SeqNo > highestPrepared {
+				highestPrepared = pEntry.SeqNo
+			}
+		},
+	})
+
+	return highestPrepared
+}
+
+// bucketLeader returns the leader of the bucket of the request in the active
+// epoch, if an epoch is active.
+func (sm *StateMachine) bucketLeader(clientID, reqNo uint64) (nodeID, bool) {
+	activeEpoch := sm.epochTracker.currentEpoch.activeEpoch
+	if activeEpoch == nil {
+		return 0, false
+	}
+
+	leader, ok := activeEpoch.buckets[clientReqToBucket(clientID, reqNo, activeEpoch.networkConfig)]
+	return leader, ok
+}
+
+// LeadershipView is a consistent snapshot of the leadership of the active
+// epoch: its leaders, and the leader of each of its buckets, by bucket.
+// Every bucket leader is among the leaders.
+type LeadershipView struct {
+	Epoch         uint64
+	Leaders       []uint64
+	BucketLeaders map[uint64]uint64
+}
+
+// LeadershipView returns the leadership of the active epoch, so that
+// applications may route requests to the leaders of their buckets with a
+// single query, rather than racing two across an epoch change.  If no epoch
+// is active, an error is returned.
+func (sm *StateMachine) LeadershipView() (*LeadershipView, error) {
+	if sm.state != smInitialized {
+		return nil, errors.Errorf("state machine is not initialized")
+	}
+
+	activeEpoch := sm.epochTracker.currentEpoch.activeEpoch
+	if activeEpoch == nil {
+		return nil, errors.Errorf("no epoch is active")
+	}
+
+	bucketLeaders := make(map[uint64]uint64, len(activeEpoch.buckets))
+	for bucket, leader := range activeEpoch.buckets {
+		bucketLeaders[uint64(bucket)] = uint64(leader)
+	}
+
+	return &LeadershipView{
+		Epoch:         activeEpoch.epochConfig.Number,
+		Leaders:       append([]uint64{}, activeEpoch.epochConfig.Leaders...),
+		BucketLeaders: bucketLeaders,
+	}, nil
+}
+
+// MisbehaviorCounts returns the number of misbehaviors flagged by Misbehavior
+// actions for each node since this state machine was initialized, or last
+// reset for the node by an EventResetMisbehavior.  Nodes never flagged are
+// omitted.  Like the other methods of the state machine, it must be invoked
+// from the serializing go routine.
+func (sm *StateMachine) MisbehaviorCounts() map[uint64]int {
+	if sm.state == smUninitialized {
+		return map[uint64]int{}
+	}
+
+	counts := make(map[uint64]int, len(sm.msgCounts.misbehaviors))
+	for node, count := range sm.msgCounts.misbehaviors {
+		counts[node] = count
+	}
+
+	return counts
+}
+
+// ExportClientState serializes the window state of a single client, its
+// watermarks and committed requests, along with the requests this node has
+// persisted for it which have not yet committed, for import into another
+// node with EventImportClientState.
+func (sm *StateMachine) ExportClientState(clientID uint64) ([]byte, error) {
+	if sm.state != smInitialized {
+		return nil, errors.Errorf("state machine is not initialized")
+	}
+
+	clientState, err := sm.clientHashDisseminator.exportClientState(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(clientState)
+}
+
 func (sm *StateMachine) Status() (s *status.StateMachine, err error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -426,14 +1062,24 @@ func (sm *StateMachine) Status() (s *status.StateMachine, err error) {
 
 	checkpoints := sm.checkpointTracker.status()
 
+	messagesReceived, messagesSent := sm.msgCounts.status()
+
 	return &status.StateMachine{
-		NodeID:        sm.myConfig.Id,
-		LowWatermark:  lowWatermark,
-		HighWatermark: highWatermark,
-		EpochTracker:  sm.epochTracker.status(),
-		ClientWindows: clientTrackerStatus,
-		Buckets:       bucketStatus,
-		Checkpoints:   checkpoints,
-		NodeBuffers:   sm.nodeBuffers.status(),
+		NodeID:             sm.myConfig.Id,
+		LowWatermark:       lowWatermark,
+		HighWatermark:      highWatermark,
+		HighestPrepared:    sm.HighestPrepared(),
+		CaughtUp:           sm.IsCaughtUp(),
+		ProgressQuorum:     sm.epochTracker.progressQuorum(),
+		EpochTracker:       sm.epochTracker.status(),
+		ClientWindows:      clientTrackerStatus,
+		Buckets:            bucketStatus,
+		Checkpoints:        checkpoints,
+		NodeBuffers:        sm.nodeBuffers.status(),
+		PendingCheckpoints: sm.checkpointTracker.pendingStatus(),
+		Followers:          sm.epochTracker.currentEpoch.followerStatus(),
+		Learners:           sm.checkpointTracker.learnerStatus(),
+		MessagesReceived:   messagesReceived,
+		MessagesSent:       messagesSent,
 	}, nil
 }
@@ -0,0 +1,3822 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statemachine
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hyperledger-labs/mirbft/pkg/logger"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+	"github.com/hyperledger-labs/mirbft/pkg/status"
+)
+
+// standardNetworkState returns the initial network state of a network of
+// nodeCount nodes tolerating f faults, with a single client.
+func standardNetworkState(nodeCount, f int) *msgs.NetworkState {
+	nodes := make([]uint64, nodeCount)
+	for i := range nodes {
+		nodes[i] = uint64(i)
+	}
+
+	return &msgs.NetworkState{
+		Config: &msgs.NetworkState_Config{
+			Nodes:              nodes,
+			F:                  int32(f),
+			NumberOfBuckets:    int32(nodeCount),
+			CheckpointInterval: int32(nodeCount) * 5,
+			MaxEpochLength:     uint64(nodeCount) * 10,
+		},
+		Clients: []*msgs.NetworkState_Client{
+			{
+				Id:    0,
+				Width: 100,
+			},
+		},
+	}
+}
+
+// newInitializedStateMachine returns a state machine for node id of a fresh
+// network with the given initial state, along with the actions produced by
+// completing its initialization.
+func newInitializedStateMachine(id uint64, networkState *msgs.NetworkState) (*StateMachine, *ActionList) {
+	sm := &StateMachine{
+		Logger: logger.ConsoleErrorLogger,
+	}
+
+	return sm, initializeStateMachine(sm, id, networkState)
+}
+
+// initializeStateMachine initializes sm, which may have its hooks set, as
+// node id of a fresh network with the given initial state.
+func initializeStateMachine(sm *StateMachine, id uint64, networkState *msgs.NetworkState) *ActionList {
+	return loadStateMachine(sm, id, 1, initialLog(networkState))
+}
+
+// initialLog returns the write-ahead log of a node of a fresh network with
+// the given initial state, whose genesis epoch is led by all nodes.
+func initialLog(networkState *msgs.NetworkState) []*msgs.Persistent {
+	return genesisLog(networkState, &msgs.EpochConfig{
+		Number:  0,
+		Leaders: networkState.Config.Nodes,
+	})
+}
+
+// genesisLog returns the write-ahead log of a node of a fresh network with
+// the given initial state, bootstrapped with the given genesis epoch config.
+func genesisLog(networkState *msgs.NetworkState, genesis *msgs.EpochConfig) []*msgs.Persistent {
+	wal, err := GenesisLog(networkState, []byte("fake-initial-value"), genesis)
+	Expect(err).NotTo(HaveOccurred())
+	return wal
+}
+
+// loadStateMachine initializes sm as node id from the entries of its
+// write-ahead log, the first of which is at index firstIndex.
+func loadStateMachine(sm *StateMachine, id uint64, firstIndex uint64, wal []*msgs.Persistent) *ActionList {
+	sm.ApplyEvent(EventInitialize(&state.EventInitialParameters{
+		Id:                   id,
+		BatchSize:            1,
+		HeartbeatTicks:       2,
+		SuspectTicks:         4,
+		NewEpochTimeoutTicks: 8,
+		BufferSize:           4 * 1024 * 1024,
+	}))
+
+	for i, entry := range wal {
+		sm.ApplyEvent(EventLoadPersistedEntry(firstIndex+uint64(i), entry))
+	}
+
+	return sm.ApplyEvent(EventCompleteInitialization())
+}
+
+// testNetwork connects a set of state machines through a perfect in-memory
+// network, answering their hash and checkpoint requests synchronously.
+type testNetwork struct {
+	nodes    []*StateMachine
+	pending  []*ActionList
+	observed [][]*state.Action
+
+	// crashed marks the nodes which neither process events nor emit
+	// actions, see crash.
+	crashed []bool
+
+	// ticks is the number of ticks elapsed on the network clock.
+	ticks int
+
+	// drop, if set, selects messages which are lost in transit.
+	drop func(source, target uint64, msg *msgs.Msg) bool
+
+	// networkState is the initial state of the network, and genesis the
+	// genesis epoch config each node was bootstrapped with, see restart.
+	networkState *msgs.NetworkState
+	genesis      []*msgs.EpochConfig
+
+	// consumer satisfies the actions of the nodes other than sends.
+	consumer *mockConsumer
+}
+
+// mockConsumer satisfies every action a node requires a result for, so
+// that tests may focus on the protocol.  Hashes are computed with sha256,
+// checkpoint values are derived from the sequence number, and state
+// transfers complete with the network state of the checkpoint transferred
+// to.  The hooks inject failures per action type.
+type mockConsumer struct {
+	// pendingReconfigurations, if set, supplies the pending
+	// reconfigurations for the checkpoint result of a sequence.
+	pendingReconfigurations func(seqNo uint64) []*msgs.Reconfiguration
+
+	// corruptHash, if set, selects the hash requests of a node which are
+	// answered with a wrong digest.
+	corruptHash func(node uint64, hash *state.ActionHashRequest) bool
+
+	// corruptCheckpoint, if set, selects the checkpoint requests of a node
+	// which are answered with a wrong checkpoint value.
+	corruptCheckpoint func(node uint64, checkpoint *state.ActionCheckpoint) bool
+
+	// checkpointStates are the network states of the checkpoints computed,
+	// indexed by checkpoint value, to complete state transfers with.
+	checkpointStates map[string]*msgs.NetworkState
+}
+
+// consume appends the events resulting from node satisfying action to
+// events.  Sends, and actions which require no result, are ignored.
+func (mc *mockConsumer) consume(node uint64, action *state.Action, events *EventList) {
+	switch t := action.Type.(type) {
+	case *state.Action_Hash:
+		h := sha256.New()
+		for _, data := range t.Hash.Data {
+			h.Write(data)
+		}
+		if mc.corruptHash != nil && mc.corruptHash(node, t.Hash) {
+			h.Write([]byte("corrupt"))
+		}
+		events.HashResult(h.Sum(nil), t.Hash.Origin)
+	case *state.Action_Checkpoint:
+		var reconfigurations []*msgs.Reconfiguration
+		if mc.pendingReconfigurations != nil {
+			reconfigurations = mc.pendingReconfigurations(t.Checkpoint.SeqNo)
+		}
+		value := sha256.Sum256([]byte(fmt.Sprintf("checkpoint-%d", t.Checkpoint.SeqNo)))
+		if mc.corruptCheckpoint != nil && mc.corruptCheckpoint(node, t.Checkpoint) {
+			value = sha256.Sum256([]byte(fmt.Sprintf("corrupt-checkpoint-%d", t.Checkpoint.SeqNo)))
+		}
+		event := EventCheckpointResult(value[:], reconfigurations, t.Checkpoint)
+		mc.checkpointStates[string(value[:])] = event.Type.(*state.Event_CheckpointResult).CheckpointResult.NetworkState
+		events.PushBack(event)
+	case *state.Action_StateTransfer:
+		networkState, ok := mc.checkpointStates[string(t.StateTransfer.Value)]
+		Expect(ok).To(BeTrue(), "state transfer to unknown checkpoint seq_no=%d", t.StateTransfer.SeqNo)
+		events.StateTransferComplete(networkState, t.StateTransfer)
+	}
+}
+
+// newTestNetwork returns a network of nodeCount initialized nodes tolerating
+// f faults.  Each configure function is invoked on every node before it is
+// initialized, so that hooks may be set.
+func newTestNetwork(nodeCount, f int, configure ...func(sm *StateMachine)) *testNetwork {
+	return newTestNetworkFromState(standardNetworkState(nodeCount, f), configure...)
+}
+
+// newTestNetworkFromState returns a network of initialized nodes starting
+// from the given network state.
+func newTestNetworkFromState(networkState *msgs.NetworkState, configure ...func(sm *StateMachine)) *testNetwork {
+	genesis := make([]*msgs.EpochConfig, len(networkState.Config.Nodes))
+	for i := range genesis {
+		genesis[i] = &msgs.EpochConfig{
+			Number:  0,
+			Leaders: networkState.Config.Nodes,
+		}
+	}
+
+	return newTestNetworkFromGenesis(networkState, genesis, configure...)
+}
+
+// newTestNetworkFromGenesis returns a network of nodes bootstrapped from the
+// given network state, each with the genesis epoch config at its index.
+func newTestNetworkFromGenesis(networkState *msgs.NetworkState, genesis []*msgs.EpochConfig, configure ...func(sm *StateMachine)) *testNetwork {
+	nodeCount := len(networkState.Config.Nodes)
+	tn := &testNetwork{
+		nodes:    make([]*StateMachine, nodeCount),
+		pending:  make([]*ActionList, nodeCount),
+		observed: make([][]*state.Action, nodeCount),
+		crashed:  make([]bool, nodeCount),
+
+		networkState: networkState,
+		genesis:      genesis,
+		consumer: &mockConsumer{
+			checkpointStates: map[string]*msgs.NetworkState{},
+		},
+	}
+
+	for i := range tn.nodes {
+		tn.nodes[i] = &StateMachine{
+			Logger: logger.ConsoleErrorLogger,
+		}
+		for _, c := range configure {
+			c(tn.nodes[i])
+		}
+		tn.pending[i] = loadStateMachine(tn.nodes[i], uint64(i), 1, genesisLog(networkState, genesis[i]))
+	}
+
+	return tn
+}
+
+// apply applies an event to every node, then delivers all resulting
+// actions until the network is quiescent.
+func (tn *testNetwork) apply(event *state.Event) {
+	for i, sm := range tn.nodes {
+		if tn.crashed[i] {
+			continue
+		}
+		tn.pending[i].concat(sm.ApplyEvent(event))
+	}
+	tn.settle()
+}
+
+// crash stops node i, discarding its pending actions.  The node receives
+// no further events, so the others observe it as silent.
+func (tn *testNetwork) crash(i int) {
+	tn.crashed[i] = true
+	tn.pending[i] = &ActionList{}
+}
+
+func (tn *testNetwork) settle() {
+	for {
+		events := make([]*EventList, len(tn.nodes))
+		for i := range events {
+			events[i] = &EventList{}
+		}
+
+		quiescent := true
+		for i, actions := range tn.pending {
+			tn.pending[i] = &ActionList{}
+			iter := actions.Iterator()
+			for action := iter.Next(); action != nil; action = iter.Next() {
+				quiescent = false
+				tn.observed[i] = append(tn.observed[i], action)
+				send, ok := action.Type.(*state.Action_Send)
+				if !ok {
+					tn.consumer.consume(uint64(i), action, events[i])
+					continue
+				}
+				for _, target := range send.Send.Targets {
+					if tn.drop != nil && tn.drop(uint64(i), target, send.Send.Msg) {
+						continue
+					}
+					events[target].Step(uint64(i), send.Send.Msg)
+				}
+			}
+		}
+
+		if quiescent {
+			return
+		}
+
+		for i, sm := range tn.nodes {
+			if tn.crashed[i] {
+				continue
+			}
+			iter := events[i].Iterator()
+			for event := iter.Next(); event != nil; event = iter.Next() {
+				tn.pending[i].concat(sm.ApplyEvent(event))
+			}
+		}
+	}
+}
+
+// restart replaces node i with a fresh state machine loaded from the
+// write-ahead log node i has written, as if it had crashed once the network
+// was last quiescent, and restarted.
+func (tn *testNetwork) restart(i int) {
+	firstIndex := uint64(1)
+	wal := genesisLog(tn.networkState, tn.genesis[i])
+	for _, action := range tn.observed[i] {
+		switch t := action.Type.(type) {
+		case *state.Action_AppendWriteAhead:
+			Expect(t.AppendWriteAhead.Index).To(Equal(firstIndex + uint64(len(wal))))
+			wal = append(wal, t.AppendWriteAhead.Data)
+		case *state.Action_TruncateWriteAhead:
+			wal = wal[t.TruncateWriteAhead.Index-firstIndex:]
+			firstIndex = t.TruncateWriteAhead.Index
+		}
+	}
+
+	tn.nodes[i] = &StateMachine{
+		Logger: logger.ConsoleErrorLogger,
+	}
+	tn.crashed[i] = false
+	tn.pending[i] = loadStateMachine(tn.nodes[i], uint64(i), firstIndex, wal)
+}
+
+// tickUntil drives the network with a clock which advances only when the
+// network is quiescent, that is, once every action resulting from the
+// previous tick has been delivered.  Timeout driven behavior therefore
+// triggers without tests issuing ticks by hand, while the run remains
+// deterministic.  It fails if condition is not satisfied within maxTicks.
+func (tn *testNetwork) tickUntil(maxTicks int, condition func() bool) {
+	tn.settle()
+	for i := 0; !condition(); i++ {
+		Expect(i).To(BeNumerically("<", maxTicks), "condition not satisfied after %d ticks", maxTicks)
+		tn.ticks++
+		tn.apply(EventTickElapsed())
+	}
+}
+
+// inProgress returns whether every node has an epoch in progress.
+func (tn *testNetwork) inProgress() bool {
+	for _, sm := range tn.nodes {
+		if sm.epochTracker.currentEpoch.state != etInProgress {
+			return false
+		}
+	}
+	return true
+}
+
+// requestAck returns the ack of request reqNo of client clientID, as the
+// tests persist it.
+func requestAck(clientID, reqNo uint64) *msgs.RequestAck {
+	return &msgs.RequestAck{
+		ClientId: clientID,
+		ReqNo:    reqNo,
+		Digest:   []byte(fmt.Sprintf("request-digest-%d", reqNo)),
+	}
+}
+
+// persistRequests has every node persist the first count requests of
+// client 0.
+func (tn *testNetwork) persistRequests(count uint64) {
+	for reqNo := uint64(0); reqNo < count; reqNo++ {
+		tn.apply(EventRequestPersisted(requestAck(0, reqNo)))
+	}
+}
+
+// persistRequestsTicking is like persistRequests, but lets a tick elapse
+// after each request, so that batches are cut as the requests arrive.
+func (tn *testNetwork) persistRequestsTicking(count uint64) {
+	for reqNo := uint64(0); reqNo < count; reqNo++ {
+		tn.apply(EventRequestPersisted(requestAck(0, reqNo)))
+		tn.apply(EventTickElapsed())
+	}
+}
+
+// commits returns the commits observed at node i, in delivery order.
+func (tn *testNetwork) commits(i int) []*state.ActionCommit {
+	var result []*state.ActionCommit
+	for _, action := range tn.observed[i] {
+		if commit, ok := action.Type.(*state.Action_Commit); ok {
+			result = append(result, commit.Commit)
+		}
+	}
+	return result
+}
+
+// committedSeqNos returns the sequences committed at node i, in delivery
+// order.
+func (tn *testNetwork) committedSeqNos(i int) []uint64 {
+	seqNos := []uint64{}
+	for _, commit := range tn.commits(i) {
+		seqNos = append(seqNos, commit.Batch.SeqNo)
+	}
+	return seqNos
+}
+
+var _ = Describe("StateMachine", func() {
+	DescribeTable("Status reports the progress quorum",
+		func(nodeCount, f int) {
+			sm, _ := newInitializedStateMachine(0, standardNetworkState(nodeCount, f))
+
+			status, err := sm.Status()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.ProgressQuorum).To(Equal(2*f + 1))
+		},
+		Entry("a single node", 1, 0),
+		Entry("four nodes", 4, 1),
+		Entry("seven nodes", 7, 2),
+	)
+
+	It("reports the sources of the checkpoints still pending", func() {
+		sm, _ := newInitializedStateMachine(0, standardNetworkState(4, 1))
+
+		for _, source := range []uint64{1, 2} {
+			sm.ApplyEvent(EventStep(source, &msgs.Msg{
+				Type: &msgs.Msg_Checkpoint{
+					Checkpoint: &msgs.Checkpoint{
+						SeqNo: 20,
+						Value: []byte("checkpoint-value"),
+					},
+				},
+			}))
+		}
+
+		status, err := sm.Status()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.PendingCheckpoints).To(HaveLen(1))
+		Expect(status.PendingCheckpoints[0].SeqNo).To(Equal(uint64(20)))
+		Expect(status.PendingCheckpoints[0].Values).To(Equal(2))
+		Expect(status.PendingCheckpoints[0].Sources).To(Equal([]uint64{1, 2}))
+	})
+
+	It("hashes the batches it proposes only once", func() {
+		tn := newTestNetwork(1, 0)
+		tn.tickUntil(10, tn.inProgress)
+
+		tn.observed[0] = nil
+		tn.apply(EventRequestPersisted(requestAck(0, 0)))
+		for i := 0; i < 5; i++ {
+			tn.apply(EventTickElapsed())
+		}
+
+		batchHashes := 0
+		commits := 0
+		for _, action := range tn.observed[0] {
+			switch t := action.Type.(type) {
+			case *state.Action_Hash:
+				if _, ok := t.Hash.Origin.Type.(*state.HashOrigin_Batch_); ok {
+					batchHashes++
+				}
+			case *state.Action_Commit:
+				if len(t.Commit.Batch.Requests) > 0 {
+					commits++
+				}
+			}
+		}
+		Expect(commits).To(Equal(1))
+		Expect(batchHashes).To(Equal(1))
+	})
+
+	It("proposes each sequence from the leader of the bucket SeqToBucket maps it to", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+
+		tn.persistRequests(16)
+		tn.tickUntil(20, func() bool {
+			return tn.nodes[0].commitState.highestCommit >= 16
+		})
+
+		activeEpoch := tn.nodes[0].epochTracker.currentEpoch.activeEpoch
+		numBuckets := uint32(activeEpoch.networkConfig.NumberOfBuckets)
+
+		proposed := map[uint64]struct{}{}
+		for i := range tn.nodes {
+			for _, action := range tn.observed[i] {
+				send, ok := action.Type.(*state.Action_Send)
+				if !ok {
+					continue
+				}
+				preprepare, ok := send.Send.Msg.Type.(*msgs.Msg_Preprepare)
+				if !ok || preprepare.Preprepare.Epoch != activeEpoch.epochConfig.Number {
+					continue
+				}
+
+				seqNo := preprepare.Preprepare.SeqNo
+				bucket := SeqToBucket(seqNo, numBuckets)
+				Expect(bucket).To(Equal(uint32(seqToBucket(seqNo, activeEpoch.networkConfig))))
+				Expect(activeEpoch.buckets[bucketID(bucket)]).To(Equal(nodeID(i)))
+				proposed[seqNo] = struct{}{}
+			}
+		}
+		for seqNo := uint64(1); seqNo <= 16; seqNo++ {
+			Expect(proposed).To(HaveKey(seqNo))
+		}
+	})
+
+	It("batches the requests of a vetoed batch again", func() {
+		validated := 0
+		tn := newTestNetwork(4, 1, func(sm *StateMachine) {
+			sm.BatchValidator = func(batch []*msgs.RequestAck) error {
+				validated++
+				if validated == 1 {
+					return fmt.Errorf("conflicting requests")
+				}
+				return nil
+			}
+		})
+		tn.tickUntil(20, tn.inProgress)
+
+		tn.apply(EventRequestPersisted(requestAck(0, 0)))
+
+		tn.tickUntil(20, func() bool {
+			for _, sm := range tn.nodes {
+				if sm.commitState.highestCommit < 4 {
+					return false
+				}
+			}
+			return true
+		})
+		Expect(validated).To(Equal(2))
+
+		for i := range tn.nodes {
+			committed := 0
+			for _, commit := range tn.commits(i) {
+				committed += len(commit.Batch.Requests)
+			}
+			Expect(committed).To(Equal(1))
+		}
+	})
+
+	It("commits batches sent compressed by the codec of the network", func() {
+		networkState := standardNetworkState(4, 1)
+		networkState.Config.BatchCompression = "flate"
+		tn := newTestNetworkFromState(networkState, func(sm *StateMachine) {
+			sm.BatchCodec = flateCodec{}
+		})
+		tn.tickUntil(20, tn.inProgress)
+
+		tn.apply(EventRequestPersisted(requestAck(0, 0)))
+
+		tn.tickUntil(20, func() bool {
+			for _, sm := range tn.nodes {
+				if sm.commitState.highestCommit < 4 {
+					return false
+				}
+			}
+			return true
+		})
+
+		compressed := 0
+		for _, action := range tn.observed[1] {
+			send, ok := action.Type.(*state.Action_Send)
+			if !ok {
+				continue
+			}
+			if pp, ok := send.Send.Msg.Type.(*msgs.Msg_Preprepare); ok && pp.Preprepare.SeqNo == 4 {
+				Expect(pp.Preprepare.Batch).To(BeEmpty())
+				Expect(pp.Preprepare.CompressedBatch).NotTo(BeEmpty())
+				compressed++
+			}
+		}
+		Expect(compressed).To(Equal(1))
+
+		for i := range tn.nodes {
+			var digests [][]byte
+			for _, commit := range tn.commits(i) {
+				for _, ack := range commit.Batch.Requests {
+					digests = append(digests, ack.Digest)
+				}
+			}
+			Expect(digests).To(Equal([][]byte{requestAck(0, 0).Digest}))
+		}
+	})
+
+	Describe("delivering a node's own messages", func() {
+		var tn *testNetwork
+
+		BeforeEach(func() {
+			tn = newTestNetwork(4, 1)
+		})
+
+		// commitRequest commits a request, and returns the sends observed
+		// meanwhile, indexed by the sending node.
+		commitRequest := func() [][]*state.ActionSend {
+			observed := make([]int, len(tn.nodes))
+			for i := range tn.nodes {
+				observed[i] = len(tn.observed[i])
+			}
+
+			tn.apply(EventRequestPersisted(requestAck(0, 0)))
+			tn.tickUntil(20, func() bool {
+				for _, sm := range tn.nodes {
+					if sm.commitState.highestCommit < 4 {
+						return false
+					}
+				}
+				return true
+			})
+
+			sends := make([][]*state.ActionSend, len(tn.nodes))
+			for i := range tn.nodes {
+				for _, action := range tn.observed[i][observed[i]:] {
+					if send, ok := action.Type.(*state.Action_Send); ok {
+						sends[i] = append(sends[i], send.Send)
+					}
+				}
+			}
+			return sends
+		}
+
+		expectCommitted := func() {
+			for i := range tn.nodes {
+				iter, err := tn.nodes[i].CommittedSince(4)
+				Expect(err).NotTo(HaveOccurred())
+				qEntry := iter.Next()
+				Expect(qEntry).NotTo(BeNil())
+				Expect(qEntry.Requests).To(HaveLen(1))
+				Expect(qEntry.Requests[0].Digest).To(Equal(requestAck(0, 0).Digest))
+			}
+		}
+
+		It("reaches commit when the transport echoes the messages", func() {
+			tn.tickUntil(20, tn.inProgress)
+			sends := commitRequest()
+			expectCommitted()
+
+			for i := range tn.nodes {
+				targetsSelf := false
+				for _, send := range sends[i] {
+					if _, ok := send.Msg.Type.(*msgs.Msg_Prepare); ok {
+						Expect(send.Targets).To(ContainElement(uint64(i)))
+						targetsSelf = true
+					}
+				}
+				if i != 1 {
+					Expect(targetsSelf).To(BeTrue())
+				}
+			}
+		})
+
+		It("reaches commit when each node delivers its messages to itself", func() {
+			for _, sm := range tn.nodes {
+				sm.myConfig.DisableSelfEcho = true
+			}
+			tn.tickUntil(20, tn.inProgress)
+
+			sends := commitRequest()
+			expectCommitted()
+
+			for i := range tn.nodes {
+				Expect(sends[i]).NotTo(BeEmpty())
+				for _, send := range sends[i] {
+					Expect(send.Targets).NotTo(ContainElement(uint64(i)))
+					Expect(send.Targets).NotTo(BeEmpty())
+				}
+			}
+		})
+	})
+
+	It("records the sources of the prepares and commits forming a quorum", func() {
+		tn := newTestNetwork(4, 1)
+		tn.drop = func(source, target uint64, msg *msgs.Msg) bool {
+			if source != 3 || target != 0 {
+				return false
+			}
+			switch innerMsg := msg.Type.(type) {
+			case *msgs.Msg_Prepare:
+				return innerMsg.Prepare.SeqNo == 4
+			case *msgs.Msg_Commit:
+				return innerMsg.Commit.SeqNo == 4
+			}
+			return false
+		}
+
+		tn.tickUntil(20, tn.inProgress)
+		tn.apply(EventRequestPersisted(requestAck(0, 0)))
+		tn.tickUntil(20, func() bool {
+			for _, sm := range tn.nodes {
+				if sm.commitState.highestCommit < 4 {
+					return false
+				}
+			}
+			return true
+		})
+
+		prepares, commits, err := tn.nodes[0].QuorumSources(4)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prepares).To(Equal([]uint64{0, 1, 2}))
+		Expect(commits).To(Equal([]uint64{0, 1, 2}))
+
+		prepares, commits, err = tn.nodes[3].QuorumSources(4)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prepares).To(Equal([]uint64{0, 1, 2, 3}))
+		Expect(commits).To(Equal([]uint64{0, 1, 2, 3}))
+
+		_, _, err = tn.nodes[0].QuorumSources(1000)
+		Expect(err).To(MatchError(HavePrefix("seq_no=1000 is outside the watermarks")))
+	})
+
+	It("reports the highest sequence it holds a prepared certificate for", func() {
+		tn := newTestNetwork(4, 1)
+		tn.drop = func(source, target uint64, msg *msgs.Msg) bool {
+			_, ok := msg.Type.(*msgs.Msg_Commit)
+			return ok && target == 0
+		}
+
+		Expect(tn.nodes[0].HighestPrepared()).To(Equal(uint64(0)))
+
+		tn.tickUntil(20, tn.inProgress)
+		tn.apply(EventRequestPersisted(requestAck(0, 0)))
+		tn.tickUntil(20, func() bool {
+			return tn.nodes[1].commitState.highestCommit >= 4
+		})
+
+		// Node 0 prepares the sequences, but receives no commits for them.
+		Expect(tn.nodes[0].commitState.highestCommit).To(Equal(uint64(0)))
+		Expect(tn.nodes[0].HighestPrepared()).To(Equal(tn.nodes[1].HighestPrepared()))
+		Expect(tn.nodes[0].HighestPrepared()).To(BeNumerically(">=", 4))
+
+		s, err := tn.nodes[0].Status()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.HighestPrepared).To(Equal(tn.nodes[0].HighestPrepared()))
+	})
+
+	It("refuses to commit a sequence whose persisted entries disagree", func() {
+		tn := newTestNetwork(4, 1)
+		tn.drop = func(source, target uint64, msg *msgs.Msg) bool {
+			_, ok := msg.Type.(*msgs.Msg_Commit)
+			return ok && target == 2
+		}
+		tn.tickUntil(20, tn.inProgress)
+		tn.apply(EventRequestPersisted(requestAck(0, 0)))
+
+		seq := tn.nodes[2].epochTracker.currentEpoch.activeEpoch.sequence(1)
+		tn.tickUntil(20, func() bool {
+			return seq.state == sequencePrepared
+		})
+
+		// Corrupt the PEntry of the sequence, then deliver the commits.
+		seq.pEntry.Digest = []byte("corrupt-digest")
+		actions := &ActionList{}
+		for _, source := range []uint64{0, 1, 2, 3} {
+			actions.concat(tn.nodes[2].ApplyEvent(EventStep(source, &msgs.Msg{
+				Type: &msgs.Msg_Commit{
+					Commit: &msgs.Commit{
+						SeqNo:  1,
+						Epoch:  1,
+						Digest: seq.digest,
+					},
+				},
+			})))
+		}
+
+		var reasons []string
+		iter := actions.Iterator()
+		for action := iter.Next(); action != nil; action = iter.Next() {
+			if unrecoverable, ok := action.Type.(*state.Action_Unrecoverable); ok {
+				reasons = append(reasons, unrecoverable.Unrecoverable.Reason)
+			}
+		}
+		Expect(reasons).To(HaveLen(1))
+		Expect(reasons[0]).To(HavePrefix("seq_no=1 has a QEntry with digest"))
+		Expect(seq.state).To(Equal(sequenceCommitted))
+		Expect(tn.nodes[2].commitState.highestCommit).To(Equal(uint64(0)))
+	})
+
+	It("exports the prepared certificate of a sequence", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+		tn.apply(EventRequestPersisted(requestAck(0, 0)))
+		tn.tickUntil(20, func() bool {
+			return tn.nodes[2].commitState.highestCommit >= 4
+		})
+
+		activeEpoch := tn.nodes[2].epochTracker.currentEpoch.activeEpoch
+		leader := uint64(activeEpoch.buckets[activeEpoch.seqToBucket(4)])
+		certificate, err := tn.nodes[2].PreparedCertificate(4)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(certificate.PEntry.SeqNo).To(Equal(uint64(4)))
+		Expect(certificate.Preprepare.SeqNo).To(Equal(uint64(4)))
+		Expect(certificate.Preprepare.Batch).To(HaveLen(1))
+		Expect(certificate.Preprepare.Batch[0].Digest).To(Equal(requestAck(0, 0).Digest))
+
+		// The preprepare and the prepares must form an intersection quorum
+		// for the digest of the batch.
+		Expect(certificate.Prepares).NotTo(HaveKey(leader))
+		Expect(len(certificate.Prepares) + 1).To(BeNumerically(">=", 3))
+		for _, prepare := range certificate.Prepares {
+			Expect(prepare.SeqNo).To(Equal(uint64(4)))
+			Expect(prepare.Epoch).To(Equal(certificate.Preprepare.Epoch))
+			Expect(prepare.Digest).To(Equal(certificate.PEntry.Digest))
+		}
+
+		_, err = tn.nodes[2].PreparedCertificate(activeEpoch.highWatermark())
+		Expect(err).To(MatchError(fmt.Sprintf("seq_no=%d has not prepared", activeEpoch.highWatermark())))
+
+		_, err = tn.nodes[2].PreparedCertificate(1000)
+		Expect(err).To(MatchError(HavePrefix("seq_no=1000 is outside the watermarks")))
+	})
+
+	It("reports a follower which never responds as lagging", func() {
+		tn := newTestNetwork(4, 1)
+		tn.drop = func(source, target uint64, msg *msgs.Msg) bool {
+			return source == 3 && target == 1
+		}
+
+		followers := func() []*status.Follower {
+			s, err := tn.nodes[1].Status()
+			Expect(err).NotTo(HaveOccurred())
+			return s.Followers
+		}
+
+		tn.tickUntil(20, tn.inProgress)
+		tn.apply(EventRequestPersisted(requestAck(0, 0)))
+		Expect(followers()).To(Equal([]*status.Follower{
+			{ID: 0, Missing: 0},
+			{ID: 2, Missing: 0},
+			{ID: 3, Missing: 1},
+		}))
+
+		tn.tickUntil(100, func() bool {
+			for _, follower := range followers() {
+				if follower.Lagging {
+					return true
+				}
+			}
+			return false
+		})
+		Expect(followers()).To(Equal([]*status.Follower{
+			{ID: 0, Missing: 0},
+			{ID: 2, Missing: 0},
+			{ID: 3, Missing: defaultLaggingFollowerThreshold, Lagging: true},
+		}))
+	})
+
+	It("reports a node behind the checkpoints of its peers as not caught up", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+		Expect(tn.nodes[3].IsCaughtUp()).To(BeTrue())
+
+		// Node 3 hears of the checkpoints of its peers, but the
+		// messages it needs to commit are delayed.
+		type delayedMsg struct {
+			source uint64
+			msg    *msgs.Msg
+		}
+		var delayed []delayedMsg
+		tn.drop = func(source, target uint64, msg *msgs.Msg) bool {
+			if _, ok := msg.Type.(*msgs.Msg_Checkpoint); ok || target != 3 || source == 3 {
+				return false
+			}
+			delayed = append(delayed, delayedMsg{source: source, msg: msg})
+			return true
+		}
+
+		tn.tickUntil(100, func() bool {
+			return tn.nodes[3].checkpointTracker.highestNetworkCheckpoint() > 0
+		})
+		caughtUp := func(i int) bool {
+			s, err := tn.nodes[i].Status()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s.CaughtUp).To(Equal(tn.nodes[i].IsCaughtUp()))
+			return s.CaughtUp
+		}
+
+		Expect(caughtUp(0)).To(BeTrue())
+		Expect(caughtUp(3)).To(BeFalse())
+
+		tn.drop = nil
+		for _, d := range delayed {
+			tn.pending[3].concat(tn.nodes[3].ApplyEvent(EventStep(d.source, d.msg)))
+		}
+		tn.settle()
+		Expect(caughtUp(3)).To(BeTrue())
+	})
+
+	Describe("bootstrapping from a genesis epoch config", func() {
+		var (
+			networkState *msgs.NetworkState
+			genesis      []*msgs.EpochConfig
+		)
+
+		BeforeEach(func() {
+			networkState = standardNetworkState(4, 1)
+			genesis = make([]*msgs.EpochConfig, 4)
+			for i := range genesis {
+				genesis[i] = &msgs.EpochConfig{
+					Number:  0,
+					Leaders: []uint64{0, 1, 2},
+				}
+			}
+		})
+
+		It("leads the first epoch with the leaders of the genesis epoch", func() {
+			tn := newTestNetworkFromGenesis(networkState, genesis)
+			tn.tickUntil(20, tn.inProgress)
+
+			for _, sm := range tn.nodes {
+				activeEpoch := sm.epochTracker.currentEpoch.activeEpoch
+				Expect(activeEpoch.epochConfig.Number).To(Equal(uint64(1)))
+				Expect(activeEpoch.epochConfig.Leaders).To(Equal([]uint64{0, 1, 2}))
+				Expect(activeEpoch.leads(3)).To(BeFalse())
+			}
+
+			tn.apply(EventRequestPersisted(requestAck(0, 0)))
+			tn.tickUntil(20, func() bool {
+				for _, sm := range tn.nodes {
+					if sm.commitState.highestCommit < 4 {
+						return false
+					}
+				}
+				return true
+			})
+		})
+
+		It("ignores the epoch change of a node bootstrapped with a different genesis", func() {
+			genesis[3] = &msgs.EpochConfig{
+				Number:  0,
+				Leaders: []uint64{1, 2, 3},
+			}
+			tn := newTestNetworkFromGenesis(networkState, genesis)
+			tn.tickUntil(20, func() bool {
+				for _, sm := range tn.nodes[:3] {
+					if sm.epochTracker.currentEpoch.state != etInProgress {
+						return false
+					}
+				}
+				return true
+			})
+
+			for _, sm := range tn.nodes[:3] {
+				Expect(sm.epochTracker.currentEpoch.changes).NotTo(HaveKey(nodeID(3)))
+			}
+			Expect(tn.nodes[3].epochTracker.currentEpoch.state).NotTo(Equal(etInProgress))
+		})
+
+		It("rejects genesis epoch configs which are not for epoch 0", func() {
+			_, err := GenesisLog(networkState, []byte("initial-value"), &msgs.EpochConfig{
+				Number:  1,
+				Leaders: []uint64{0},
+			})
+			Expect(err).To(MatchError("genesis epoch config must be for epoch 0, not epoch 1"))
+		})
+
+		It("rejects genesis epoch configs led by nodes outside the network", func() {
+			_, err := GenesisLog(networkState, []byte("initial-value"), &msgs.EpochConfig{
+				Number:  0,
+				Leaders: []uint64{0, 4},
+			})
+			Expect(err).To(MatchError("genesis epoch config leader 4 is not a node of the network"))
+		})
+
+		It("rejects network configurations without nodes", func() {
+			networkState.Config.Nodes = nil
+			_, err := GenesisLog(networkState, []byte("initial-value"), &msgs.EpochConfig{
+				Number:  0,
+				Leaders: []uint64{0},
+			})
+			Expect(err).To(MatchError("network configuration must have at least one node"))
+		})
+
+		It("rejects network configurations which cannot tolerate their faults", func() {
+			networkState.Config.F = 2
+			_, err := GenesisLog(networkState, []byte("initial-value"), &msgs.EpochConfig{
+				Number:  0,
+				Leaders: []uint64{0},
+			})
+			Expect(err).To(MatchError("network of 4 nodes cannot tolerate f=2 faults, at least 7 nodes are required, or f may be at most 1"))
+		})
+
+		It("rejects network configurations whose learners are already nodes", func() {
+			networkState.Config.Learners = []uint64{3}
+			_, err := GenesisLog(networkState, []byte("initial-value"), &msgs.EpochConfig{
+				Number:  0,
+				Leaders: []uint64{0},
+			})
+			Expect(err).To(MatchError("learner 3 is already a node, or listed twice"))
+		})
+	})
+
+	It("checkpoints across several intervals as a single node", func() {
+		networkState := standardNetworkState(1, 0)
+		networkState.Config.CheckpointInterval = 2
+		tn := newTestNetworkFromState(networkState)
+		tn.tickUntil(20, tn.inProgress)
+
+		tn.persistRequests(3)
+		tn.tickUntil(50, func() bool {
+			return tn.nodes[0].commitState.lowWatermark >= 8
+		})
+
+		var checkpoints, commits []uint64
+		committed := 0
+		for _, action := range tn.observed[0] {
+			switch t := action.Type.(type) {
+			case *state.Action_Checkpoint:
+				checkpoints = append(checkpoints, t.Checkpoint.SeqNo)
+			case *state.Action_Commit:
+				commits = append(commits, t.Commit.Batch.SeqNo)
+				committed += len(t.Commit.Batch.Requests)
+			}
+		}
+		Expect(checkpoints[:4]).To(Equal([]uint64{2, 4, 6, 8}))
+		for i, seqNo := range commits {
+			Expect(seqNo).To(Equal(uint64(i + 1)))
+		}
+		Expect(committed).To(Equal(3))
+		Expect(tn.nodes[0].checkpointTracker.lowWatermark()).To(BeNumerically(">=", 8))
+	})
+
+	It("reports the clients whose state is pruned once a checkpoint is stable", func() {
+		networkState := standardNetworkState(4, 1)
+		networkState.Clients = append(networkState.Clients, &msgs.NetworkState_Client{Id: 1, Width: 100})
+		tn := newTestNetworkFromState(networkState)
+		tn.tickUntil(20, tn.inProgress)
+
+		tn.persistRequests(3)
+		tn.tickUntil(100, func() bool {
+			return tn.nodes[0].checkpointTracker.lowWatermark() >= 20
+		})
+
+		var pruned []*state.ActionClientStatePruned
+		for _, action := range tn.observed[0] {
+			if t, ok := action.Type.(*state.Action_ClientStatePruned); ok {
+				pruned = append(pruned, t.ClientStatePruned)
+			}
+		}
+
+		// Only client 0 committed requests, so only its state is pruned.
+		Expect(pruned).To(HaveLen(1))
+		Expect(pruned[0].SeqNo).To(Equal(uint64(20)))
+		Expect(pruned[0].Clients).To(HaveLen(1))
+		Expect(pruned[0].Clients[0].Id).To(Equal(uint64(0)))
+		Expect(pruned[0].Clients[0].LowWatermark).To(Equal(uint64(3)))
+	})
+
+	It("reports a client added since the earliest checkpoint as pruned from zero", func() {
+		sm := &StateMachine{persisted: newPersisted(logger.ConsoleErrorLogger)}
+		for i, cEntry := range []*msgs.CEntry{
+			{
+				SeqNo: 20,
+				NetworkState: &msgs.NetworkState{
+					Clients: []*msgs.NetworkState_Client{{Id: 0, LowWatermark: 3}},
+				},
+			},
+			{
+				SeqNo: 40,
+				NetworkState: &msgs.NetworkState{
+					Clients: []*msgs.NetworkState_Client{{Id: 0, LowWatermark: 3}, {Id: 1, LowWatermark: 2}},
+				},
+			},
+		} {
+			sm.persisted.appendInitialLoad(uint64(i), &msgs.Persistent{
+				Type: &msgs.Persistent_CEntry{
+					CEntry: cEntry,
+				},
+			})
+		}
+
+		pruned := sm.prunedClients(40)
+		Expect(pruned).To(HaveLen(1))
+		Expect(pruned[0].Id).To(Equal(uint64(1)))
+	})
+
+	It("reports the request watermarks of every client", func() {
+		networkState := standardNetworkState(4, 1)
+		networkState.Clients = append(networkState.Clients,
+			&msgs.NetworkState_Client{Id: 1, Width: 50},
+			&msgs.NetworkState_Client{Id: 2, Width: 20, LowWatermark: 10},
+		)
+		tn := newTestNetworkFromState(networkState)
+		tn.tickUntil(20, tn.inProgress)
+
+		for _, ack := range []*msgs.RequestAck{
+			{ClientId: 0, ReqNo: 0, Digest: []byte("client-0-request-0")},
+			{ClientId: 1, ReqNo: 0, Digest: []byte("client-1-request-0")},
+			{ClientId: 1, ReqNo: 1, Digest: []byte("client-1-request-1")},
+			{ClientId: 2, ReqNo: 10, Digest: []byte("client-2-request-10")},
+		} {
+			tn.apply(EventRequestPersisted(ack))
+		}
+		tn.tickUntil(20, func() bool {
+			for _, sm := range tn.nodes {
+				if sm.commitState.lastAppliedCommit < 8 {
+					return false
+				}
+			}
+			return true
+		})
+
+		s, err := tn.nodes[0].Status()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.ClientWindows).To(HaveLen(3))
+		for i, expected := range []struct {
+			id, low, high uint64
+		}{
+			{0, 0, 100},
+			{1, 0, 50},
+			{2, 10, 30},
+		} {
+			Expect(s.ClientWindows[i].ClientID).To(Equal(expected.id))
+			Expect(s.ClientWindows[i].LowWatermark).To(Equal(expected.low))
+			Expect(s.ClientWindows[i].HighWatermark).To(Equal(expected.high))
+		}
+	})
+
+	Describe("a preprepare retransmitted by a restarted leader", func() {
+		var (
+			tn         *testNetwork
+			preprepare *msgs.Preprepare
+		)
+
+		BeforeEach(func() {
+			tn = newTestNetwork(4, 1)
+			tn.tickUntil(20, tn.inProgress)
+			tn.apply(EventRequestPersisted(requestAck(0, 0)))
+
+			for _, action := range tn.observed[1] {
+				send, ok := action.Type.(*state.Action_Send)
+				if !ok {
+					continue
+				}
+				if pp, ok := send.Send.Msg.Type.(*msgs.Msg_Preprepare); ok && len(pp.Preprepare.Batch) > 0 {
+					preprepare = pp.Preprepare
+				}
+			}
+			Expect(preprepare).NotTo(BeNil())
+		})
+
+		suspects := func(actions *ActionList) bool {
+			iter := actions.Iterator()
+			for action := iter.Next(); action != nil; action = iter.Next() {
+				send, ok := action.Type.(*state.Action_Send)
+				if !ok {
+					continue
+				}
+				if _, ok := send.Send.Msg.Type.(*msgs.Msg_Suspect); ok {
+					return true
+				}
+			}
+			return false
+		}
+
+		It("is accepted silently when identical to the one applied", func() {
+			actions := tn.nodes[2].ApplyEvent(EventStep(1, &msgs.Msg{
+				Type: &msgs.Msg_Preprepare{
+					Preprepare: preprepare,
+				},
+			}))
+			Expect(suspects(actions)).To(BeFalse())
+			Expect(tn.nodes[2].epochTracker.currentEpoch.state).To(BeEquivalentTo(etInProgress))
+		})
+
+		It("is accepted silently when its acks carry fields unknown to the follower", func() {
+			batch := make([]*msgs.RequestAck, len(preprepare.Batch))
+			for i, ack := range preprepare.Batch {
+				batch[i] = proto.Clone(ack).(*msgs.RequestAck)
+				unknown := protowire.AppendTag(nil, 15, protowire.VarintType)
+				batch[i].ProtoReflect().SetUnknown(protowire.AppendVarint(unknown, 1))
+			}
+
+			actions := tn.nodes[2].ApplyEvent(EventStep(1, &msgs.Msg{
+				Type: &msgs.Msg_Preprepare{
+					Preprepare: &msgs.Preprepare{
+						SeqNo: preprepare.SeqNo,
+						Epoch: preprepare.Epoch,
+						Batch: batch,
+					},
+				},
+			}))
+			Expect(suspects(actions)).To(BeFalse())
+			Expect(tn.nodes[2].epochTracker.currentEpoch.state).To(BeEquivalentTo(etInProgress))
+		})
+
+		It("is treated as equivocation when its batch differs", func() {
+			actions := tn.nodes[2].ApplyEvent(EventStep(1, &msgs.Msg{
+				Type: &msgs.Msg_Preprepare{
+					Preprepare: &msgs.Preprepare{
+						SeqNo: preprepare.SeqNo,
+						Epoch: preprepare.Epoch,
+						Batch: []*msgs.RequestAck{
+							{
+								ClientId: 0,
+								ReqNo:    0,
+								Digest:   []byte("other-request-digest"),
+							},
+						},
+					},
+				},
+			}))
+			Expect(suspects(actions)).To(BeTrue())
+		})
+	})
+
+	It("counts the messages received and sent by type", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+
+		counts := func(i int) (received, sent map[string]uint64) {
+			status, err := tn.nodes[i].Status()
+			Expect(err).NotTo(HaveOccurred())
+			return status.MessagesReceived, status.MessagesSent
+		}
+
+		receivedBefore := make([]map[string]uint64, len(tn.nodes))
+		sentBefore := make([]map[string]uint64, len(tn.nodes))
+		for i := range tn.nodes {
+			receivedBefore[i], sentBefore[i] = counts(i)
+		}
+
+		// Request 0 maps to bucket 0, led by node 1 in epoch 1, and
+		// commits at sequence 4 without any tick.
+		tn.apply(EventRequestPersisted(requestAck(0, 0)))
+		Expect(tn.nodes[0].epochTracker.currentEpoch.activeEpoch.sequence(4).state).To(Equal(sequenceCommitted))
+
+		for i := range tn.nodes {
+			received, sent := counts(i)
+			delta := func(after, before map[string]uint64, msgType string) uint64 {
+				return after[msgType] - before[msgType]
+			}
+
+			// Every node broadcasts its ack and commit, the leader its
+			// preprepare and every other node its prepare.
+			Expect(delta(received, receivedBefore[i], "request_ack")).To(Equal(uint64(4)))
+			Expect(delta(received, receivedBefore[i], "preprepare")).To(Equal(uint64(1)))
+			Expect(delta(received, receivedBefore[i], "prepare")).To(Equal(uint64(3)))
+			Expect(delta(received, receivedBefore[i], "commit")).To(Equal(uint64(4)))
+			Expect(delta(received, receivedBefore[i], "epoch_change")).To(BeZero())
+
+			Expect(delta(sent, sentBefore[i], "request_ack")).To(Equal(uint64(4)))
+			Expect(delta(sent, sentBefore[i], "commit")).To(Equal(uint64(4)))
+			if i == 1 {
+				Expect(delta(sent, sentBefore[i], "preprepare")).To(Equal(uint64(4)))
+				Expect(delta(sent, sentBefore[i], "prepare")).To(BeZero())
+			} else {
+				Expect(delta(sent, sentBefore[i], "preprepare")).To(BeZero())
+				Expect(delta(sent, sentBefore[i], "prepare")).To(Equal(uint64(4)))
+			}
+		}
+	})
+
+	It("forwards the requests of a batch missing at a node together", func() {
+		networkState := standardNetworkState(4, 1)
+		for _, clientID := range []uint64{4, 8} {
+			networkState.Clients = append(networkState.Clients, &msgs.NetworkState_Client{
+				Id:    clientID,
+				Width: 100,
+			})
+		}
+		tn := newTestNetworkFromState(networkState)
+		tn.nodes[1].myConfig.BatchSize = 3
+		tn.tickUntil(20, tn.inProgress)
+
+		// The first requests of clients 0, 4 and 8 map to bucket 0, led by
+		// node 1 in epoch 1, node 3 never receives them.
+		for _, clientID := range []uint64{0, 4, 8} {
+			for _, i := range []int{0, 1, 2} {
+				tn.pending[i].concat(tn.nodes[i].ApplyEvent(EventRequestPersisted(&msgs.RequestAck{
+					ClientId: clientID,
+					ReqNo:    0,
+					Digest:   []byte(fmt.Sprintf("request-digest-%d", clientID)),
+				})))
+			}
+		}
+
+		tn.tickUntil(20, func() bool {
+			return tn.nodes[1].commitState.highestCommit >= 4
+		})
+
+		var forwards []*state.ActionForward
+		for _, action := range tn.observed[1] {
+			if forward, ok := action.Type.(*state.Action_ForwardRequest); ok {
+				forwards = append(forwards, forward.ForwardRequest)
+			}
+		}
+		Expect(forwards).To(HaveLen(1))
+		Expect(forwards[0].Targets).To(Equal([]uint64{3}))
+
+		var clientIDs []uint64
+		for _, ack := range forwards[0].Acks {
+			clientIDs = append(clientIDs, ack.ClientId)
+		}
+		Expect(clientIDs).To(ConsistOf(uint64(0), uint64(4), uint64(8)))
+	})
+
+	It("forwards the requests its bucket leader lacks together", func() {
+		networkState := standardNetworkState(4, 1)
+		for _, clientID := range []uint64{4, 8} {
+			networkState.Clients = append(networkState.Clients, &msgs.NetworkState_Client{
+				Id:    clientID,
+				Width: 100,
+			})
+		}
+		tn := newTestNetworkFromState(networkState)
+		tn.tickUntil(20, tn.inProgress)
+
+		// The first requests of clients 0, 4 and 8 map to bucket 0, led by
+		// node 1 in epoch 1, only node 0 receives them.
+		for _, clientID := range []uint64{0, 4, 8} {
+			tn.pending[0].concat(tn.nodes[0].ApplyEvent(EventRequestPersisted(&msgs.RequestAck{
+				ClientId: clientID,
+				ReqNo:    0,
+				Digest:   []byte(fmt.Sprintf("request-digest-%d", clientID)),
+			})))
+		}
+
+		forwards := func() []*state.ActionForward {
+			var result []*state.ActionForward
+			for _, action := range tn.observed[0] {
+				if forward, ok := action.Type.(*state.Action_ForwardRequest); ok {
+					result = append(result, forward.ForwardRequest)
+				}
+			}
+			return result
+		}
+
+		tn.tickUntil(20, func() bool {
+			return len(forwards()) > 0
+		})
+		for i := 0; i < 10; i++ {
+			tn.apply(EventTickElapsed())
+		}
+
+		Expect(forwards()).To(HaveLen(1))
+		Expect(forwards()[0].Targets).To(Equal([]uint64{1}))
+		Expect(forwards()[0].Acks).To(HaveLen(3))
+	})
+
+	Describe("with forwarding disabled", func() {
+		var tn *testNetwork
+
+		// persist persists the request of client 0 at the given nodes.
+		persist := func(reqNo uint64, nodes ...int) {
+			for _, i := range nodes {
+				tn.pending[i].concat(tn.nodes[i].ApplyEvent(EventRequestPersisted(requestAck(0, reqNo))))
+			}
+		}
+
+		// acked returns the request numbers node i acked.
+		acked := func(i int) map[uint64]struct{} {
+			result := map[uint64]struct{}{}
+			for _, action := range tn.observed[i] {
+				if send, ok := action.Type.(*state.Action_Send); ok {
+					if ack := send.Send.Msg.GetRequestAck(); ack != nil {
+						result[ack.ReqNo] = struct{}{}
+					}
+				}
+			}
+			return result
+		}
+
+		BeforeEach(func() {
+			tn = newTestNetwork(4, 1)
+			for _, sm := range tn.nodes {
+				sm.myConfig.DisableForwarding = true
+			}
+			tn.tickUntil(20, tn.inProgress)
+		})
+
+		It("never forwards requests, and rejects those of buckets led by nodes which lack them", func() {
+			// Node 3 is cut off, so that the leaders would forward to it.
+			tn.drop = func(source, target uint64, msg *msgs.Msg) bool {
+				return source == 3 || target == 3
+			}
+
+			// Request 0 maps to bucket 0, led by node 1 in epoch 1.  Request
+			// 1 maps to bucket 1, led by node 2, and only node 0 receives it.
+			persist(0, 0, 1, 2)
+			persist(1, 0)
+			tn.settle()
+
+			// Node 0 acks request 0 once its leader does, but not request 1.
+			Expect(acked(0)).To(HaveKey(uint64(0)))
+			Expect(acked(0)).NotTo(HaveKey(uint64(1)))
+
+			tn.tickUntil(20, func() bool {
+				return tn.nodes[0].commitState.highestCommit >= 4
+			})
+			for i := 0; i < 20; i++ {
+				tn.apply(EventTickElapsed())
+			}
+
+			for i := range tn.nodes {
+				for _, action := range tn.observed[i] {
+					switch t := action.Type.(type) {
+					case *state.Action_ForwardRequest:
+						Fail(fmt.Sprintf("node %d forwarded request %d", i, t.ForwardRequest.Acks[0].ReqNo))
+					case *state.Action_Commit:
+						for _, req := range t.Commit.Batch.Requests {
+							Expect(req.ReqNo).To(Equal(uint64(0)))
+						}
+					}
+				}
+			}
+		})
+
+		It("still answers fetches of the requests it acked", func() {
+			persist(0, 0, 1)
+			tn.settle()
+			Expect(acked(0)).To(HaveKey(uint64(0)))
+
+			actions := tn.nodes[0].ApplyEvent(EventStep(3, &msgs.Msg{
+				Type: &msgs.Msg_FetchRequest{
+					FetchRequest: &msgs.RequestAck{
+						ClientId: 0,
+						ReqNo:    0,
+						Digest:   []byte("request-digest-0"),
+					},
+				},
+			}))
+			Expect(actions.Len()).To(Equal(1))
+			forward := actions.Iterator().Next().Type.(*state.Action_ForwardRequest).ForwardRequest
+			Expect(forward.Targets).To(Equal([]uint64{3}))
+		})
+	})
+
+	It("suppresses a request proposed twice before it commits", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+
+		ack := requestAck(0, 0)
+		for i := range tn.nodes {
+			tn.observed[i] = nil
+			tn.pending[i].concat(tn.nodes[i].ApplyEvent(EventRequestPersisted(ack)))
+			Expect(tn.nodes[i].ApplyEvent(EventRequestPersisted(ack)).Len()).To(Equal(0))
+		}
+		tn.tickUntil(20, func() bool {
+			return tn.nodes[0].commitState.highestCommit >= 1
+		})
+		for i := 0; i < 5; i++ {
+			tn.apply(EventTickElapsed())
+		}
+
+		for i := range tn.nodes {
+			commits := 0
+			entries := 0
+			for _, commit := range tn.commits(i) {
+				for _, req := range commit.Batch.Requests {
+					if req.ClientId == ack.ClientId && req.ReqNo == ack.ReqNo {
+						entries++
+					}
+				}
+				if len(commit.Batch.Requests) > 0 {
+					commits++
+				}
+			}
+			Expect(entries).To(Equal(1))
+			Expect(commits).To(Equal(1))
+		}
+	})
+
+	It("holds commits back while the application lags by the unapplied commit limit", func() {
+		tn := newTestNetwork(1, 0)
+		tn.nodes[0].myConfig.MaxUnappliedCommits = 2
+		tn.tickUntil(10, tn.inProgress)
+
+		tn.persistRequestsTicking(5)
+
+		Expect(tn.committedSeqNos(0)).To(Equal([]uint64{1, 2}))
+		Expect(tn.nodes[0].commitState.highestCommit).To(BeNumerically(">", 4))
+
+		tn.apply(EventTickElapsed())
+		Expect(tn.committedSeqNos(0)).To(Equal([]uint64{1, 2}))
+
+		tn.apply(EventCommitsApplied(1, 1))
+		Expect(tn.committedSeqNos(0)).To(Equal([]uint64{1, 2, 3}))
+
+		tn.apply(EventCommitsApplied(2, 3))
+		Expect(tn.committedSeqNos(0)).To(Equal([]uint64{1, 2, 3, 4, 5}))
+	})
+
+	It("ignores acknowledgements of commits the application was never delivered", func() {
+		tn := newTestNetwork(1, 0)
+		tn.nodes[0].myConfig.MaxUnappliedCommits = 2
+		tn.tickUntil(10, tn.inProgress)
+
+		tn.persistRequestsTicking(5)
+		Expect(tn.committedSeqNos(0)).To(Equal([]uint64{1, 2}))
+
+		for _, event := range []*state.Event{
+			EventCommitsApplied(1, 5),
+			EventCommitsApplied(2, 1),
+			EventCommitsApplied(3, 4),
+		} {
+			Expect(func() { tn.apply(event) }).NotTo(Panic())
+		}
+		Expect(tn.nodes[0].commitState.lastAckedCommit).To(BeZero())
+		Expect(tn.committedSeqNos(0)).To(Equal([]uint64{1, 2}))
+
+		tn.apply(EventCommitsApplied(1, 2))
+		Expect(tn.committedSeqNos(0)).To(Equal([]uint64{1, 2, 3, 4}))
+	})
+
+	Describe("a gap in the committed sequences", func() {
+		type delayedMsg struct {
+			source uint64
+			msg    *msgs.Msg
+		}
+
+		var (
+			tn      *testNetwork
+			delayed []delayedMsg
+		)
+
+		// commits returns the sequences committed by node 0, in the order
+		// they were delivered, with the gaps each was delivered past.
+		commits := func() ([]uint64, map[uint64][]uint64) {
+			seqNos := []uint64{}
+			gaps := map[uint64][]uint64{}
+			for _, commit := range tn.commits(0) {
+				seqNos = append(seqNos, commit.Batch.SeqNo)
+				if len(commit.Gaps) > 0 {
+					gaps[commit.Batch.SeqNo] = commit.Gaps
+				}
+			}
+			return seqNos, gaps
+		}
+
+		// createGap commits the request of client 0 at sequence 4 everywhere
+		// but at node 0, which the commits of its peers do not reach, while
+		// the sequences after it commit.
+		createGap := func() {
+			tn.tickUntil(20, tn.inProgress)
+
+			delayed = nil
+			tn.drop = func(source, target uint64, msg *msgs.Msg) bool {
+				commit, ok := msg.Type.(*msgs.Msg_Commit)
+				if !ok || target != 0 || source == 0 || commit.Commit.SeqNo != 4 {
+					return false
+				}
+				delayed = append(delayed, delayedMsg{source: source, msg: msg})
+				return true
+			}
+
+			tn.apply(EventRequestPersisted(requestAck(0, 0)))
+			tn.tickUntil(20, func() bool {
+				return tn.nodes[1].commitState.highestCommit >= 8
+			})
+			Expect(tn.nodes[0].commitState.highestCommit).To(Equal(uint64(3)))
+		}
+
+		fillGap := func() {
+			tn.drop = nil
+			for _, d := range delayed {
+				tn.pending[0].concat(tn.nodes[0].ApplyEvent(EventStep(d.source, d.msg)))
+			}
+			tn.settle()
+			Expect(tn.nodes[0].commitState.highestCommit).To(BeNumerically(">=", 8))
+		}
+
+		BeforeEach(func() {
+			tn = newTestNetwork(4, 1)
+		})
+
+		It("holds later commits back until the gap fills by default", func() {
+			createGap()
+			seqNos, _ := commits()
+			Expect(seqNos).To(Equal([]uint64{1, 2, 3}))
+
+			fillGap()
+			seqNos, gaps := commits()
+			Expect(seqNos[:6]).To(Equal([]uint64{1, 2, 3, 4, 5, 6}))
+			Expect(gaps).To(BeEmpty())
+		})
+
+		It("delivers later commits past the gap, marking it, when configured to", func() {
+			tn.nodes[0].myConfig.DeliverCommitsPastGaps = true
+
+			createGap()
+			seqNos, gaps := commits()
+			Expect(seqNos).NotTo(ContainElement(uint64(4)))
+			for seqNo := uint64(5); seqNo <= 8; seqNo++ {
+				Expect(seqNos).To(ContainElement(seqNo))
+				Expect(gaps[seqNo]).To(ContainElement(uint64(4)), "seq_no=%d", seqNo)
+			}
+
+			fillGap()
+			seqNos, _ = commits()
+			delivered := map[uint64]int{}
+			for _, seqNo := range seqNos {
+				delivered[seqNo]++
+			}
+			for seqNo := uint64(1); seqNo <= 8; seqNo++ {
+				Expect(delivered[seqNo]).To(Equal(1), "seq_no=%d", seqNo)
+			}
+		})
+
+		It("counts the commits delivered past the gap against the unapplied commit limit", func() {
+			tn.nodes[0].myConfig.DeliverCommitsPastGaps = true
+			tn.nodes[0].myConfig.MaxUnappliedCommits = 5
+
+			createGap()
+			seqNos, gaps := commits()
+			Expect(gaps).NotTo(BeEmpty())
+			Expect(len(seqNos)).To(BeNumerically("<=", 5))
+		})
+
+		It("delivers nothing past the gap beyond the next checkpoint", func() {
+			networkState := standardNetworkState(4, 1)
+			networkState.Config.CheckpointInterval = 6
+			tn = newTestNetworkFromState(networkState)
+			tn.nodes[0].myConfig.DeliverCommitsPastGaps = true
+
+			createGap()
+			seqNos, gaps := commits()
+			Expect(seqNos).To(ContainElement(uint64(6)))
+			Expect(gaps[6]).To(ContainElement(uint64(4)))
+			for seqNo := uint64(7); seqNo <= 8; seqNo++ {
+				Expect(seqNos).NotTo(ContainElement(seqNo))
+			}
+		})
+	})
+
+	It("refuses to deliver a commit whose digest does not match its requests", func() {
+		tn := newTestNetwork(1, 0, func(sm *StateMachine) {
+			sm.HashFunc = func(data [][]byte) []byte {
+				h := sha256.New()
+				for _, d := range data {
+					h.Write(d)
+				}
+				return h.Sum(nil)
+			}
+		})
+		tn.nodes[0].myConfig.MaxUnappliedCommits = 1
+		tn.nodes[0].myConfig.VerifyCommitDigests = true
+		tn.tickUntil(10, tn.inProgress)
+
+		tn.persistRequestsTicking(3)
+		Expect(tn.committedSeqNos(0)).To(Equal([]uint64{1}))
+
+		tn.apply(EventCommitsApplied(1, 1))
+		Expect(tn.committedSeqNos(0)).To(Equal([]uint64{1, 2}))
+
+		// Corrupt the digest of the held back commit of sequence 3.
+		commits := tn.nodes[0].commitState.lowerHalfCommits
+		Expect(commits[2].SeqNo).To(Equal(uint64(3)))
+		commits[2] = &msgs.QEntry{
+			SeqNo:    commits[2].SeqNo,
+			Digest:   []byte("corrupted-digest"),
+			Requests: commits[2].Requests,
+		}
+
+		tn.apply(EventCommitsApplied(2, 2))
+		Expect(tn.committedSeqNos(0)).To(Equal([]uint64{1, 2}))
+
+		tn.apply(EventTickElapsed())
+		Expect(tn.committedSeqNos(0)).To(Equal([]uint64{1, 2}))
+	})
+
+	It("asks the consumer for the audit digest of a sequence, flagging it if uncommitted", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+
+		tn.apply(EventRequestPersisted(requestAck(0, 0)))
+		tn.tickUntil(20, func() bool {
+			return tn.nodes[0].commitState.highestCommit >= 4
+		})
+
+		audits := func(actions *ActionList) []*state.ActionAuditDigest {
+			var result []*state.ActionAuditDigest
+			iter := actions.Iterator()
+			for action := iter.Next(); action != nil; action = iter.Next() {
+				if audit, ok := action.Type.(*state.Action_AuditDigest); ok {
+					result = append(result, audit.AuditDigest)
+				}
+			}
+			return result
+		}
+
+		highestCommit := tn.nodes[0].commitState.highestCommit
+		Expect(audits(tn.nodes[0].ApplyEvent(EventAuditDigest(4)))).To(Equal([]*state.ActionAuditDigest{
+			{SeqNo: 4},
+		}))
+		Expect(audits(tn.nodes[0].ApplyEvent(EventAuditDigest(highestCommit + 1)))).To(Equal([]*state.ActionAuditDigest{
+			{SeqNo: highestCommit + 1, Uncommitted: true},
+		}))
+	})
+
+	It("surfaces a configuration change at the sequence it commits at", func() {
+		networkState := standardNetworkState(1, 0)
+		networkState.Config.ConfigClients = []uint64{1}
+		networkState.Clients = append(networkState.Clients, &msgs.NetworkState_Client{
+			Id:    1,
+			Width: 100,
+		})
+		tn := newTestNetworkFromState(networkState)
+		tn.tickUntil(10, tn.inProgress)
+
+		configChange := &msgs.RequestAck{
+			ClientId: 1,
+			ReqNo:    0,
+			Digest:   []byte("config-change-digest"),
+		}
+		for _, ack := range []*msgs.RequestAck{
+			{ClientId: 0, ReqNo: 0, Digest: []byte("request-digest-0")},
+			{ClientId: 0, ReqNo: 1, Digest: []byte("request-digest-1")},
+			configChange,
+			{ClientId: 0, ReqNo: 2, Digest: []byte("request-digest-2")},
+		} {
+			tn.apply(EventRequestPersisted(ack))
+			tn.apply(EventTickElapsed())
+		}
+
+		commits := tn.commits(0)
+		Expect(commits).To(HaveLen(4))
+		for _, commit := range commits {
+			if commit.Batch.SeqNo == 3 {
+				Expect(commit.ConfigChanges).To(HaveLen(1))
+				Expect(proto.Equal(commit.ConfigChanges[0], configChange)).To(BeTrue())
+			} else {
+				Expect(commit.ConfigChanges).To(BeEmpty())
+			}
+		}
+	})
+
+	It("withholds the commits following a barrier until the application catches up", func() {
+		networkState := standardNetworkState(1, 0)
+		networkState.Config.BarrierClients = []uint64{1}
+		networkState.Clients = append(networkState.Clients, &msgs.NetworkState_Client{
+			Id:    1,
+			Width: 100,
+		})
+		tn := newTestNetworkFromState(networkState)
+		tn.tickUntil(10, tn.inProgress)
+
+		for _, ack := range []*msgs.RequestAck{
+			{ClientId: 0, ReqNo: 0, Digest: []byte("request-digest-0")},
+			{ClientId: 0, ReqNo: 1, Digest: []byte("request-digest-1")},
+			{ClientId: 1, ReqNo: 0, Digest: []byte("barrier-digest")},
+			{ClientId: 0, ReqNo: 2, Digest: []byte("request-digest-2")},
+			{ClientId: 0, ReqNo: 3, Digest: []byte("request-digest-3")},
+		} {
+			tn.apply(EventRequestPersisted(ack))
+			tn.apply(EventTickElapsed())
+		}
+
+		Expect(tn.nodes[0].commitState.highestCommit).To(Equal(uint64(5)))
+		Expect(tn.committedSeqNos(0)).To(Equal([]uint64{1, 2, 3}))
+
+		tn.apply(EventCommitsApplied(1, 2))
+		Expect(tn.committedSeqNos(0)).To(Equal([]uint64{1, 2, 3}))
+
+		tn.apply(EventCommitsApplied(3, 3))
+		Expect(tn.committedSeqNos(0)).To(Equal([]uint64{1, 2, 3, 4, 5}))
+	})
+
+	It("checkpoints every node at the sequence of a coordinated checkpoint request", func() {
+		networkState := standardNetworkState(4, 1)
+		networkState.Config.CheckpointClients = []uint64{1}
+		networkState.Clients = append(networkState.Clients, &msgs.NetworkState_Client{Id: 1, Width: 100})
+		tn := newTestNetworkFromState(networkState)
+		tn.tickUntil(20, tn.inProgress)
+
+		tn.persistRequests(3)
+		tn.apply(EventRequestCheckpoint())
+
+		// coordinated returns the sequences at which node i was asked
+		// for a coordinated checkpoint, and the sequence at which the
+		// checkpoint request committed.
+		coordinated := func(i int) ([]uint64, uint64) {
+			var seqNos []uint64
+			var committedAt uint64
+			for _, action := range tn.observed[i] {
+				switch t := action.Type.(type) {
+				case *state.Action_CoordinatedCheckpoint:
+					seqNos = append(seqNos, t.CoordinatedCheckpoint.SeqNo)
+				case *state.Action_Commit:
+					for _, req := range t.Commit.Batch.Requests {
+						if req.ClientId == 1 {
+							committedAt = t.Commit.Batch.SeqNo
+						}
+					}
+				}
+			}
+			return seqNos, committedAt
+		}
+
+		tn.tickUntil(50, func() bool {
+			for i := range tn.nodes {
+				if seqNos, _ := coordinated(i); len(seqNos) == 0 {
+					return false
+				}
+			}
+			return true
+		})
+
+		seqNos, committedAt := coordinated(0)
+		Expect(committedAt).NotTo(BeZero())
+		Expect(seqNos).To(Equal([]uint64{committedAt}))
+		for i := range tn.nodes {
+			nodeSeqNos, nodeCommittedAt := coordinated(i)
+			Expect(nodeSeqNos).To(Equal(seqNos))
+			Expect(nodeCommittedAt).To(Equal(committedAt))
+		}
+	})
+
+	It("ignores a checkpoint request if no checkpoint clients are configured", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+
+		for i, sm := range tn.nodes {
+			Expect(sm.ApplyEvent(EventRequestCheckpoint()).Len()).To(Equal(0), "node %d", i)
+		}
+	})
+
+	It("reconciles echoes of its own preprepares from an epoch it will lead", func() {
+		tn := newTestNetwork(4, 1)
+
+		// In epoch 1, node 0 leads bucket 3, whose first sequence is 3,
+		// and which the request below maps to.  Deliver an echo of the
+		// preprepare for it before node 0 has installed the epoch, let
+		// alone allocated the sequence.  Requests 0 through 2 precede it
+		// in the other buckets.
+		ack := requestAck(0, 3)
+		echo := &msgs.Msg{
+			Type: &msgs.Msg_Preprepare{
+				Preprepare: &msgs.Preprepare{
+					SeqNo: 3,
+					Epoch: 1,
+					Batch: []*msgs.RequestAck{ack},
+				},
+			},
+		}
+		tn.pending[0].concat(tn.nodes[0].ApplyEvent(EventStep(0, echo)))
+
+		tn.tickUntil(20, tn.inProgress)
+		activeEpoch := tn.nodes[0].epochTracker.currentEpoch.activeEpoch
+		Expect(activeEpoch.epochConfig.Number).To(Equal(uint64(1)))
+		Expect(activeEpoch.buckets[3]).To(Equal(nodeID(0)))
+
+		tn.persistRequests(3)
+		tn.apply(EventRequestPersisted(ack))
+		tn.tickUntil(20, func() bool {
+			return tn.nodes[0].commitState.highestCommit >= 3
+		})
+
+		// The echo must be counted as our own preprepare for the digest we
+		// computed, not as a vote for an unknown batch.
+		seq := activeEpoch.sequence(3)
+		Expect(seq.batch).To(HaveLen(1))
+		Expect(seq.nodeChoices[0].digest).To(Equal(seq.digest))
+		Expect(seq.prepares).To(HaveLen(1))
+		Expect(activeEpoch.preprepareBuffers[3].buffer.buffer.Len()).To(Equal(0))
+	})
+
+	It("rejects a preprepare claiming a digest inconsistent with the stored request data", func() {
+		// Node 0 leads bucket 3 in epoch 1, withhold its real preprepares
+		// so that only the forged one below reaches node 1.
+		tn := newTestNetwork(4, 1)
+		tn.drop = func(source, target uint64, msg *msgs.Msg) bool {
+			_, ok := msg.Type.(*msgs.Msg_Preprepare)
+			return ok && source == 0
+		}
+		tn.tickUntil(20, tn.inProgress)
+
+		// Requests are acknowledged in order, so persist those preceding
+		// request 3 as well.
+		for i := 1; i < 4; i++ {
+			for reqNo := uint64(0); reqNo <= 3; reqNo++ {
+				tn.pending[i].concat(tn.nodes[i].ApplyEvent(EventRequestPersisted(requestAck(0, reqNo))))
+			}
+		}
+		tn.settle()
+
+		tn.observed[1] = nil
+		tn.pending[1].concat(tn.nodes[1].ApplyEvent(EventStep(0, &msgs.Msg{
+			Type: &msgs.Msg_Preprepare{
+				Preprepare: &msgs.Preprepare{
+					SeqNo: 3,
+					Epoch: 1,
+					Batch: []*msgs.RequestAck{
+						{
+							ClientId: 0,
+							ReqNo:    3,
+							Digest:   []byte("forged-digest"),
+						},
+					},
+				},
+			},
+		})))
+		tn.settle()
+
+		seq := tn.nodes[1].epochTracker.currentEpoch.activeEpoch.sequence(3)
+		Expect(seq.state).To(Equal(sequenceUninitialized))
+
+		suspected := false
+		for _, action := range tn.observed[1] {
+			if send, ok := action.Type.(*state.Action_Send); ok {
+				if _, ok := send.Send.Msg.Type.(*msgs.Msg_Suspect); ok {
+					suspected = true
+				}
+			}
+		}
+		Expect(suspected).To(BeTrue())
+	})
+
+	It("rejects a preprepare beyond the high watermark under the strict policy", func() {
+		networkState := standardNetworkState(4, 1)
+		networkState.Config.MaxEpochLength = 200
+		tn := newTestNetworkFromState(networkState)
+		tn.tickUntil(20, tn.inProgress)
+
+		// Node 0 leads bucket 3 in epoch 1, find its first sequence beyond
+		// the high watermark, though not beyond the planned expiration.
+		activeEpoch := tn.nodes[1].epochTracker.currentEpoch.activeEpoch
+		seqNo := activeEpoch.highWatermark() + 1
+		for seqToBucket(seqNo, activeEpoch.networkConfig) != 3 {
+			seqNo++
+		}
+		Expect(seqNo).To(BeNumerically("<=", activeEpoch.epochConfig.PlannedExpiration))
+
+		preprepare := EventStep(0, &msgs.Msg{
+			Type: &msgs.Msg_Preprepare{
+				Preprepare: &msgs.Preprepare{
+					SeqNo: seqNo,
+					Epoch: 1,
+				},
+			},
+		})
+		suspects := func(actions *ActionList) bool {
+			iter := actions.Iterator()
+			for action := iter.Next(); action != nil; action = iter.Next() {
+				if send, ok := action.Type.(*state.Action_Send); ok {
+					if _, ok := send.Send.Msg.Type.(*msgs.Msg_Suspect); ok {
+						return true
+					}
+				}
+			}
+			return false
+		}
+
+		// By default, the preprepare is buffered until the watermarks move.
+		Expect(suspects(tn.nodes[2].ApplyEvent(preprepare))).To(BeFalse())
+		Expect(tn.nodes[2].epochTracker.currentEpoch.activeEpoch.preprepareBuffers[3].buffer.buffer.Len()).To(Equal(1))
+
+		tn.nodes[1].myConfig.StrictHighWatermark = true
+		Expect(suspects(tn.nodes[1].ApplyEvent(preprepare))).To(BeTrue())
+		Expect(activeEpoch.preprepareBuffers[3].buffer.buffer.Len()).To(Equal(0))
+	})
+
+	It("buffers a preprepare skipping a sequence of its bucket, flagging it under strict ordering", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+
+		// Node 0 leads bucket 3 in epoch 1.
+		preprepare := func(seqNo uint64) *state.Event {
+			return EventStep(0, &msgs.Msg{
+				Type: &msgs.Msg_Preprepare{
+					Preprepare: &msgs.Preprepare{
+						SeqNo: seqNo,
+						Epoch: 1,
+					},
+				},
+			})
+		}
+		misbehaviors := func(actions *ActionList) []*state.ActionMisbehavior {
+			var result []*state.ActionMisbehavior
+			iter := actions.Iterator()
+			for action := iter.Next(); action != nil; action = iter.Next() {
+				if misbehavior, ok := action.Type.(*state.Action_Misbehavior); ok {
+					result = append(result, misbehavior.Misbehavior)
+				}
+			}
+			return result
+		}
+
+		tn.nodes[2].myConfig.StrictOrdering = true
+		for _, i := range []int{1, 2} {
+			activeEpoch := tn.nodes[i].epochTracker.currentEpoch.activeEpoch
+			preprepareBuffer := activeEpoch.preprepareBuffers[3]
+			nextSeqNo := preprepareBuffer.nextSeqNo
+			skippedSeqNo := nextSeqNo + uint64(len(activeEpoch.buckets))
+			Expect(skippedSeqNo).To(BeNumerically("<=", activeEpoch.highWatermark()))
+
+			flagged := misbehaviors(tn.nodes[i].ApplyEvent(preprepare(skippedSeqNo)))
+			if i == 2 {
+				Expect(flagged).To(HaveLen(1))
+				Expect(flagged[0].NodeId).To(Equal(uint64(0)))
+			} else {
+				Expect(flagged).To(BeEmpty())
+			}
+			Expect(preprepareBuffer.buffer.buffer.Len()).To(Equal(1))
+			Expect(activeEpoch.sequence(skippedSeqNo).state).To(Equal(sequenceUninitialized))
+
+			// Once the gap is filled, both preprepares apply in order.
+			Expect(misbehaviors(tn.nodes[i].ApplyEvent(preprepare(nextSeqNo)))).To(BeEmpty())
+			Expect(preprepareBuffer.buffer.buffer.Len()).To(Equal(0))
+			Expect(preprepareBuffer.nextSeqNo).To(Equal(skippedSeqNo + uint64(len(activeEpoch.buckets))))
+			Expect(activeEpoch.sequence(skippedSeqNo).state).To(BeNumerically(">=", sequencePreprepared))
+		}
+	})
+
+	It("lets a bucket preprepare beyond the commit window while another lags", func() {
+		networkState := standardNetworkState(4, 1)
+		networkState.Config.NumberOfBuckets = 2
+		networkState.Config.MaxEpochLength = 200
+		networkState.Config.BucketLookahead = 1
+		tn := newTestNetworkFromState(networkState)
+		tn.tickUntil(20, tn.inProgress)
+
+		stopAtSeqNo := tn.nodes[0].commitState.stopAtSeqNo
+		for i := range tn.observed {
+			tn.observed[i] = nil
+		}
+
+		request := func(reqNo uint64) *state.Event {
+			return EventRequestPersisted(requestAck(0, reqNo))
+		}
+
+		// The preprepares of the leader of bucket 1 are held up, so bucket 1
+		// lags, and nothing commits past its next sequence.
+		type heldMsg struct {
+			source, target uint64
+			msg            *msgs.Msg
+		}
+		var held []heldMsg
+		laggingLeader := uint64(tn.nodes[0].epochTracker.currentEpoch.activeEpoch.buckets[1])
+		tn.drop = func(source, target uint64, msg *msgs.Msg) bool {
+			if _, ok := msg.Type.(*msgs.Msg_Preprepare); !ok || source != laggingLeader || target == source {
+				return false
+			}
+			held = append(held, heldMsg{source: source, target: target, msg: msg})
+			return true
+		}
+
+		for reqNo := uint64(0); reqNo < 100; reqNo++ {
+			tn.apply(request(reqNo))
+		}
+
+		for i, sm := range tn.nodes {
+			activeEpoch := sm.epochTracker.currentEpoch.activeEpoch
+			Expect(sm.commitState.stopAtSeqNo).To(Equal(stopAtSeqNo))
+			Expect(sm.commitState.highestCommit).To(BeNumerically("<", stopAtSeqNo))
+
+			// A global window would have stopped bucket 0 at the stop
+			// sequence, instead it proposed into the lookahead.
+			lastAllocated := activeEpoch.lowestUnallocated[0] - uint64(len(activeEpoch.buckets))
+			Expect(lastAllocated).To(BeNumerically(">", stopAtSeqNo))
+			Expect(activeEpoch.highWatermark()).To(Equal(stopAtSeqNo + uint64(networkState.Config.CheckpointInterval)))
+
+			seq := activeEpoch.sequence(lastAllocated)
+			Expect(seq.withheld).To(BeTrue())
+			Expect(seq.state).To(Equal(sequencePreprepared))
+
+			// Beyond the commit window, nothing prepares.
+			for _, action := range tn.observed[i] {
+				send, ok := action.Type.(*state.Action_Send)
+				if !ok {
+					continue
+				}
+				if commit, ok := send.Send.Msg.Type.(*msgs.Msg_Commit); ok {
+					Expect(commit.Commit.SeqNo).To(BeNumerically("<=", stopAtSeqNo))
+				}
+			}
+		}
+
+		// Once bucket 1 catches up, the checkpoints move the commit window
+		// over the sequences proposed into the lookahead, and they commit.
+		tn.drop = nil
+		for _, h := range held {
+			tn.pending[h.target].concat(tn.nodes[h.target].ApplyEvent(EventStep(h.source, h.msg)))
+		}
+		tn.tickUntil(100, func() bool {
+			for _, sm := range tn.nodes {
+				if sm.commitState.highestCommit <= stopAtSeqNo+uint64(networkState.Config.CheckpointInterval) {
+					return false
+				}
+			}
+			return true
+		})
+	})
+
+	It("reports a leadership view in which every bucket is led by a leader of the epoch", func() {
+		tn := newTestNetwork(4, 1)
+		_, err := (&StateMachine{}).LeadershipView()
+		Expect(err).To(MatchError("state machine is not initialized"))
+
+		tn.tickUntil(20, tn.inProgress)
+		tn.pending[0].concat(tn.nodes[0].ApplyEvent(EventStepDown()))
+		tn.tickUntil(50, func() bool {
+			return tn.inProgress() && tn.nodes[1].epochTracker.currentEpoch.number > 1
+		})
+
+		view, err := tn.nodes[1].LeadershipView()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(view.Epoch).To(Equal(tn.nodes[1].epochTracker.currentEpoch.number))
+		Expect(view.Leaders).NotTo(ContainElement(uint64(0)))
+		Expect(view.BucketLeaders).To(HaveLen(int(tn.networkState.Config.NumberOfBuckets)))
+		for bucket := uint64(0); bucket < uint64(tn.networkState.Config.NumberOfBuckets); bucket++ {
+			Expect(view.BucketLeaders).To(HaveKey(bucket))
+			Expect(view.Leaders).To(ContainElement(view.BucketLeaders[bucket]))
+		}
+	})
+
+	It("rejects results for actions it never emitted", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+		sm := tn.nodes[0]
+		epoch := sm.epochTracker.currentEpoch
+
+		// No batch was ever sent for hashing for this sequence.
+		actions := sm.ApplyEvent(EventHashResult([]byte("fabricated"), &state.HashOrigin{
+			Type: &state.HashOrigin_Batch_{
+				Batch: &state.HashOrigin_Batch{
+					Source:      1,
+					SeqNo:       1,
+					Epoch:       epoch.number,
+					RequestAcks: []*msgs.RequestAck{requestAck(0, 0)},
+				},
+			},
+		}))
+		Expect(actions.isEmpty()).To(BeTrue())
+		Expect(epoch.activeEpoch.sequence(1).state).To(Equal(sequenceUninitialized))
+		_, ok := sm.batchTracker.getBatch([]byte("fabricated"))
+		Expect(ok).To(BeFalse())
+
+		// Nor was a checkpoint ever requested.
+		actions = sm.ApplyEvent(EventCheckpointResult([]byte("fabricated"), nil, &state.ActionCheckpoint{
+			SeqNo:         20,
+			NetworkConfig: sm.commitState.activeState.Config,
+			ClientStates:  sm.commitState.activeState.Clients,
+		}))
+		Expect(actions.isEmpty()).To(BeTrue())
+		Expect(sm.commitState.lowWatermark).To(Equal(uint64(0)))
+	})
+
+	It("round trips a client's state from one node into a fresh node", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+
+		// Only node 0 persists the requests, so they cannot commit.
+		acks := []*msgs.RequestAck{
+			{ClientId: 0, ReqNo: 0, Digest: []byte("request-digest-0")},
+			{ClientId: 0, ReqNo: 1, Digest: []byte("request-digest-1")},
+		}
+		for _, ack := range acks {
+			tn.nodes[0].ApplyEvent(EventRequestPersisted(ack))
+		}
+
+		exported, err := tn.nodes[0].ExportClientState(0)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = tn.nodes[0].ExportClientState(7)
+		Expect(err).To(MatchError("unknown client_id=7"))
+
+		clientState := &state.ClientState{}
+		Expect(proto.Unmarshal(exported, clientState)).To(Succeed())
+
+		sm, _ := newInitializedStateMachine(1, standardNetworkState(4, 1))
+		actions := sm.ApplyEvent(EventImportClientState(clientState))
+
+		reimported, err := sm.ExportClientState(0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reimported).To(Equal(exported))
+
+		// The imported requests are acked to the network.
+		var acked []*msgs.RequestAck
+		iter := actions.Iterator()
+		for action := iter.Next(); action != nil; action = iter.Next() {
+			if send, ok := action.Type.(*state.Action_Send); ok && send.Send.Msg.GetRequestAck() != nil {
+				Expect(send.Send.Targets).To(Equal([]uint64{0, 1, 2, 3}))
+				acked = append(acked, send.Send.Msg.GetRequestAck())
+			}
+		}
+		Expect(acked).To(Equal(acks))
+	})
+
+	It("refuses to import a client state which conflicts with its commits", func() {
+		sm, _ := newInitializedStateMachine(1, standardNetworkState(4, 1))
+
+		actions := sm.ApplyEvent(EventImportClientState(&state.ClientState{
+			Client: &msgs.NetworkState_Client{
+				Id:           0,
+				Width:        100,
+				LowWatermark: 2,
+			},
+			Requests: []*msgs.RequestAck{
+				{ClientId: 0, ReqNo: 2, Digest: []byte("request-digest-2")},
+			},
+		}))
+		Expect(actions.isEmpty()).To(BeTrue())
+
+		exported, err := sm.ExportClientState(0)
+		Expect(err).NotTo(HaveOccurred())
+		clientState := &state.ClientState{}
+		Expect(proto.Unmarshal(exported, clientState)).To(Succeed())
+		Expect(clientState.Requests).To(BeEmpty())
+	})
+
+	It("drops a request rejected by preprocessing, flagging the node which forwarded it", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+
+		rejected := &msgs.RequestAck{
+			ClientId: 0,
+			ReqNo:    0,
+			Digest:   []byte("rejected-digest"),
+		}
+		for i, node := range tn.nodes {
+			if i == 3 {
+				tn.pending[i].concat(node.ApplyEvent(EventForwardedRequestRejected(1, rejected, "malformed payload")))
+				continue
+			}
+			if i == 2 {
+				// A rejection without a reason is still a rejection.
+				tn.pending[i].concat(node.ApplyEvent(EventRequestRejected(rejected, "")))
+				continue
+			}
+			tn.pending[i].concat(node.ApplyEvent(EventRequestRejected(rejected, "malformed payload")))
+		}
+		for i := 0; i < 20; i++ {
+			tn.apply(EventTickElapsed())
+		}
+
+		for i := range tn.nodes {
+			for _, action := range tn.observed[i] {
+				switch a := action.Type.(type) {
+				case *state.Action_Send:
+					if ack := a.Send.Msg.GetRequestAck(); ack != nil {
+						Expect(ack.Digest).NotTo(Equal(rejected.Digest))
+					}
+				case *state.Action_Commit:
+					for _, ack := range a.Commit.Batch.Requests {
+						Expect(ack.Digest).NotTo(Equal(rejected.Digest))
+					}
+				}
+			}
+			crn := tn.nodes[i].clientHashDisseminator.clients[0].reqNo(0)
+			Expect(crn.myRequests).To(BeEmpty())
+			Expect(crn.committed).To(BeFalse())
+		}
+
+		Expect(tn.nodes[3].MisbehaviorCounts()).To(Equal(map[uint64]int{1: 1}))
+		Expect(tn.nodes[0].MisbehaviorCounts()).To(BeEmpty())
+	})
+
+	Describe("messages received during state transfer", func() {
+		var sm *StateMachine
+
+		orderingMsgs := func(seqNo uint64) []*msgs.Msg {
+			return []*msgs.Msg{
+				{Type: &msgs.Msg_Preprepare{Preprepare: &msgs.Preprepare{SeqNo: seqNo, Epoch: 2}}},
+				{Type: &msgs.Msg_Prepare{Prepare: &msgs.Prepare{SeqNo: seqNo, Epoch: 2, Digest: []byte("digest")}}},
+				{Type: &msgs.Msg_Commit{Commit: &msgs.Commit{SeqNo: seqNo, Epoch: 2, Digest: []byte("digest")}}},
+			}
+		}
+
+		// transfer starts a transfer of sm to seq_no=20, and has node 1
+		// send it the ordering messages for sequences 10, 20 and 30 of
+		// epoch 2, which the epoch tracker buffers as it is in epoch 1.
+		transfer := func() {
+			sm.commitState.transferTo(20, []byte("checkpoint-value"))
+			for _, seqNo := range []uint64{10, 20, 30} {
+				for _, msg := range orderingMsgs(seqNo) {
+					Expect(sm.ApplyEvent(EventStep(1, msg)).isEmpty()).To(BeTrue())
+				}
+			}
+		}
+
+		// buffered returns the type and sequence of each buffered message.
+		buffered := func() []string {
+			var result []string
+			for _, mb := range sm.transferBuffer.msgBuffers {
+				for e := mb.buffer.Front(); e != nil; e = e.Next() {
+					msg := e.Value.(*msgs.Msg)
+					seqNo, _ := orderingSeqNo(msg)
+					result = append(result, fmt.Sprintf("%s %d", msgType(msg), seqNo))
+				}
+			}
+			return result
+		}
+
+		BeforeEach(func() {
+			sm, _ = newInitializedStateMachine(0, standardNetworkState(4, 1))
+		})
+
+		It("drops those at or below the transfer target and buffers those above it", func() {
+			transfer()
+			Expect(buffered()).To(Equal([]string{"preprepare 30", "prepare 30", "commit 30"}))
+
+			sm.ApplyEvent(EventStateTransferComplete(standardNetworkState(4, 1), &state.ActionStateTarget{
+				SeqNo: 20,
+				Value: []byte("checkpoint-value"),
+			}))
+			Expect(sm.commitState.transferring).To(BeFalse())
+			Expect(sm.commitState.lowWatermark).To(Equal(uint64(20)))
+			Expect(buffered()).To(BeEmpty())
+
+			// The buffered messages are then applied, and reach the epoch
+			// tracker.
+			Expect(sm.epochTracker.futureMsgs[1].buffer.Len()).To(Equal(3))
+		})
+
+		It("drops them all when configured to", func() {
+			sm.myConfig.DropDuringStateTransfer = true
+			transfer()
+			Expect(buffered()).To(BeEmpty())
+			Expect(sm.epochTracker.futureMsgs[1].buffer.Len()).To(BeZero())
+		})
+	})
+
+	It("counts the misbehaviors flagged for each node until reset", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+
+		sm := tn.nodes[2]
+		sm.myConfig.StrictOrdering = true
+		activeEpoch := sm.epochTracker.currentEpoch.activeEpoch
+
+		// Nodes 0 and 1 lead buckets 3 and 0 in epoch 1, each preprepare
+		// skips the next expected sequence of its bucket.
+		skip := func(source uint64, bucket int, skipped int) {
+			seqNo := activeEpoch.preprepareBuffers[bucket].nextSeqNo + uint64(skipped*len(activeEpoch.buckets))
+			sm.ApplyEvent(EventStep(source, &msgs.Msg{
+				Type: &msgs.Msg_Preprepare{
+					Preprepare: &msgs.Preprepare{
+						SeqNo: seqNo,
+						Epoch: 1,
+					},
+				},
+			}))
+		}
+		Expect(sm.MisbehaviorCounts()).To(BeEmpty())
+
+		skip(0, 3, 1)
+		skip(0, 3, 2)
+		skip(1, 0, 1)
+		Expect(sm.MisbehaviorCounts()).To(Equal(map[uint64]int{0: 2, 1: 1}))
+
+		sm.ApplyEvent(EventResetMisbehavior(0))
+		Expect(sm.MisbehaviorCounts()).To(Equal(map[uint64]int{1: 1}))
+	})
+
+	It("outvotes a node whose batch digest is corrupted", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+
+		var corruptedSeqNo uint64
+		tn.consumer.corruptHash = func(node uint64, hash *state.ActionHashRequest) bool {
+			batch, ok := hash.Origin.Type.(*state.HashOrigin_Batch_)
+			if node != 3 || !ok || len(batch.Batch.RequestAcks) == 0 || corruptedSeqNo != 0 {
+				return false
+			}
+			corruptedSeqNo = batch.Batch.SeqNo
+			return true
+		}
+
+		tn.persistRequests(8)
+
+		commits := func(node int) map[uint64][]byte {
+			result := map[uint64][]byte{}
+			for _, commit := range tn.commits(node) {
+				if len(commit.Batch.Requests) > 0 {
+					result[commit.Batch.SeqNo] = commit.Batch.Digest
+				}
+			}
+			return result
+		}
+
+		tn.tickUntil(50, func() bool {
+			for node := 0; node < 3; node++ {
+				if len(commits(node)) != 8 {
+					return false
+				}
+			}
+			return true
+		})
+		Expect(corruptedSeqNo).NotTo(BeZero())
+
+		// The corrupted node never commits the batch it computed the wrong
+		// digest for, and agrees with the network on everything it commits.
+		Expect(commits(3)).NotTo(HaveKey(corruptedSeqNo))
+		for seqNo, digest := range commits(3) {
+			Expect(digest).To(Equal(commits(0)[seqNo]))
+		}
+	})
+
+	It("changes epoch when a leader is silent without explicit ticks", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+		Expect(tn.nodes[0].epochTracker.currentEpoch.number).To(Equal(uint64(1)))
+
+		tn.crash(1)
+		startTicks := tn.ticks
+		tn.tickUntil(100, func() bool {
+			for i, sm := range tn.nodes {
+				if tn.crashed[i] {
+					continue
+				}
+				if sm.epochTracker.currentEpoch.number <= 1 || sm.epochTracker.currentEpoch.state != etInProgress {
+					return false
+				}
+			}
+			return true
+		})
+
+		suspected := false
+		for _, action := range tn.observed[0] {
+			if send, ok := action.Type.(*state.Action_Send); ok {
+				if _, ok := send.Send.Msg.Type.(*msgs.Msg_Suspect); ok {
+					suspected = true
+				}
+			}
+		}
+		Expect(suspected).To(BeTrue())
+		Expect(tn.ticks).To(BeNumerically(">", startTicks))
+	})
+
+	It("moves the watermarks of an epoch whose starting checkpoint leaves it no sequences", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+		tn.crash(1)
+		tn.tickUntil(100, func() bool {
+			return tn.nodes[0].commitState.lowWatermark >= 80
+		})
+
+		// Epoch 2 starts from the checkpoint at its planned expiration, so
+		// it never allocates a sequence, yet the network moves past it.
+		expirations := map[uint64]uint64{}
+		allocatedBy := map[uint64]uint64{}
+		for _, action := range tn.observed[0] {
+			if write, ok := action.Type.(*state.Action_AppendWriteAhead); ok {
+				if nEntry := write.AppendWriteAhead.Data.GetNEntry(); nEntry != nil {
+					expirations[nEntry.EpochConfig.Number] = nEntry.EpochConfig.PlannedExpiration
+					allocatedBy[nEntry.SeqNo] = nEntry.EpochConfig.Number
+				}
+			}
+		}
+		Expect(expirations).To(HaveKeyWithValue(uint64(2), uint64(40)))
+		Expect(allocatedBy).To(HaveKeyWithValue(uint64(41), uint64(3)))
+		Expect(tn.nodes[0].epochTracker.currentEpoch.number).To(BeNumerically(">", 3))
+	})
+
+	It("leaves the leaders of a suspected epoch out of the next epoch's leaders", func() {
+		genesis := make([]*msgs.EpochConfig, 4)
+		for i := range genesis {
+			genesis[i] = &msgs.EpochConfig{
+				Number:  0,
+				Leaders: []uint64{0, 1},
+			}
+		}
+		tn := newTestNetworkFromGenesis(standardNetworkState(4, 1), genesis, func(sm *StateMachine) {
+			sm.LeaderSelector = DefaultLeaderSelector
+		})
+		tn.tickUntil(20, tn.inProgress)
+		Expect(tn.nodes[0].epochTracker.currentEpoch.activeEpoch.epochConfig.Leaders).To(Equal([]uint64{0, 1}))
+
+		tn.crash(1)
+		tn.tickUntil(100, func() bool {
+			currentEpoch := tn.nodes[0].epochTracker.currentEpoch
+			return currentEpoch.number > 1 && currentEpoch.state == etInProgress
+		})
+
+		for i, sm := range tn.nodes {
+			if tn.crashed[i] {
+				continue
+			}
+			Expect(sm.epochTracker.suspicions).To(Equal(map[uint64]uint64{0: 1, 1: 1}))
+			leaders := sm.epochTracker.currentEpoch.activeEpoch.epochConfig.Leaders
+			// The epoch following the suspected one expires straight away,
+			// the leaders of epoch 3 rotate from node 3, skipping 0 and 1.
+			Expect(leaders).To(Equal([]uint64{3, 2}))
+		}
+	})
+
+	It("warns of network asymmetry when no peer prepares the sequences it leads", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+
+		asymmetries := func(i int) []*state.ActionNetworkAsymmetry {
+			var result []*state.ActionNetworkAsymmetry
+			for _, action := range tn.observed[i] {
+				if asymmetry, ok := action.Type.(*state.Action_NetworkAsymmetry); ok {
+					result = append(result, asymmetry.NetworkAsymmetry)
+				}
+			}
+			return result
+		}
+
+		// Node 3 keeps receiving messages, but none of those it sends arrive,
+		// so the other nodes commit without it while its own bucket stalls.
+		tn.drop = func(source, target uint64, msg *msgs.Msg) bool {
+			return source == 3 && target != 3
+		}
+		tn.persistRequestsTicking(20)
+
+		Expect(tn.nodes[3].epochTracker.currentEpoch.number).To(BeNumerically(">", 1))
+		Expect(tn.nodes[3].commitState.highestCommit).To(Equal(tn.nodes[0].commitState.highestCommit))
+
+		reported := asymmetries(3)
+		Expect(reported).NotTo(BeEmpty())
+		Expect(reported[0].Epoch).To(Equal(uint64(1)))
+		Expect(reported[0].Peers).To(Equal([]uint64{0, 1, 2}))
+		for i := 1; i < len(reported); i++ {
+			Expect(reported[i].Epoch).To(BeNumerically(">", reported[i-1].Epoch))
+		}
+		for i := 0; i < 3; i++ {
+			Expect(asymmetries(i)).To(BeEmpty())
+		}
+	})
+
+	It("completes an epoch change referencing batches by digest, fetching those it lacks", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+		observedBefore := make([]int, len(tn.nodes))
+		for i := range tn.nodes {
+			observedBefore[i] = len(tn.observed[i])
+		}
+
+		// The batch prepares, but never commits, and node 3 never receives it.
+		tn.drop = func(source, target uint64, msg *msgs.Msg) bool {
+			switch msg.Type.(type) {
+			case *msgs.Msg_Commit:
+				return true
+			case *msgs.Msg_Preprepare, *msgs.Msg_ForwardBatch:
+				return target == 3
+			default:
+				return false
+			}
+		}
+		tn.apply(EventRequestPersisted(requestAck(0, 0)))
+		tn.tickUntil(100, func() bool {
+			return tn.nodes[3].epochTracker.currentEpoch.number > 1
+		})
+		tn.drop = nil
+		tn.tickUntil(100, tn.inProgress)
+
+		sent := func(i int) []*state.ActionSend {
+			var result []*state.ActionSend
+			for _, action := range tn.observed[i][observedBefore[i]:] {
+				if send, ok := action.Type.(*state.Action_Send); ok {
+					result = append(result, send.Send)
+				}
+			}
+			return result
+		}
+
+		var fetches []*state.ActionSend
+		for _, send := range sent(3) {
+			if send.Msg.GetFetchBatch() != nil {
+				fetches = append(fetches, send)
+			}
+		}
+		Expect(fetches).NotTo(BeEmpty())
+		fetch := fetches[0].Msg.GetFetchBatch()
+
+		// The batch is only referenced by sequence and digest in the epoch
+		// changes, and is fetched from the nodes which claimed it alone.
+		referencedBy := map[uint64]struct{}{}
+		for i := 0; i < 3; i++ {
+			for _, send := range sent(i) {
+				for _, entry := range send.Msg.GetEpochChange().GetQSet() {
+					if entry.SeqNo == fetch.SeqNo && bytes.Equal(entry.Digest, fetch.Digest) {
+						referencedBy[uint64(i)] = struct{}{}
+					}
+				}
+			}
+		}
+		Expect(referencedBy).NotTo(BeEmpty())
+		Expect(len(fetches[0].Targets)).To(BeNumerically("<", len(tn.nodes)-1))
+		for _, target := range fetches[0].Targets {
+			Expect(referencedBy).To(HaveKey(target))
+		}
+
+		Expect(tn.nodes[3].commitState.highestCommit).To(BeNumerically(">=", fetch.SeqNo))
+		Expect(tn.nodes[3].commitState.highestCommit).To(Equal(tn.nodes[0].commitState.highestCommit))
+	})
+
+	It("hands an actions observer copies of every action emitted", func() {
+		var copies []*state.Action
+		tn := newTestNetwork(1, 0, func(sm *StateMachine) {
+			sm.ActionsObserver = func(actions *ActionList) {
+				iter := actions.Iterator()
+				for action := iter.Next(); action != nil; action = iter.Next() {
+					copies = append(copies, action)
+				}
+			}
+		})
+		tn.tickUntil(10, tn.inProgress)
+		tn.persistRequestsTicking(3)
+
+		Expect(copies).To(HaveLen(len(tn.observed[0])))
+		commits := 0
+		for i, action := range copies {
+			Expect(action).NotTo(BeIdenticalTo(tn.observed[0][i]))
+			Expect(proto.Equal(action, tn.observed[0][i])).To(BeTrue())
+
+			if commit, ok := action.Type.(*state.Action_Commit); ok {
+				commits++
+				seqNo := commit.Commit.Batch.SeqNo
+				commit.Commit.Batch.SeqNo = 1000
+				Expect(tn.observed[0][i].Type.(*state.Action_Commit).Commit.Batch.SeqNo).To(Equal(seqNo))
+			}
+		}
+		Expect(commits).NotTo(BeZero())
+	})
+
+	Describe("announcing the network configuration to peers", func() {
+		hellos := func(actions *ActionList) []*state.Action {
+			var result []*state.Action
+			iter := actions.Iterator()
+			for action := iter.Next(); action != nil; action = iter.Next() {
+				if send, ok := action.Type.(*state.Action_Send); ok {
+					if _, ok := send.Send.Msg.Type.(*msgs.Msg_Hello); ok {
+						result = append(result, action)
+					}
+				}
+			}
+			return result
+		}
+
+		mismatches := func(actions *ActionList) []*state.ActionConfigMismatch {
+			var result []*state.ActionConfigMismatch
+			iter := actions.Iterator()
+			for action := iter.Next(); action != nil; action = iter.Next() {
+				if mismatch, ok := action.Type.(*state.Action_ConfigMismatch); ok {
+					result = append(result, mismatch.ConfigMismatch)
+				}
+			}
+			return result
+		}
+
+		var sm *StateMachine
+
+		BeforeEach(func() {
+			sm, _ = newInitializedStateMachine(0, standardNetworkState(4, 1))
+		})
+
+		greet := func(networkState *msgs.NetworkState) *ActionList {
+			_, initActions := newInitializedStateMachine(1, networkState)
+
+			hello := hellos(initActions)
+			Expect(hello).To(HaveLen(1))
+			send := hello[0].Type.(*state.Action_Send).Send
+			Expect(send.Targets).To(Equal([]uint64{0, 2, 3}))
+
+			return sm.ApplyEvent(EventStep(1, send.Msg))
+		}
+
+		checkpointVotes := func() map[string][]nodeID {
+			sm.ApplyEvent(EventStep(1, &msgs.Msg{
+				Type: &msgs.Msg_Checkpoint{
+					Checkpoint: &msgs.Checkpoint{
+						SeqNo: 20,
+						Value: []byte("value"),
+					},
+				},
+			}))
+			return sm.checkpointTracker.checkpoint(20).values
+		}
+
+		It("accepts a peer started from the same configuration", func() {
+			Expect(mismatches(greet(standardNetworkState(4, 1)))).To(BeEmpty())
+			Expect(checkpointVotes()).To(HaveKeyWithValue("value", []nodeID{1}))
+		})
+
+		It("reports a peer started from a different configuration", func() {
+			networkState := standardNetworkState(4, 1)
+			networkState.Config.NumberOfBuckets = 8
+
+			reported := mismatches(greet(networkState))
+			Expect(reported).To(HaveLen(1))
+			Expect(reported[0].NodeId).To(Equal(uint64(1)))
+			Expect(reported[0].SeqNo).To(Equal(uint64(0)))
+			Expect(reported[0].Reason).To(HaveSuffix(ErrConfigMismatch.Error()))
+		})
+
+		It("ignores a mismatched peer until it announces a matching configuration", func() {
+			networkState := standardNetworkState(4, 1)
+			networkState.Config.NumberOfBuckets = 8
+			Expect(mismatches(greet(networkState))).To(HaveLen(1))
+			Expect(checkpointVotes()).To(BeEmpty())
+
+			Expect(mismatches(greet(standardNetworkState(4, 1)))).To(BeEmpty())
+			Expect(checkpointVotes()).To(HaveKeyWithValue("value", []nodeID{1}))
+		})
+
+		// transfer completes a state transfer of sm to networkState at
+		// seq_no=20.
+		transfer := func(networkState *msgs.NetworkState) *ActionList {
+			sm.commitState.transferTo(20, []byte("checkpoint-value"))
+			return sm.ApplyEvent(EventStateTransferComplete(networkState, &state.ActionStateTarget{
+				SeqNo: 20,
+				Value: []byte("checkpoint-value"),
+			}))
+		}
+
+		It("announces the configuration anew once reinitialized", func() {
+			hello := hellos(transfer(standardNetworkState(4, 1)))
+			Expect(hello).To(HaveLen(1))
+			Expect(hello[0].Type.(*state.Action_Send).Send.Msg.GetHello().SeqNo).To(Equal(uint64(20)))
+		})
+
+		It("keeps ignoring a mismatched peer while the configuration is unchanged", func() {
+			networkState := standardNetworkState(4, 1)
+			networkState.Config.NumberOfBuckets = 8
+			Expect(mismatches(greet(networkState))).To(HaveLen(1))
+
+			transfer(standardNetworkState(4, 1))
+			Expect(sm.mismatchedPeers).To(HaveKey(nodeID(1)))
+		})
+
+		It("judges its peers anew once the configuration changes", func() {
+			networkState := standardNetworkState(4, 1)
+			networkState.Config.NumberOfBuckets = 8
+			Expect(mismatches(greet(networkState))).To(HaveLen(1))
+
+			transfer(networkState)
+			Expect(sm.mismatchedPeers).To(BeEmpty())
+		})
+
+		It("announces the configuration anew at every stable checkpoint", func() {
+			tn := newTestNetwork(4, 1)
+			tn.tickUntil(20, tn.inProgress)
+			tn.persistRequestsTicking(60)
+			Expect(tn.nodes[0].commitState.lowWatermark).To(BeNumerically(">=", 40))
+
+			var announced []uint64
+			for _, action := range tn.observed[0] {
+				if send, ok := action.Type.(*state.Action_Send); ok {
+					if hello := send.Send.Msg.GetHello(); hello != nil {
+						announced = append(announced, hello.SeqNo)
+					}
+				}
+			}
+			expected := []uint64{}
+			for seqNo := uint64(0); seqNo <= tn.nodes[0].commitState.lowWatermark; seqNo += 20 {
+				expected = append(expected, seqNo)
+			}
+			Expect(announced).To(Equal(expected))
+		})
+	})
+
+	It("proposes requests left uncommitted by a leader which lost its buckets", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+
+		// The request maps to bucket 0, led by node 1 in epoch 1, which only
+		// obtains it once the other nodes forward it on its fetch.
+		ack := requestAck(0, 0)
+		for _, i := range []int{0, 2, 3} {
+			tn.pending[i].concat(tn.nodes[i].ApplyEvent(EventRequestPersisted(ack)))
+		}
+		tn.tickUntil(20, func() bool {
+			for _, i := range []int{0, 2, 3} {
+				for _, action := range tn.observed[i] {
+					if forward, ok := action.Type.(*state.Action_ForwardRequest); ok {
+						Expect(forward.ForwardRequest.Targets).To(Equal([]uint64{1}))
+						return true
+					}
+				}
+			}
+			return false
+		})
+
+		// The preprepare of node 1 proposing the request never arrives.
+		tn.drop = func(source, target uint64, msg *msgs.Msg) bool {
+			pp, ok := msg.Type.(*msgs.Msg_Preprepare)
+			return ok && source == 1 && len(pp.Preprepare.Batch) > 0
+		}
+		tn.pending[1].concat(tn.nodes[1].ApplyEvent(EventRequestPersisted(ack)))
+		tn.settle()
+
+		proposers := func() map[nodeID]uint64 {
+			result := map[nodeID]uint64{}
+			for i := range tn.nodes {
+				for _, action := range tn.observed[i] {
+					send, ok := action.Type.(*state.Action_Send)
+					if !ok {
+						continue
+					}
+					pp, ok := send.Send.Msg.Type.(*msgs.Msg_Preprepare)
+					if !ok {
+						continue
+					}
+					for _, req := range pp.Preprepare.Batch {
+						if req.ClientId == 0 && req.ReqNo == 0 {
+							result[nodeID(i)] = pp.Preprepare.Epoch
+						}
+					}
+				}
+			}
+			return result
+		}
+		tn.tickUntil(20, func() bool {
+			return len(proposers()) > 0
+		})
+		Expect(proposers()).To(Equal(map[nodeID]uint64{1: 1}))
+
+		// Node 1 then crashes, and loses its buckets in the epoch change.
+		tn.crash(1)
+		tn.drop = nil
+
+		committed := func(i int) bool {
+			for _, commit := range tn.commits(i) {
+				for _, req := range commit.Batch.Requests {
+					if req.ClientId == 0 && req.ReqNo == 0 {
+						return true
+					}
+				}
+			}
+			return false
+		}
+
+		tn.tickUntil(200, func() bool {
+			return committed(0) && committed(2) && committed(3)
+		})
+
+		// The request was proposed again by the node which took over bucket 0.
+		activeEpoch := tn.nodes[0].epochTracker.currentEpoch.activeEpoch
+		newLeader := activeEpoch.buckets[0]
+		Expect(newLeader).NotTo(Equal(nodeID(1)))
+		Expect(activeEpoch.epochConfig.Number).To(BeNumerically(">", 1))
+		Expect(proposers()).To(Equal(map[nodeID]uint64{
+			1:         1,
+			newLeader: activeEpoch.epochConfig.Number,
+		}))
+	})
+
+	Describe("receiving messages from a later epoch", func() {
+		var sm *StateMachine
+
+		BeforeEach(func() {
+			sm, _ = newInitializedStateMachine(0, standardNetworkState(4, 1))
+		})
+
+		suspect := func(source, epoch uint64) {
+			sm.ApplyEvent(EventStep(source, &msgs.Msg{
+				Type: &msgs.Msg_Suspect{
+					Suspect: &msgs.Suspect{
+						Epoch: epoch,
+					},
+				},
+			}))
+		}
+
+		// tick returns the epochs this node sent epoch changes for.
+		tick := func(ticks int) []uint64 {
+			epochs := []uint64{}
+			for i := 0; i < ticks; i++ {
+				actions := sm.ApplyEvent(EventTickElapsed())
+				iter := actions.Iterator()
+				for action := iter.Next(); action != nil; action = iter.Next() {
+					send, ok := action.Type.(*state.Action_Send)
+					if !ok {
+						continue
+					}
+					if ec, ok := send.Send.Msg.Type.(*msgs.Msg_EpochChange); ok {
+						epochs = append(epochs, ec.EpochChange.NewEpoch)
+					}
+				}
+			}
+			return epochs
+		}
+
+		It("catches up once f+1 nodes reference the later epoch", func() {
+			suspect(1, 5)
+			suspect(2, 5)
+
+			Expect(tick(defaultCatchUpTicks + 1)).To(ContainElement(uint64(5)))
+			Expect(sm.epochTracker.currentEpoch.number).To(Equal(uint64(5)))
+		})
+
+		commit := func(source, epoch uint64) {
+			sm.ApplyEvent(EventStep(source, &msgs.Msg{
+				Type: &msgs.Msg_Commit{
+					Commit: &msgs.Commit{
+						SeqNo:  1,
+						Epoch:  epoch,
+						Digest: []byte("digest"),
+					},
+				},
+			}))
+		}
+
+		It("buffers commits for the next epoch, and catches up once f+1 nodes send them", func() {
+			next := sm.epochTracker.currentEpoch.number + 1
+			commit(1, next)
+			commit(2, next)
+			Expect(sm.epochTracker.futureMsgs[1].buffer.Len()).To(Equal(1))
+			Expect(sm.epochTracker.futureMsgs[2].buffer.Len()).To(Equal(1))
+
+			Expect(tick(defaultCatchUpTicks + 1)).To(ContainElement(next))
+			Expect(sm.epochTracker.currentEpoch.number).To(Equal(next))
+		})
+
+		It("drops commits beyond the lookahead, while still catching up", func() {
+			far := sm.epochTracker.currentEpoch.number + defaultFutureEpochLookahead + 1
+			commit(1, far)
+			commit(2, far)
+			Expect(sm.epochTracker.futureMsgs[1].buffer.Len()).To(Equal(0))
+			Expect(sm.epochTracker.futureMsgs[2].buffer.Len()).To(Equal(0))
+
+			Expect(tick(defaultCatchUpTicks + 1)).To(ContainElement(far))
+		})
+
+		It("does not catch up when only a single node references the later epoch", func() {
+			suspect(1, 5)
+
+			Expect(tick(defaultCatchUpTicks + 1)).NotTo(ContainElement(uint64(5)))
+			Expect(sm.epochTracker.currentEpoch.number).To(BeNumerically("<", 5))
+		})
+
+		When("the epoch jump is limited", func() {
+			BeforeEach(func() {
+				sm.myConfig.MaxEpochJump = 2
+			})
+
+			It("targets the intermediate epochs in turn when f+1 nodes reference a far epoch", func() {
+				suspect(1, 50)
+				suspect(2, 50)
+
+				previous := sm.epochTracker.currentEpoch.number
+				epochs := tick(3 * (defaultCatchUpTicks + 1))
+				Expect(epochs).NotTo(BeEmpty())
+				for _, epoch := range epochs {
+					Expect(epoch).To(BeNumerically("<=", previous+2))
+					previous = epoch
+				}
+				Expect(epochs).NotTo(ContainElement(uint64(50)))
+			})
+
+			It("skips to the far epoch when 2f+1 nodes reference it", func() {
+				suspect(1, 50)
+				suspect(2, 50)
+				suspect(3, 50)
+
+				Expect(tick(defaultCatchUpTicks + 1)).To(ContainElement(uint64(50)))
+			})
+		})
+	})
+
+	It("hands over the buckets of a leader stepping down without dropping requests", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+
+		persist := func(from, to uint64) {
+			for reqNo := from; reqNo < to; reqNo++ {
+				tn.apply(EventRequestPersisted(requestAck(0, reqNo)))
+			}
+		}
+
+		committed := func() map[uint64]struct{} {
+			result := map[uint64]struct{}{}
+			for _, commit := range tn.commits(1) {
+				for _, req := range commit.Batch.Requests {
+					result[req.ReqNo] = struct{}{}
+				}
+			}
+			return result
+		}
+
+		persist(0, 8)
+
+		tn.pending[0].concat(tn.nodes[0].ApplyEvent(EventStepDown()))
+		persist(8, 16)
+
+		startTicks := tn.ticks
+		tn.tickUntil(20, func() bool {
+			return tn.inProgress() && tn.nodes[0].epochTracker.currentEpoch.number > 1
+		})
+		// The others join the suspicion of the leader stepping down rather
+		// than waiting to suspect the epoch themselves.
+		Expect(tn.ticks - startTicks).To(BeNumerically("<=", int(tn.nodes[0].myConfig.SuspectTicks)))
+		Expect(tn.nodes[0].epochTracker.currentEpoch.activeEpoch.leads(0)).To(BeFalse())
+
+		tn.tickUntil(20, func() bool {
+			return len(committed()) == 16
+		})
+	})
+
+	It("ends the epoch of every bucket when a single leader steps down", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+		Expect(tn.nodes[0].epochTracker.currentEpoch.activeEpoch.epochConfig.Leaders).To(Equal([]uint64{0, 1, 2, 3}))
+
+		tn.pending[0].concat(tn.nodes[0].ApplyEvent(EventStepDown()))
+		tn.tickUntil(20, func() bool {
+			return tn.inProgress() && tn.nodes[0].epochTracker.currentEpoch.number > 1
+		})
+
+		// Rather than the buckets of node 0 alone changing hands, every
+		// node moved to the same new epoch, and every bucket is assigned
+		// anew among the other leaders.
+		newEpoch := tn.nodes[0].epochTracker.currentEpoch
+		Expect(newEpoch.activeEpoch.epochConfig.Leaders).NotTo(BeEmpty())
+		Expect(newEpoch.activeEpoch.epochConfig.Leaders).NotTo(ContainElement(uint64(0)))
+		for i, node := range tn.nodes {
+			activeEpoch := node.epochTracker.currentEpoch.activeEpoch
+			Expect(node.epochTracker.currentEpoch.number).To(Equal(newEpoch.number), "node=%d", i)
+			Expect(activeEpoch.epochConfig.Leaders).To(Equal(newEpoch.activeEpoch.epochConfig.Leaders), "node=%d", i)
+			Expect(activeEpoch.buckets).To(Equal(newEpoch.activeEpoch.buckets), "node=%d", i)
+		}
+	})
+
+	It("leaves a leader stepping down out of the leaders every node chooses next", func() {
+		tn := newTestNetwork(4, 1, func(sm *StateMachine) {
+			sm.LeaderSelector = DefaultLeaderSelector
+		})
+		tn.tickUntil(20, tn.inProgress)
+
+		tn.pending[1].concat(tn.nodes[1].ApplyEvent(EventStepDown()))
+		tn.tickUntil(20, func() bool {
+			return tn.inProgress() && tn.nodes[0].epochTracker.currentEpoch.number > 1
+		})
+
+		for i, node := range tn.nodes {
+			Expect(node.epochTracker.currentEpoch.myLeaderChoice).NotTo(ContainElement(uint64(1)), "node=%d", i)
+			Expect(node.epochTracker.currentEpoch.myLeaderChoice).NotTo(BeEmpty(), "node=%d", i)
+		}
+		Expect(tn.nodes[0].epochTracker.currentEpoch.activeEpoch.leads(1)).To(BeFalse())
+	})
+
+	It("raises an instability alarm when epochs change too often", func() {
+		tn := newTestNetwork(4, 1)
+		tn.nodes[0].myConfig.EpochChangeAlarmThreshold = 2
+		tn.nodes[0].myConfig.EpochChangeAlarmWindowTicks = 20
+		tn.tickUntil(20, tn.inProgress)
+
+		alarms := func() []*state.ActionEpochInstability {
+			var result []*state.ActionEpochInstability
+			for _, action := range tn.observed[0] {
+				if alarm, ok := action.Type.(*state.Action_EpochInstability); ok {
+					result = append(result, alarm.EpochInstability)
+				}
+			}
+			return result
+		}
+
+		// Every leader of the active epoch steps down, forcing an epoch change.
+		stepDown := func() {
+			epoch := tn.nodes[0].epochTracker.currentEpoch.number
+			for i, node := range tn.nodes {
+				tn.pending[i].concat(node.ApplyEvent(EventStepDown()))
+			}
+			tn.tickUntil(50, func() bool {
+				return tn.inProgress() && tn.nodes[0].epochTracker.currentEpoch.number > epoch
+			})
+		}
+
+		stepDown()
+		Expect(alarms()).To(BeEmpty())
+		Expect(tn.nodes[0].epochTracker.status().Unstable).To(BeFalse())
+
+		stepDown()
+		Expect(alarms()).To(HaveLen(1))
+		Expect(alarms()[0].EpochChanges).To(Equal(uint32(2)))
+		Expect(alarms()[0].WindowTicks).To(Equal(uint32(20)))
+		Expect(tn.nodes[0].epochTracker.status().Unstable).To(BeTrue())
+
+		// The alarm clears once the epoch changes fall out of the window.
+		for i := 0; i < 20; i++ {
+			tn.apply(EventTickElapsed())
+		}
+		Expect(tn.nodes[0].epochTracker.status().Unstable).To(BeFalse())
+		Expect(alarms()).To(HaveLen(1))
+	})
+
+	Describe("reporting the reason for each epoch change", func() {
+		var tn *testNetwork
+
+		BeforeEach(func() {
+			tn = newTestNetwork(4, 1)
+			tn.tickUntil(20, tn.inProgress)
+		})
+
+		// reasons returns the reasons node i reported for its epoch changes.
+		reasons := func(i int) []string {
+			var result []string
+			for _, action := range tn.observed[i] {
+				if changed, ok := action.Type.(*state.Action_EpochChanged); ok {
+					Expect(changed.EpochChanged.Epoch).To(BeNumerically(">", changed.EpochChanged.PreviousEpoch))
+					result = append(result, changed.EpochChanged.Reason)
+				}
+			}
+			return result
+		}
+
+		epochChanged := func(i int) func() bool {
+			return func() bool {
+				return tn.nodes[i].epochTracker.currentEpoch.number > 1
+			}
+		}
+
+		It("reports an epoch reaching its planned expiration as expired", func() {
+			expiration := tn.nodes[0].epochTracker.currentEpoch.activeEpoch.epochConfig.PlannedExpiration
+			tn.persistRequests(expiration + 20)
+
+			tn.tickUntil(100, epochChanged(0))
+			Expect(reasons(0)).To(Equal([]string{EpochChangeExpired}))
+		})
+
+		It("reports a silent leader suspected by this node as a timeout", func() {
+			tn.crash(1)
+
+			// The epoch which follows consists only of the final preprepares
+			// of the epoch change, so it expires straight away.
+			tn.tickUntil(100, epochChanged(0))
+			Expect(reasons(0)).To(Equal([]string{EpochChangeTimeout, EpochChangeExpired}))
+		})
+
+		It("reports joining the suspicions of others as a suspicion", func() {
+			tn.nodes[0].myConfig.SuspectTicks = 1000
+			tn.crash(1)
+
+			tn.tickUntil(100, epochChanged(0))
+			Expect(reasons(0)).To(Equal([]string{EpochChangeSuspicion, EpochChangeExpired}))
+		})
+
+		It("reports a leader stepping down as a step down", func() {
+			tn.pending[0].concat(tn.nodes[0].ApplyEvent(EventStepDown()))
+
+			tn.tickUntil(100, epochChanged(1))
+			Expect(reasons(0)).To(Equal([]string{EpochChangeStepDown}))
+			Expect(reasons(1)).To(Equal([]string{EpochChangeStepDown}))
+		})
+
+		It("reports following f+1 nodes to a later epoch as catching up", func() {
+			for _, source := range []uint64{1, 2} {
+				tn.pending[0].concat(tn.nodes[0].ApplyEvent(EventStep(source, &msgs.Msg{
+					Type: &msgs.Msg_Suspect{
+						Suspect: &msgs.Suspect{
+							Epoch: 5,
+						},
+					},
+				})))
+			}
+			tn.drop = func(source, target uint64, msg *msgs.Msg) bool {
+				return source == 0 || target == 0
+			}
+
+			tn.tickUntil(100, func() bool {
+				return tn.nodes[0].epochTracker.currentEpoch.number == 5
+			})
+			Expect(reasons(0)).To(Equal([]string{EpochChangeCatchUp}))
+		})
+	})
+
+	It("discards the messages buffered for an epoch once it is superseded", func() {
+		networkState := standardNetworkState(4, 1)
+		networkState.Config.MaxEpochLength = 200
+		tn := newTestNetworkFromState(networkState)
+		tn.tickUntil(20, tn.inProgress)
+
+		// Node 0 leads bucket 3 in epoch 1, buffer a preprepare and a commit
+		// beyond the high watermark at node 2.
+		epoch := tn.nodes[2].epochTracker.currentEpoch
+		activeEpoch := epoch.activeEpoch
+		seqNo := activeEpoch.highWatermark() + 1
+		for seqToBucket(seqNo, activeEpoch.networkConfig) != 3 {
+			seqNo++
+		}
+		tn.pending[2].concat(tn.nodes[2].ApplyEvent(EventStep(0, &msgs.Msg{
+			Type: &msgs.Msg_Preprepare{
+				Preprepare: &msgs.Preprepare{
+					SeqNo: seqNo,
+					Epoch: epoch.number,
+				},
+			},
+		})))
+		tn.pending[2].concat(tn.nodes[2].ApplyEvent(EventStep(1, &msgs.Msg{
+			Type: &msgs.Msg_Commit{
+				Commit: &msgs.Commit{
+					SeqNo:  seqNo,
+					Epoch:  epoch.number,
+					Digest: []byte("digest"),
+				},
+			},
+		})))
+		Expect(activeEpoch.preprepareBuffers[3].buffer.buffer.Len()).To(Equal(1))
+		Expect(activeEpoch.otherBuffers[1].buffer.Len()).To(Equal(1))
+
+		for i, node := range tn.nodes {
+			tn.pending[i].concat(node.ApplyEvent(EventStepDown()))
+		}
+		tn.tickUntil(50, func() bool {
+			return tn.inProgress() && tn.nodes[2].epochTracker.currentEpoch.number > epoch.number
+		})
+
+		Expect(activeEpoch.preprepareBuffers[3].buffer.buffer.Len()).To(Equal(0))
+		Expect(activeEpoch.otherBuffers[1].buffer.Len()).To(Equal(0))
+		for _, nodeBuffer := range tn.nodes[2].nodeBuffers.status() {
+			for _, msgBuffer := range nodeBuffer.MsgBuffers {
+				Expect(msgBuffer.Component).NotTo(HavePrefix(fmt.Sprintf("epoch-%d-", epoch.number)))
+			}
+		}
+	})
+
+	It("reports an epoch as fresh until it commits its first sequence", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+
+		fresh := func() bool {
+			s, err := tn.nodes[0].Status()
+			Expect(err).NotTo(HaveOccurred())
+			return s.EpochTracker.ActiveEpoch.Fresh
+		}
+
+		epoch := tn.nodes[0].epochTracker.currentEpoch.number
+		for i, node := range tn.nodes {
+			tn.pending[i].concat(node.ApplyEvent(EventStepDown()))
+		}
+		tn.tickUntil(50, func() bool {
+			return tn.inProgress() && tn.nodes[0].epochTracker.currentEpoch.number > epoch
+		})
+		Expect(fresh()).To(BeTrue())
+
+		highestCommit := tn.nodes[0].commitState.highestCommit
+		tn.apply(EventRequestPersisted(requestAck(0, 0)))
+		tn.tickUntil(20, func() bool {
+			return tn.nodes[0].commitState.highestCommit > highestCommit
+		})
+		Expect(fresh()).To(BeFalse())
+	})
+
+	It("caps the epoch change messages buffered from a flooding node", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+
+		var misbehaviors []*state.ActionMisbehavior
+		for i := uint64(0); i < 20; i++ {
+			actions := tn.nodes[0].ApplyEvent(EventStep(3, &msgs.Msg{
+				Type: &msgs.Msg_EpochChange{
+					EpochChange: &msgs.EpochChange{
+						NewEpoch: 100 + i,
+					},
+				},
+			}))
+			iter := actions.Iterator()
+			for action := iter.Next(); action != nil; action = iter.Next() {
+				if misbehavior, ok := action.Type.(*state.Action_Misbehavior); ok {
+					misbehaviors = append(misbehaviors, misbehavior.Misbehavior)
+				}
+			}
+		}
+
+		Expect(tn.nodes[0].epochTracker.futureEpochChanges[3].buffer.Len()).To(Equal(defaultMaxBufferedEpochChanges))
+		Expect(misbehaviors).To(HaveLen(20 - defaultMaxBufferedEpochChanges))
+		for _, misbehavior := range misbehaviors {
+			Expect(misbehavior.NodeId).To(Equal(uint64(3)))
+		}
+
+		// The epoch changes of the other nodes are still processed.
+		epoch := tn.nodes[0].epochTracker.currentEpoch.number
+		for i, node := range tn.nodes {
+			tn.pending[i].concat(node.ApplyEvent(EventStepDown()))
+		}
+		tn.tickUntil(50, func() bool {
+			return tn.inProgress() && tn.nodes[0].epochTracker.currentEpoch.number > epoch
+		})
+		Expect(tn.nodes[0].epochTracker.futureEpochChanges[3].buffer.Len()).To(Equal(defaultMaxBufferedEpochChanges))
+	})
+
+	It("caps the epoch change messages buffered from a lagging node without flagging it", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+
+		// Node 3 is cut off while the others checkpoint without it.
+		tn.crash(3)
+		tn.tickUntil(100, func() bool {
+			return tn.nodes[0].commitState.lowWatermark >= 20
+		})
+		Expect(tn.nodes[0].checkpointTracker.lagging(3)).To(BeTrue())
+		Expect(tn.nodes[0].checkpointTracker.lagging(2)).To(BeFalse())
+
+		flood := func(source uint64) int {
+			misbehaviors := 0
+			for i := uint64(0); i < 20; i++ {
+				iter := tn.nodes[0].ApplyEvent(EventStep(source, &msgs.Msg{
+					Type: &msgs.Msg_EpochChange{
+						EpochChange: &msgs.EpochChange{
+							NewEpoch: 100 + i,
+						},
+					},
+				})).Iterator()
+				for action := iter.Next(); action != nil; action = iter.Next() {
+					if _, ok := action.Type.(*state.Action_Misbehavior); ok {
+						misbehaviors++
+					}
+				}
+			}
+			return misbehaviors
+		}
+
+		Expect(flood(3)).To(Equal(0))
+		Expect(tn.nodes[0].epochTracker.futureEpochChanges[3].buffer.Len()).To(Equal(defaultMaxBufferedEpochChanges))
+
+		// A node which is not behind is flagged for the same flood.
+		Expect(flood(2)).To(Equal(20 - defaultMaxBufferedEpochChanges))
+		Expect(tn.nodes[0].epochTracker.futureEpochChanges[2].buffer.Len()).To(Equal(defaultMaxBufferedEpochChanges))
+	})
+
+	It("buffers the epoch changes and acknowledgements a correct node sends for future epochs", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+
+		misbehaviors := 0
+		step := func(msg *msgs.Msg) {
+			iter := tn.nodes[0].ApplyEvent(EventStep(3, msg)).Iterator()
+			for action := iter.Next(); action != nil; action = iter.Next() {
+				if _, ok := action.Type.(*state.Action_Misbehavior); ok {
+					misbehaviors++
+				}
+			}
+		}
+		ack := func(epoch, originator uint64) *msgs.Msg {
+			return &msgs.Msg{
+				Type: &msgs.Msg_EpochChangeAck{
+					EpochChangeAck: &msgs.EpochChangeAck{
+						Originator:  originator,
+						EpochChange: &msgs.EpochChange{NewEpoch: epoch},
+					},
+				},
+			}
+		}
+
+		// For each epoch, node 3 sends its epoch change, and acknowledges
+		// those of the other three nodes.
+		for epoch := uint64(100); epoch < 100+defaultMaxBufferedEpochChanges; epoch++ {
+			step(&msgs.Msg{
+				Type: &msgs.Msg_EpochChange{
+					EpochChange: &msgs.EpochChange{NewEpoch: epoch},
+				},
+			})
+			for originator := uint64(0); originator < 3; originator++ {
+				step(ack(epoch, originator))
+			}
+		}
+		Expect(misbehaviors).To(Equal(0))
+		Expect(tn.nodes[0].epochTracker.futureEpochChanges[3].buffer.Len()).To(Equal(4 * defaultMaxBufferedEpochChanges))
+
+		// Messages for a further epoch are an excess.
+		step(ack(100+defaultMaxBufferedEpochChanges, 0))
+		Expect(misbehaviors).To(Equal(1))
+	})
+
+	It("notifies speculative preprepares, and their discarding by an epoch change", func() {
+		type speculation struct {
+			clientID, reqNo, seqNo uint64
+		}
+		preprepared := map[*StateMachine][]speculation{}
+		discarded := map[*StateMachine][]speculation{}
+
+		// The first request of client 3 maps to bucket 3, led by node 0 in
+		// epoch 1.
+		networkState := standardNetworkState(4, 1)
+		networkState.Clients = append(networkState.Clients, &msgs.NetworkState_Client{
+			Id:    3,
+			Width: 100,
+		})
+		tn := newTestNetworkFromState(networkState, func(sm *StateMachine) {
+			sm.OnPreprepared = func(clientID, reqNo, seqNo uint64) {
+				preprepared[sm] = append(preprepared[sm], speculation{clientID, reqNo, seqNo})
+			}
+			sm.OnPreprepareDiscarded = func(clientID, reqNo, seqNo uint64) {
+				discarded[sm] = append(discarded[sm], speculation{clientID, reqNo, seqNo})
+			}
+		})
+		tn.tickUntil(20, tn.inProgress)
+
+		// Only node 0 learns of its own preprepare.
+		tn.drop = func(source, target uint64, msg *msgs.Msg) bool {
+			_, ok := msg.Type.(*msgs.Msg_Preprepare)
+			return ok && source == 0 && target != 0
+		}
+		tn.apply(EventRequestPersisted(requestAck(3, 0)))
+
+		node0 := tn.nodes[0]
+		Expect(preprepared[node0]).To(HaveLen(1))
+		seqNo := preprepared[node0][0].seqNo
+		Expect(preprepared[node0][0]).To(Equal(speculation{3, 0, seqNo}))
+		Expect(node0.commitState.highestCommit).To(BeNumerically("<", seqNo))
+		Expect(discarded[node0]).To(BeEmpty())
+		for _, sm := range tn.nodes[1:] {
+			Expect(preprepared[sm]).To(BeEmpty())
+		}
+
+		// As no other node preprepared the sequence, the epoch change
+		// which follows node 0 stepping down discards it.
+		tn.pending[0].concat(node0.ApplyEvent(EventStepDown()))
+		tn.tickUntil(100, func() bool {
+			for _, commit := range tn.commits(0) {
+				if len(commit.Batch.Requests) > 0 {
+					return true
+				}
+			}
+			return false
+		})
+
+		Expect(discarded[node0]).To(Equal([]speculation{{3, 0, seqNo}}))
+		Expect(preprepared[node0]).To(HaveLen(2))
+		Expect(preprepared[node0][1].clientID).To(Equal(uint64(3)))
+		Expect(preprepared[node0][1].reqNo).To(Equal(uint64(0)))
+	})
+
+	It("reports the bucket and leader of each sequence allocated", func() {
+		type allocation struct {
+			seqNo  uint64
+			bucket uint32
+			leader uint64
+		}
+		allocations := map[*StateMachine][]allocation{}
+
+		tn := newTestNetwork(4, 1, func(sm *StateMachine) {
+			sm.OnSequenceAllocated = func(seqNo uint64, bucket uint32, leader uint64) {
+				allocations[sm] = append(allocations[sm], allocation{seqNo, bucket, leader})
+			}
+		})
+		tn.tickUntil(20, tn.inProgress)
+		tn.apply(EventRequestPersisted(requestAck(0, 0)))
+		tn.tickUntil(20, func() bool {
+			for _, sm := range tn.nodes {
+				if sm.commitState.highestCommit < 8 {
+					return false
+				}
+			}
+			return true
+		})
+
+		// Bucket b is led by node b+1 in epoch 1, every node reports each
+		// sequence once, whether it cut the batch or accepted the preprepare.
+		for _, sm := range tn.nodes {
+			reported := map[uint64]allocation{}
+			for _, a := range allocations[sm] {
+				Expect(reported).NotTo(HaveKey(a.seqNo))
+				reported[a.seqNo] = a
+			}
+			for seqNo := uint64(1); seqNo <= 8; seqNo++ {
+				bucket := SeqToBucket(seqNo, 4)
+				Expect(reported).To(HaveKeyWithValue(seqNo, allocation{seqNo, bucket, uint64(bucket+1) % 4}))
+			}
+		}
+	})
+
+	It("resumes an epoch change from its write-ahead log after a restart", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+
+		// With node 1 silent, the others change epoch, but node 0 does not
+		// hear from node 3, and so cannot complete the epoch change.
+		tn.crash(1)
+		tn.drop = func(source, target uint64, msg *msgs.Msg) bool {
+			switch msg.Type.(type) {
+			case *msgs.Msg_EpochChange, *msgs.Msg_EpochChangeAck:
+				return source == 3 && target == 0
+			default:
+				return false
+			}
+		}
+
+		epochTarget := func() *epochTarget {
+			return tn.nodes[0].epochTracker.currentEpoch
+		}
+		acks := func() map[nodeID]int {
+			result := map[nodeID]int{}
+			for origin, change := range epochTarget().changes {
+				for _, parsedChange := range change.parsedByDigest {
+					result[origin] += len(parsedChange.acks)
+				}
+			}
+			return result
+		}
+
+		tn.tickUntil(20, func() bool {
+			return epochTarget().number == 2
+		})
+		Expect(epochTarget().state).To(BeEquivalentTo(etPrepending))
+		collected := acks()
+		Expect(collected).NotTo(BeEmpty())
+
+		// Each epoch change is persisted once, its later acknowledgements
+		// only reference it.
+		stored := map[string]int{}
+		for _, action := range tn.observed[0] {
+			if write, ok := action.Type.(*state.Action_AppendWriteAhead); ok {
+				if eaEntry := write.AppendWriteAhead.Data.GetEAEntry(); eaEntry != nil && eaEntry.EpochChange != nil {
+					stored[fmt.Sprintf("%d-%x", eaEntry.Origin, eaEntry.Digest)]++
+				}
+			}
+		}
+		Expect(stored).NotTo(BeEmpty())
+		for _, count := range stored {
+			Expect(count).To(Equal(1))
+		}
+
+		tn.drop = nil
+		tn.restart(0)
+		Expect(epochTarget().number).To(Equal(uint64(2)))
+		Expect(acks()).To(Equal(collected))
+
+		tn.tickUntil(100, func() bool {
+			for i, sm := range tn.nodes {
+				if tn.crashed[i] {
+					continue
+				}
+				if sm.epochTracker.currentEpoch.number < 2 || sm.epochTracker.currentEpoch.state != etInProgress {
+					return false
+				}
+			}
+			return true
+		})
+	})
+
+	It("reports the commit watermark as the application acknowledges commits", func() {
+		tn := newTestNetwork(4, 1)
+		tn.tickUntil(20, tn.inProgress)
+		tn.apply(EventRequestPersisted(requestAck(0, 0)))
+		tn.tickUntil(20, func() bool {
+			return tn.nodes[0].commitState.highestCommit >= 4
+		})
+
+		watermarks := func() []uint64 {
+			result := []uint64{}
+			for _, action := range tn.observed[0] {
+				if persist, ok := action.Type.(*state.Action_PersistWatermark); ok {
+					result = append(result, persist.PersistWatermark.SeqNo)
+				}
+			}
+			return result
+		}
+		Expect(watermarks()).To(BeEmpty())
+
+		tn.pending[0].concat(tn.nodes[0].ApplyEvent(EventCommitsApplied(1, 2)))
+		tn.settle()
+		Expect(watermarks()).To(Equal([]uint64{2}))
+
+		// Acknowledgements which do not move the watermark are not reported.
+		tn.pending[0].concat(tn.nodes[0].ApplyEvent(EventCommitsApplied(1, 2)))
+		tn.settle()
+		Expect(watermarks()).To(Equal([]uint64{2}))
+
+		tn.pending[0].concat(tn.nodes[0].ApplyEvent(EventCommitsApplied(3, 4)))
+		tn.settle()
+		Expect(watermarks()).To(Equal([]uint64{2, 4}))
+	})
+
+	Describe("compacting", func() {
+		var tn *testNetwork
+
+		truncations := func(actions *ActionList) []uint64 {
+			result := []uint64{}
+			iter := actions.Iterator()
+			for action := iter.Next(); action != nil; action = iter.Next() {
+				if truncate, ok := action.Type.(*state.Action_TruncateWriteAhead); ok {
+					result = append(result, truncate.TruncateWriteAhead.Index)
+				}
+			}
+			return result
+		}
+
+		BeforeEach(func() {
+			tn = newTestNetwork(4, 1)
+			tn.tickUntil(20, tn.inProgress)
+		})
+
+		It("is a no-op when nothing has become stable", func() {
+			Expect(truncations(tn.nodes[0].ApplyEvent(EventCompact()))).To(BeEmpty())
+		})
+
+		It("truncates the log to the stable checkpoint", func() {
+			tn.persistRequestsTicking(30)
+
+			sm := tn.nodes[0]
+			Expect(sm.checkpointTracker.lowWatermark()).To(Equal(uint64(20)))
+
+			// The stable checkpoint itself must survive the truncation.
+			cEntries := []uint64{}
+			sm.persisted.iterate(logIterator{
+				onCEntry: func(cEntry *msgs.CEntry) {
+					cEntries = append(cEntries, cEntry.SeqNo)
+				},
+			})
+			Expect(cEntries).To(Equal([]uint64{20}))
+
+			head := sm.persisted.logHead
+			Expect(head.index).To(BeNumerically(">", 1))
+			Expect(truncations(sm.ApplyEvent(EventCompact()))).To(Equal([]uint64{head.index}))
+			Expect(truncations(sm.ApplyEvent(EventCompact()))).To(BeEmpty())
+		})
+
+		It("does not compact again a log truncated as a reconfiguration is adopted", func() {
+			tn.consumer.pendingReconfigurations = func(seqNo uint64) []*msgs.Reconfiguration {
+				if seqNo != 20 {
+					return nil
+				}
+				newConfig := standardNetworkState(4, 1).Config
+				newConfig.CheckpointInterval = 10
+				return []*msgs.Reconfiguration{
+					{
+						Type: &msgs.Reconfiguration_NewConfig{
+							NewConfig: newConfig,
+						},
+					},
+				}
+			}
+
+			epochEnded := func() bool {
+				for _, action := range tn.observed[0] {
+					if write, ok := action.Type.(*state.Action_AppendWriteAhead); ok && write.AppendWriteAhead.Data.GetFEntry() != nil {
+						return true
+					}
+				}
+				return false
+			}
+
+			// Adopting the reconfiguration ends the epoch with an FEntry,
+			// to whose checkpoint the log is truncated when recovering it.
+			for reqNo := uint64(0); !epochEnded(); reqNo++ {
+				Expect(reqNo).To(BeNumerically("<", 60))
+				tn.apply(EventRequestPersisted(requestAck(0, reqNo)))
+				tn.apply(EventTickElapsed())
+			}
+
+			tn.restart(0)
+			sm := tn.nodes[0]
+			Expect(truncations(tn.pending[0])).To(Equal([]uint64{sm.persisted.logHead.index}))
+			Expect(truncations(sm.ApplyEvent(EventCompact()))).To(BeEmpty())
+		})
+	})
+
+	Describe("changing the checkpoint interval through reconfiguration", func() {
+		var (
+			tn                    *testNetwork
+			newCheckpointInterval int32
+		)
+
+		BeforeEach(func() {
+			tn = newTestNetwork(4, 1)
+			tn.consumer.pendingReconfigurations = func(seqNo uint64) []*msgs.Reconfiguration {
+				if seqNo != 20 {
+					return nil
+				}
+				newConfig := standardNetworkState(4, 1).Config
+				newConfig.CheckpointInterval = newCheckpointInterval
+				return []*msgs.Reconfiguration{
+					{
+						Type: &msgs.Reconfiguration_NewConfig{
+							NewConfig: newConfig,
+						},
+					},
+				}
+			}
+		})
+
+		checkpoints := func() [][]uint64 {
+			tn.tickUntil(20, tn.inProgress)
+			tn.persistRequestsTicking(60)
+
+			result := make([][]uint64, len(tn.nodes))
+			for i, observed := range tn.observed {
+				for _, action := range observed {
+					if cp, ok := action.Type.(*state.Action_Checkpoint); ok {
+						result[i] = append(result[i], cp.Checkpoint.SeqNo)
+					}
+				}
+			}
+			return result
+		}
+
+		It("checkpoints at the new interval on all nodes after the next checkpoint", func() {
+			newCheckpointInterval = 10
+			for _, seqNos := range checkpoints() {
+				Expect(seqNos).To(Equal([]uint64{20, 40, 50, 60}))
+			}
+		})
+
+		It("ignores an interval which does not divide the checkpoint it takes effect at", func() {
+			newCheckpointInterval = 15
+			for _, seqNos := range checkpoints() {
+				Expect(seqNos).To(Equal([]uint64{20, 40, 60}))
+			}
+
+			// The checkpoint results the nodes were given are left as is.
+			value := sha256.Sum256([]byte("checkpoint-20"))
+			Expect(tn.consumer.checkpointStates[string(value[:])].PendingReconfigurations).To(HaveLen(1))
+		})
+	})
+
+	It("adds a node as a learner before promoting it to a voter", func() {
+		tn := newTestNetwork(4, 1)
+		tn.consumer.pendingReconfigurations = func(seqNo uint64) []*msgs.Reconfiguration {
+			newConfig := standardNetworkState(4, 1).Config
+			switch seqNo {
+			case 20:
+				newConfig.Learners = []uint64{4}
+			case 40:
+				newConfig.Nodes = []uint64{0, 1, 2, 3, 4}
+			default:
+				return nil
+			}
+			return []*msgs.Reconfiguration{
+				{
+					Type: &msgs.Reconfiguration_NewConfig{
+						NewConfig: newConfig,
+					},
+				},
+			}
+		}
+
+		// Node 4 is not part of the test network, so messages to it are lost.
+		var checkpointTargets map[uint64][]uint64
+		tn.drop = func(source, target uint64, msg *msgs.Msg) bool {
+			if checkpoint, ok := msg.Type.(*msgs.Msg_Checkpoint); ok && source == 0 {
+				checkpointTargets[checkpoint.Checkpoint.SeqNo] = append(checkpointTargets[checkpoint.Checkpoint.SeqNo], target)
+			}
+			return target == 4
+		}
+		checkpointTargets = map[uint64][]uint64{}
+
+		quorums := map[uint64]int{}
+		tn.tickUntil(20, tn.inProgress)
+		for reqNo := uint64(0); reqNo < 60 && len(quorums) < 4; reqNo++ {
+			tn.apply(EventRequestPersisted(requestAck(0, reqNo)))
+			tn.apply(EventTickElapsed())
+
+			for _, action := range tn.observed[0] {
+				if applied, ok := action.Type.(*state.Action_StateApplied); ok {
+					quorums[applied.StateApplied.SeqNo] = intersectionQuorum(applied.StateApplied.NetworkState.Config)
+				}
+			}
+		}
+
+		// The learner receives the checkpoints once added, but the quorum
+		// only grows once it is promoted.
+		Expect(quorums).To(Equal(map[uint64]int{0: 3, 20: 3, 40: 3, 60: 4}))
+		Expect(checkpointTargets[20]).NotTo(ContainElement(uint64(4)))
+		Expect(checkpointTargets[40]).To(ContainElement(uint64(4)))
+		Expect(checkpointTargets[60]).To(ContainElement(uint64(4)))
+	})
+
+	It("catches a learner up from the checkpoints of the network, without it voting", func() {
+		tn := newTestNetwork(4, 1)
+		tn.consumer.pendingReconfigurations = func(seqNo uint64) []*msgs.Reconfiguration {
+			if seqNo != 20 {
+				return nil
+			}
+			newConfig := standardNetworkState(4, 1).Config
+			newConfig.Learners = []uint64{4}
+			return []*msgs.Reconfiguration{
+				{
+					Type: &msgs.Reconfiguration_NewConfig{
+						NewConfig: newConfig,
+					},
+				},
+			}
+		}
+
+		// Node 4 starts from the genesis state of the network, which it is
+		// not part of, and is only sent messages once it is a learner.
+		learner := &StateMachine{
+			Logger: logger.ConsoleErrorLogger,
+		}
+		tn.nodes = append(tn.nodes, learner)
+		tn.pending = append(tn.pending, loadStateMachine(learner, 4, 1, genesisLog(tn.networkState, tn.genesis[0])))
+		tn.observed = append(tn.observed, nil)
+		tn.crashed = append(tn.crashed, false)
+
+		tn.tickUntil(20, func() bool {
+			for _, sm := range tn.nodes[:4] {
+				if sm.epochTracker.currentEpoch.state != etInProgress {
+					return false
+				}
+			}
+			return true
+		})
+		tn.persistRequestsTicking(60)
+
+		Expect(learner.learning()).To(BeTrue())
+		Expect(learner.commitState.lowWatermark).To(BeNumerically(">=", 40))
+		Expect(learner.commitState.lowWatermark).To(Equal(tn.nodes[0].commitState.lowWatermark))
+
+		// The learner only reports the checkpoints it caught up to.
+		for _, action := range tn.observed[4] {
+			if send, ok := action.Type.(*state.Action_Send); ok {
+				Expect(send.Send.Msg.Type).To(Or(
+					BeAssignableToTypeOf(&msgs.Msg_Checkpoint{}),
+					BeAssignableToTypeOf(&msgs.Msg_Hello{}),
+				))
+			}
+		}
+
+		s, err := tn.nodes[0].Status()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Learners).To(Equal([]*status.Learner{
+			{ID: 4, CaughtUpTo: learner.commitState.lowWatermark},
+		}))
+	})
+
+	It("reports an unrecoverable state once nodes are removed below 3F+1", func() {
+		tn := newTestNetwork(4, 1)
+		tn.consumer.pendingReconfigurations = func(seqNo uint64) []*msgs.Reconfiguration {
+			if seqNo != 20 {
+				return nil
+			}
+			newConfig := standardNetworkState(4, 1).Config
+			newConfig.Nodes = []uint64{0, 1, 2}
+			return []*msgs.Reconfiguration{
+				{
+					Type: &msgs.Reconfiguration_NewConfig{
+						NewConfig: newConfig,
+					},
+				},
+			}
+		}
+
+		unrecoverable := func() bool {
+			for _, action := range tn.observed[0] {
+				if _, ok := action.Type.(*state.Action_Unrecoverable); ok {
+					return true
+				}
+			}
+			return false
+		}
+
+		tn.tickUntil(20, tn.inProgress)
+		for reqNo := uint64(0); reqNo < 60 && !unrecoverable(); reqNo++ {
+			tn.apply(EventRequestPersisted(requestAck(0, reqNo)))
+			tn.apply(EventTickElapsed())
+		}
+
+		Expect(tn.observed[0]).To(ContainElement(Equal(ActionUnrecoverable(
+			"network of 3 nodes cannot tolerate f=1 faults, at least 4 nodes are required, or f may be at most 0",
+		))))
+	})
+})
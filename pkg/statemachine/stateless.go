@@ -10,9 +10,18 @@ package statemachine
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
 )
 
 func isCommitted(reqNo uint64, clientState *msgs.NetworkState_Client) bool {
@@ -112,12 +121,403 @@ func someCorrectQuorum(nc *msgs.NetworkState_Config) int {
 	return int(nc.F) + 1
 }
 
+// epochQuorums returns the number of epoch change messages required to form
+// a new epoch, and the number referencing a later epoch required to join it.
+// These are the intersection and some correct quorums of the network, unless
+// myConfig overrides either of them.  The overrides are only honored if
+// epochQuorumError accepts them.
+func epochQuorums(nc *msgs.NetworkState_Config, myConfig *state.EventInitialParameters) (change, join int) {
+	if epochQuorumError(nc, myConfig) != nil {
+		return intersectionQuorum(nc), someCorrectQuorum(nc)
+	}
+	return overriddenEpochQuorums(nc, myConfig)
+}
+
+// overriddenEpochQuorums returns the epoch change and join quorums, each
+// overridden by myConfig if set there, whether or not the result is valid.
+func overriddenEpochQuorums(nc *msgs.NetworkState_Config, myConfig *state.EventInitialParameters) (change, join int) {
+	change, join = intersectionQuorum(nc), someCorrectQuorum(nc)
+	if myConfig.EpochChangeQuorum != 0 {
+		change = int(myConfig.EpochChangeQuorum)
+	}
+	if myConfig.EpochJoinQuorum != 0 {
+		join = int(myConfig.EpochJoinQuorum)
+	}
+	return change, join
+}
+
+// epochQuorumError returns an error if the epoch quorums myConfig overrides
+// are less strict than the defaults, as smaller quorums would not be safe, or
+// if the network is too small to satisfy them.
+func epochQuorumError(nc *msgs.NetworkState_Config, myConfig *state.EventInitialParameters) error {
+	change, join := overriddenEpochQuorums(nc, myConfig)
+	switch {
+	case change < intersectionQuorum(nc):
+		return errors.Errorf("epoch change quorum %d is below the intersection quorum of %d", change, intersectionQuorum(nc))
+	case change > len(nc.Nodes):
+		return errors.Errorf("epoch change quorum %d exceeds the %d nodes of the network", change, len(nc.Nodes))
+	case join < someCorrectQuorum(nc):
+		return errors.Errorf("epoch join quorum %d is below the some correct quorum of %d", join, someCorrectQuorum(nc))
+	case join > change:
+		return errors.Errorf("epoch join quorum %d exceeds the epoch change quorum %d", join, change)
+	}
+	return nil
+}
+
+// MaxFaultTolerance returns the largest number of faults f which a network of
+// numNodes nodes can tolerate, that is, the largest f such that 3f+1 <= numNodes.
+func MaxFaultTolerance(numNodes int) int {
+	if numNodes < 1 {
+		return 0
+	}
+	return (numNodes - 1) / 3
+}
+
+// faultToleranceError returns an error if the network configuration has too
+// few nodes to tolerate its configured number of faults, in which case the
+// network can no longer be relied upon to form quorums.
+func faultToleranceError(nc *msgs.NetworkState_Config) error {
+	if maxF := MaxFaultTolerance(len(nc.Nodes)); int(nc.F) > maxF {
+		return errors.Errorf("network of %d nodes cannot tolerate f=%d faults, at least %d nodes are required, or f may be at most %d", len(nc.Nodes), nc.F, 3*int(nc.F)+1, maxF)
+	}
+	return nil
+}
+
+// bucketLimitError returns an error if the network configuration limits the
+// buckets each node may lead so tightly that its nodes cannot lead every bucket.
+func bucketLimitError(nc *msgs.NetworkState_Config) error {
+	if nc.MaxBucketsPerNode == 0 {
+		return nil
+	}
+
+	if capacity := len(nc.Nodes) * int(nc.MaxBucketsPerNode); capacity < int(nc.NumberOfBuckets) {
+		return errors.Errorf("network of %d nodes leading at most %d buckets each cannot lead %d buckets", len(nc.Nodes), nc.MaxBucketsPerNode, nc.NumberOfBuckets)
+	}
+	return nil
+}
+
+// learnerError returns an error if a learner of the network configuration is
+// listed more than once, or is also one of its nodes.
+func learnerError(nc *msgs.NetworkState_Config) error {
+	ids := map[uint64]struct{}{}
+	for _, node := range nc.Nodes {
+		ids[node] = struct{}{}
+	}
+
+	for _, learner := range nc.Learners {
+		if _, ok := ids[learner]; ok {
+			return errors.Errorf("learner %d is already a node, or listed twice", learner)
+		}
+		ids[learner] = struct{}{}
+	}
+	return nil
+}
+
+// promotionError returns an error if a node of the next network
+// configuration is neither a node nor a learner of the active one, so that
+// every node added first catches up as a learner.
+func promotionError(active, next *msgs.NetworkState_Config) error {
+	members := map[uint64]struct{}{}
+	for _, id := range active.Nodes {
+		members[id] = struct{}{}
+	}
+	for _, id := range active.Learners {
+		members[id] = struct{}{}
+	}
+
+	for _, node := range next.Nodes {
+		if _, ok := members[node]; !ok {
+			return errors.Errorf("node %d must be added as a learner first", node)
+		}
+	}
+	return nil
+}
+
+// isNode returns whether id is one of the nodes of the network configuration.
+func isNode(nc *msgs.NetworkState_Config, id nodeID) bool {
+	for _, node := range nc.Nodes {
+		if nodeID(node) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// isLearner returns whether id is one of the learners of the network configuration.
+func isLearner(nc *msgs.NetworkState_Config, id nodeID) bool {
+	for _, learner := range nc.Learners {
+		if nodeID(learner) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// checkpointIntervalError returns an error if a new network configuration
+// taking effect at the checkpoint with sequence number seqNo has a checkpoint
+// interval which would not keep checkpoints aligned.  Checkpoints must fall on
+// multiples of the interval, and epochs must expire on a checkpoint.
+func checkpointIntervalError(seqNo uint64, nc *msgs.NetworkState_Config) error {
+	ci := uint64(nc.CheckpointInterval)
+	switch {
+	case nc.CheckpointInterval <= 0:
+		return errors.Errorf("checkpoint interval %d must be positive", nc.CheckpointInterval)
+	case seqNo%ci != 0:
+		return errors.Errorf("checkpoint interval %d does not divide the seq_no=%d at which it takes effect", ci, seqNo)
+	case nc.MaxEpochLength%ci != 0:
+		return errors.Errorf("checkpoint interval %d does not divide the max epoch length %d", ci, nc.MaxEpochLength)
+	default:
+		return nil
+	}
+}
+
+// ConfigDigest returns a SHA-256 digest of a canonical encoding of the network
+// configuration.  The encoding does not depend on the wire encoding of the
+// message, nor on the order in which the members of client and learner sets are
+// listed, so two nodes may compare digests to verify that they share the same
+// configuration.  The order of the nodes is significant, as the assignment of
+// buckets and the choice of leaders follow it, so it is encoded as listed.
+func ConfigDigest(nc *msgs.NetworkState_Config) []byte {
+	buf := make([]byte, 8)
+	h := sha256.New()
+	writeUint64 := func(value uint64) {
+		binary.BigEndian.PutUint64(buf, value)
+		h.Write(buf)
+	}
+	writeIDs := func(ids []uint64) {
+		writeUint64(uint64(len(ids)))
+		for _, id := range ids {
+			writeUint64(id)
+		}
+	}
+	writeIDSet := func(ids []uint64) {
+		sorted := append([]uint64(nil), ids...)
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i] < sorted[j]
+		})
+		writeIDs(sorted)
+	}
+
+	writeIDs(nc.Nodes)
+	writeUint64(uint64(nc.CheckpointInterval))
+	writeUint64(nc.MaxEpochLength)
+	writeUint64(uint64(nc.NumberOfBuckets))
+	writeUint64(uint64(nc.F))
+	writeIDSet(nc.ConfigClients)
+
+	// The optional fields are written only when set, so that the digest of
+	// configurations which do not use them is unchanged.  Each is preceded
+	// by its field number, so that configurations setting different fields
+	// to the same value do not share a digest.
+	if nc.BatchCompression != "" {
+		writeUint64(7)
+		writeUint64(uint64(len(nc.BatchCompression)))
+		h.Write([]byte(nc.BatchCompression))
+	}
+	if nc.MaxBucketsPerNode > 0 {
+		writeUint64(8)
+		writeUint64(uint64(nc.MaxBucketsPerNode))
+	}
+	if len(nc.BarrierClients) > 0 {
+		writeUint64(9)
+		writeIDSet(nc.BarrierClients)
+	}
+	if len(nc.Learners) > 0 {
+		writeUint64(10)
+		writeIDSet(nc.Learners)
+	}
+	if len(nc.CheckpointClients) > 0 {
+		writeUint64(11)
+		writeIDSet(nc.CheckpointClients)
+	}
+	if nc.BucketLookahead > 0 {
+		writeUint64(12)
+		writeUint64(uint64(nc.BucketLookahead))
+	}
+
+	return h.Sum(nil)
+}
+
+// batchHashData returns the data over which the digest of a batch is computed.
+// It depends only on the request acks, not on how the batch was sent.
+func batchHashData(requestAcks []*msgs.RequestAck) [][]byte {
+	data := make([][]byte, len(requestAcks))
+	for i, ack := range requestAcks {
+		data[i] = ack.Digest
+	}
+	return data
+}
+
+// configChanges returns the requests of the batch which were submitted by
+// one of the configuration clients of the network.
+func configChanges(batch *msgs.QEntry, nc *msgs.NetworkState_Config) []*msgs.RequestAck {
+	if len(nc.ConfigClients) == 0 {
+		return nil
+	}
+
+	var result []*msgs.RequestAck
+	for _, req := range batch.Requests {
+		for _, clientID := range nc.ConfigClients {
+			if req.ClientId == clientID {
+				result = append(result, req)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// containsBarrier returns whether the batch contains a request submitted by
+// one of the barrier clients of the network.
+func containsBarrier(batch *msgs.QEntry, nc *msgs.NetworkState_Config) bool {
+	for _, req := range batch.Requests {
+		for _, clientID := range nc.BarrierClients {
+			if req.ClientId == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// containsCheckpointRequest returns whether the batch contains a request
+// submitted by one of the checkpoint clients of the network.
+func containsCheckpointRequest(batch *msgs.QEntry, nc *msgs.NetworkState_Config) bool {
+	for _, req := range batch.Requests {
+		for _, clientID := range nc.CheckpointClients {
+			if req.ClientId == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func clientReqToBucket(clientID, reqNo uint64, nc *msgs.NetworkState_Config) bucketID {
 	return bucketID((clientID + reqNo) % uint64(nc.NumberOfBuckets))
 }
 
+// SeqToBucket returns the bucket to which the sequence number belongs, among
+// numBuckets buckets.  Sequences are assigned to buckets round robin, so
+// sequence seqNo belongs to bucket seqNo modulo numBuckets, and its batch is
+// proposed by the leader of that bucket in the current epoch.  Preprepares for
+// a sequence are only accepted from the leader of its bucket.
+func SeqToBucket(seqNo uint64, numBuckets uint32) uint32 {
+	return uint32(seqNo % uint64(numBuckets))
+}
+
 func seqToBucket(seqNo uint64, nc *msgs.NetworkState_Config) bucketID {
-	return bucketID(seqNo % uint64(nc.NumberOfBuckets))
+	return bucketID(SeqToBucket(seqNo, uint32(nc.NumberOfBuckets)))
+}
+
+// epochRand returns a pseudo-random source seeded by the epoch number and the
+// network configuration.  Any choice which would otherwise be arbitrary (such as
+// breaking ties between eligible leaders) must be drawn from this source, so that
+// all correct nodes arrive at the same decision for the same epoch.
+func epochRand(epoch uint64, nc *msgs.NetworkState_Config) *rand.Rand {
+	configBytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(nc)
+	assertEqualf(err, nil, "could not marshal network config: %s", err)
+
+	h := fnv.New64a()
+	h.Write(uint64ToBytes(epoch))
+	h.Write(configBytes)
+
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
+
+// assignBuckets computes the leader of each bucket for the given epoch.  A bucket
+// is led by its round-robin node whenever that node is in the epoch's leader set,
+// otherwise the remaining buckets are spread across the leader set beginning at
+// an offset chosen by epochRand.  Finally, buckets are moved away from leaders
+// exceeding the network's limit on the buckets per node, see limitBuckets.
+func assignBuckets(epochConfig *msgs.EpochConfig, nc *msgs.NetworkState_Config) map[bucketID]nodeID {
+	buckets := map[bucketID]nodeID{}
+
+	leaders := map[uint64]struct{}{}
+	for _, leader := range epochConfig.Leaders {
+		leaders[leader] = struct{}{}
+	}
+
+	overflowIndex := epochRand(epochConfig.Number, nc).Intn(len(epochConfig.Leaders))
+	for i := 0; i < int(nc.NumberOfBuckets); i++ {
+		bucketID := bucketID(i)
+		leader := nc.Nodes[(uint64(i)+epochConfig.Number)%uint64(len(nc.Nodes))]
+		if _, ok := leaders[leader]; !ok {
+			buckets[bucketID] = nodeID(epochConfig.Leaders[overflowIndex%len(epochConfig.Leaders)])
+			overflowIndex++
+		} else {
+			buckets[bucketID] = nodeID(leader)
+		}
+	}
+
+	if nc.MaxBucketsPerNode > 0 {
+		limitBuckets(buckets, epochConfig.Leaders, int(nc.MaxBucketsPerNode))
+	}
+
+	return buckets
+}
+
+// limitBuckets reassigns buckets, highest first, from the leaders leading
+// more than limit buckets to the least loaded leader below the limit, the
+// earliest in leaders on ties.  If every leader is at the limit, the
+// remaining excess buckets stay with their leaders.
+func limitBuckets(buckets map[bucketID]nodeID, leaders []uint64, limit int) {
+	counts := map[nodeID]int{}
+	for _, leader := range buckets {
+		counts[leader]++
+	}
+
+	for i := len(buckets) - 1; i >= 0; i-- {
+		bucket := bucketID(i)
+		leader := buckets[bucket]
+		if counts[leader] <= limit {
+			continue
+		}
+
+		target, ok := nodeID(0), false
+		for _, id := range leaders {
+			candidate := nodeID(id)
+			if counts[candidate] >= limit {
+				continue
+			}
+			if !ok || counts[candidate] < counts[target] {
+				target, ok = candidate, true
+			}
+		}
+
+		if !ok {
+			return
+		}
+
+		buckets[bucket] = target
+		counts[leader]--
+		counts[target]++
+	}
+}
+
+// bucketLeadershipDiff returns, in increasing order, the buckets which node id
+// leads in next but not in prev, and those it leads in prev but not in next.
+func bucketLeadershipDiff(id nodeID, prev, next map[bucketID]nodeID) (gained, lost []uint64) {
+	numBuckets := len(prev)
+	if len(next) > numBuckets {
+		numBuckets = len(next)
+	}
+
+	for i := 0; i < numBuckets; i++ {
+		prevLeader, prevOK := prev[bucketID(i)]
+		nextLeader, nextOK := next[bucketID(i)]
+		wasLeader := prevOK && prevLeader == id
+		isLeader := nextOK && nextLeader == id
+
+		switch {
+		case isLeader && !wasLeader:
+			gained = append(gained, uint64(i))
+		case wasLeader && !isLeader:
+			lost = append(lost, uint64(i))
+		}
+	}
+
+	return gained, lost
 }
 
 func constructNewEpochConfig(config *msgs.NetworkState_Config, newLeaders []uint64, epochChanges map[nodeID]*parsedEpochChange) *msgs.NewEpochConfig {
@@ -213,6 +613,10 @@ func constructNewEpochConfig(config *msgs.NetworkState_Config, newLeaders []uint
 
 		var selectedEntry *msgs.EpochChange_SetEntry
 
+		// The epoch change set may contain several distinct entries which
+		// each satisfy conditions A1 and A2, so rather than selecting the
+		// first we find, we select the entry prepared in the highest epoch,
+		// as any entry which committed must have been prepared in it.
 		for _, id := range config.Nodes {
 			nodeID := nodeID(id)
 			// Note, it looks like we're re-implementing `range epochChanges` here,
@@ -228,6 +632,10 @@ func constructNewEpochConfig(config *msgs.NetworkState_Config, newLeaders []uint
 				continue
 			}
 
+			if selectedEntry != nil && !preferSetEntry(entry, selectedEntry) {
+				continue
+			}
+
 			a1Count := 0
 			for _, iEpochChange := range epochChanges {
 				// non-determinism once again fine here,
@@ -285,7 +693,6 @@ func constructNewEpochConfig(config *msgs.NetworkState_Config, newLeaders []uint
 			}
 
 			selectedEntry = entry
-			break
 		}
 
 		if selectedEntry != nil {
@@ -320,9 +727,27 @@ func constructNewEpochConfig(config *msgs.NetworkState_Config, newLeaders []uint
 	return newEpochConfig
 }
 
+// preferSetEntry returns whether entry should be selected for a sequence in
+// the new epoch over selected, both of which satisfy the selection conditions.
+// The entry prepared in the higher epoch wins.  Two distinct entries prepared
+// in the same epoch should not both be selectable, but should the epoch change
+// set contain them, the lower digest wins so that every node breaks the tie
+// identically.
+func preferSetEntry(entry, selected *msgs.EpochChange_SetEntry) bool {
+	if entry.Epoch != selected.Epoch {
+		return entry.Epoch > selected.Epoch
+	}
+
+	return bytes.Compare(entry.Digest, selected.Digest) < 0
+}
+
 func epochChangeHashData(epochChange *msgs.EpochChange) [][]byte {
-	// [new_epoch, checkpoints, pSet, qSet]
-	hashData := make([][]byte, 1+len(epochChange.Checkpoints)*2+len(epochChange.PSet)*3+len(epochChange.QSet)*3)
+	// [new_epoch, checkpoints, pSet, qSet, genesis]
+	genesisLen := 0
+	if epochChange.Genesis != nil {
+		genesisLen = 1 + len(epochChange.Genesis.Leaders)
+	}
+	hashData := make([][]byte, 1+len(epochChange.Checkpoints)*2+len(epochChange.PSet)*3+len(epochChange.QSet)*3+genesisLen)
 	hashData[0] = uint64ToBytes(epochChange.NewEpoch)
 
 	cpOffset := 1
@@ -345,8 +770,16 @@ func epochChangeHashData(epochChange *msgs.EpochChange) [][]byte {
 		hashData[qEntryOffset+3*i+2] = qEntry.Digest
 	}
 
+	genesisOffset := qEntryOffset + len(epochChange.QSet)*3
+	if epochChange.Genesis != nil {
+		hashData[genesisOffset] = uint64ToBytes(epochChange.Genesis.Number)
+		for i, leader := range epochChange.Genesis.Leaders {
+			hashData[genesisOffset+1+i] = uint64ToBytes(leader)
+		}
+	}
+
 	// TODO, is this worth checking?
-	assertEqual(qEntryOffset+len(epochChange.QSet)*3, len(hashData), "allocated more hash data byte slices than needed")
+	assertEqual(genesisOffset+genesisLen, len(hashData), "allocated more hash data byte slices than needed")
 
 	return hashData
 }
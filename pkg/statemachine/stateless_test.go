@@ -0,0 +1,340 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statemachine
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+)
+
+var _ = Describe("assignBuckets", func() {
+	var (
+		newNetworkConfig = func() *msgs.NetworkState_Config {
+			return &msgs.NetworkState_Config{
+				Nodes:              []uint64{0, 1, 2, 3},
+				F:                  1,
+				NumberOfBuckets:    8,
+				CheckpointInterval: 5,
+				MaxEpochLength:     200,
+			}
+		}
+
+		newEpochConfig = func() *msgs.EpochConfig {
+			return &msgs.EpochConfig{
+				Number:  7,
+				Leaders: []uint64{0, 2, 3},
+			}
+		}
+	)
+
+	It("computes identical assignments for independently constructed configs", func() {
+		first := assignBuckets(newEpochConfig(), newNetworkConfig())
+		second := assignBuckets(newEpochConfig(), newNetworkConfig())
+		Expect(first).To(Equal(second))
+		Expect(first).To(HaveLen(8))
+		for _, leader := range first {
+			Expect([]nodeID{0, 2, 3}).To(ContainElement(leader))
+		}
+	})
+
+	It("assigns each bucket to its round-robin node when all nodes lead", func() {
+		epochConfig := newEpochConfig()
+		epochConfig.Leaders = []uint64{0, 1, 2, 3}
+		buckets := assignBuckets(epochConfig, newNetworkConfig())
+		for i := 0; i < 8; i++ {
+			Expect(buckets[bucketID(i)]).To(Equal(nodeID((uint64(i) + 7) % 4)))
+		}
+	})
+
+	It("redistributes buckets so that no leader exceeds the bucket limit", func() {
+		networkConfig := newNetworkConfig()
+		networkConfig.NumberOfBuckets = 5
+
+		// Some epoch hands the bucket of node 1 to a leader which already
+		// leads two buckets.
+		violated := false
+		for epoch := uint64(0); epoch < 20; epoch++ {
+			epochConfig := &msgs.EpochConfig{
+				Number:  epoch,
+				Leaders: []uint64{0, 2, 3},
+			}
+
+			counts := map[nodeID]int{}
+			for _, leader := range assignBuckets(epochConfig, networkConfig) {
+				counts[leader]++
+			}
+			for _, count := range counts {
+				if count > 2 {
+					violated = true
+				}
+			}
+
+			limited := proto.Clone(networkConfig).(*msgs.NetworkState_Config)
+			limited.MaxBucketsPerNode = 2
+			buckets := assignBuckets(epochConfig, limited)
+			Expect(buckets).To(HaveLen(5))
+
+			counts = map[nodeID]int{}
+			for _, leader := range buckets {
+				Expect([]nodeID{0, 2, 3}).To(ContainElement(leader))
+				counts[leader]++
+			}
+			for _, count := range counts {
+				Expect(count).To(BeNumerically("<=", 2))
+			}
+		}
+		Expect(violated).To(BeTrue())
+	})
+
+	It("rejects a bucket limit under which the nodes cannot lead every bucket", func() {
+		networkConfig := newNetworkConfig()
+		networkConfig.MaxBucketsPerNode = 2
+		Expect(bucketLimitError(networkConfig)).NotTo(HaveOccurred())
+
+		networkConfig.MaxBucketsPerNode = 1
+		Expect(bucketLimitError(networkConfig)).To(MatchError("network of 4 nodes leading at most 1 buckets each cannot lead 8 buckets"))
+	})
+
+	It("rejects learners which are already nodes", func() {
+		networkConfig := newNetworkConfig()
+		networkConfig.Learners = []uint64{4}
+		Expect(learnerError(networkConfig)).NotTo(HaveOccurred())
+
+		networkConfig.Learners = []uint64{4, 3}
+		Expect(learnerError(networkConfig)).To(MatchError("learner 3 is already a node, or listed twice"))
+	})
+
+	It("rejects nodes which were not learners first", func() {
+		active := newNetworkConfig()
+		active.Learners = []uint64{4}
+
+		next := newNetworkConfig()
+		next.Nodes = []uint64{0, 1, 2, 3, 4}
+		Expect(promotionError(active, next)).NotTo(HaveOccurred())
+
+		next.Nodes = []uint64{0, 1, 2, 3, 4, 5}
+		Expect(promotionError(active, next)).To(MatchError("node 5 must be added as a learner first"))
+	})
+
+	It("validates the epoch quorum overrides, each defaulting when unset", func() {
+		networkConfig := newNetworkConfig()
+		myConfig := &state.EventInitialParameters{}
+		Expect(epochQuorumError(networkConfig, myConfig)).NotTo(HaveOccurred())
+
+		myConfig.EpochChangeQuorum = 4
+		Expect(epochQuorumError(networkConfig, myConfig)).NotTo(HaveOccurred())
+		change, join := epochQuorums(networkConfig, myConfig)
+		Expect([]int{change, join}).To(Equal([]int{4, 2}))
+
+		myConfig.EpochChangeQuorum = 5
+		Expect(epochQuorumError(networkConfig, myConfig)).To(MatchError("epoch change quorum 5 exceeds the 4 nodes of the network"))
+
+		myConfig.EpochChangeQuorum = 0
+		myConfig.EpochJoinQuorum = 4
+		Expect(epochQuorumError(networkConfig, myConfig)).To(MatchError("epoch join quorum 4 exceeds the epoch change quorum 3"))
+		change, join = epochQuorums(networkConfig, myConfig)
+		Expect([]int{change, join}).To(Equal([]int{3, 2}))
+	})
+})
+
+var _ = Describe("epochRand", func() {
+	It("yields the same sequence for the same epoch and config", func() {
+		nc := &msgs.NetworkState_Config{
+			Nodes:           []uint64{0, 1, 2, 3},
+			F:               1,
+			NumberOfBuckets: 4,
+		}
+		first, second := epochRand(3, nc), epochRand(3, proto.Clone(nc).(*msgs.NetworkState_Config))
+		for i := 0; i < 10; i++ {
+			Expect(first.Int63()).To(Equal(second.Int63()))
+		}
+	})
+})
+
+var _ = Describe("ConfigDigest", func() {
+	newNetworkConfig := func() *msgs.NetworkState_Config {
+		return &msgs.NetworkState_Config{
+			Nodes:              []uint64{0, 1, 2, 3},
+			F:                  1,
+			NumberOfBuckets:    4,
+			CheckpointInterval: 20,
+			MaxEpochLength:     200,
+		}
+	}
+
+	It("does not depend on the order of fields or of the members of client sets", func() {
+		nc := newNetworkConfig()
+		nc.ConfigClients = []uint64{5, 6, 7}
+		reordered := &msgs.NetworkState_Config{
+			ConfigClients:      []uint64{7, 5, 6},
+			MaxEpochLength:     200,
+			CheckpointInterval: 20,
+			NumberOfBuckets:    4,
+			F:                  1,
+			Nodes:              []uint64{0, 1, 2, 3},
+		}
+		Expect(ConfigDigest(reordered)).To(Equal(ConfigDigest(nc)))
+	})
+
+	It("differs for configs with different content", func() {
+		changed := newNetworkConfig()
+		changed.CheckpointInterval = 10
+		Expect(ConfigDigest(changed)).NotTo(Equal(ConfigDigest(newNetworkConfig())))
+
+		changed = newNetworkConfig()
+		changed.Nodes = []uint64{0, 1, 2, 4}
+		Expect(ConfigDigest(changed)).NotTo(Equal(ConfigDigest(newNetworkConfig())))
+	})
+
+	It("differs for configs listing the nodes in a different order", func() {
+		reordered := newNetworkConfig()
+		reordered.Nodes = []uint64{3, 1, 0, 2}
+
+		// The nodes lead different buckets under the two configs.
+		epochConfig := &msgs.EpochConfig{
+			Number:  1,
+			Leaders: []uint64{0, 1, 2, 3},
+		}
+		Expect(assignBuckets(epochConfig, reordered)).NotTo(Equal(assignBuckets(epochConfig, newNetworkConfig())))
+		Expect(ConfigDigest(reordered)).NotTo(Equal(ConfigDigest(newNetworkConfig())))
+	})
+
+	It("does not modify the config", func() {
+		nc := newNetworkConfig()
+		nc.ConfigClients = []uint64{3, 2, 1, 0}
+		ConfigDigest(nc)
+		Expect(nc.ConfigClients).To(Equal([]uint64{3, 2, 1, 0}))
+	})
+
+	It("differs for configs setting different optional fields to the same value", func() {
+		setters := []func(nc *msgs.NetworkState_Config){
+			func(nc *msgs.NetworkState_Config) { nc.BarrierClients = []uint64{3} },
+			func(nc *msgs.NetworkState_Config) { nc.Learners = []uint64{3} },
+			func(nc *msgs.NetworkState_Config) { nc.CheckpointClients = []uint64{3} },
+			func(nc *msgs.NetworkState_Config) { nc.MaxBucketsPerNode = 2 },
+			func(nc *msgs.NetworkState_Config) { nc.BucketLookahead = 2 },
+			func(nc *msgs.NetworkState_Config) { nc.BatchCompression = "gzip" },
+		}
+
+		digests := map[string]int{string(ConfigDigest(newNetworkConfig())): -1}
+		for i, set := range setters {
+			nc := newNetworkConfig()
+			set(nc)
+			digest := string(ConfigDigest(nc))
+			Expect(digests).NotTo(HaveKey(digest), "optional field %d collides with %d", i, digests[digest])
+			digests[digest] = i
+		}
+	})
+})
+
+var _ = Describe("constructNewEpochConfig", func() {
+	var (
+		networkConfig = &msgs.NetworkState_Config{
+			Nodes:              []uint64{0, 1, 2, 3},
+			F:                  1,
+			NumberOfBuckets:    4,
+			CheckpointInterval: 5,
+			MaxEpochLength:     200,
+		}
+
+		// newEpochChanges constructs an epoch change set split over
+		// sequence 1: node 0 prepared digest X in epoch 1, while node 1
+		// prepared digest Y in epoch 2.  Both entries look valid, as each
+		// is supported by 2f+1 pSets and f+1 qSets.
+		newEpochChanges = func() []*msgs.EpochChange {
+			checkpoints := []*msgs.Checkpoint{{SeqNo: 0, Value: []byte("checkpoint")}}
+			return []*msgs.EpochChange{
+				{
+					NewEpoch:    3,
+					Checkpoints: checkpoints,
+					PSet:        []*msgs.EpochChange_SetEntry{{Epoch: 1, SeqNo: 1, Digest: []byte("X")}},
+					QSet:        []*msgs.EpochChange_SetEntry{{Epoch: 1, SeqNo: 1, Digest: []byte("X")}},
+				},
+				{
+					NewEpoch:    3,
+					Checkpoints: checkpoints,
+					PSet:        []*msgs.EpochChange_SetEntry{{Epoch: 2, SeqNo: 1, Digest: []byte("Y")}},
+					QSet: []*msgs.EpochChange_SetEntry{
+						{Epoch: 1, SeqNo: 1, Digest: []byte("X")},
+						{Epoch: 2, SeqNo: 1, Digest: []byte("Y")},
+					},
+				},
+				{
+					NewEpoch:    3,
+					Checkpoints: checkpoints,
+					QSet: []*msgs.EpochChange_SetEntry{
+						{Epoch: 1, SeqNo: 1, Digest: []byte("X")},
+						{Epoch: 2, SeqNo: 1, Digest: []byte("Y")},
+					},
+				},
+				{
+					NewEpoch:    3,
+					Checkpoints: checkpoints,
+					QSet:        []*msgs.EpochChange_SetEntry{{Epoch: 1, SeqNo: 1, Digest: []byte("X")}},
+				},
+			}
+		}
+	)
+
+	It("selects the entry prepared in the highest epoch identically on every node", func() {
+		var first *msgs.NewEpochConfig
+		for i := range networkConfig.Nodes {
+			// Each node receives the epoch changes in a different order.
+			epochChanges := map[nodeID]*parsedEpochChange{}
+			underlying := newEpochChanges()
+			for j := range underlying {
+				source := (i + j) % len(underlying)
+				parsed, err := newParsedEpochChange(underlying[source])
+				Expect(err).NotTo(HaveOccurred())
+				epochChanges[nodeID(source)] = parsed
+			}
+
+			newEpochConfig := constructNewEpochConfig(networkConfig, []uint64{0, 1, 2, 3}, epochChanges)
+			Expect(newEpochConfig).NotTo(BeNil())
+			Expect(newEpochConfig.StartingCheckpoint.SeqNo).To(Equal(uint64(0)))
+			Expect(newEpochConfig.FinalPreprepares[0]).To(Equal([]byte("Y")))
+
+			if first == nil {
+				first = newEpochConfig
+				continue
+			}
+			Expect(proto.Equal(newEpochConfig, first)).To(BeTrue())
+		}
+	})
+
+	It("breaks ties between entries prepared in the same epoch by digest", func() {
+		Expect(preferSetEntry(
+			&msgs.EpochChange_SetEntry{Epoch: 2, SeqNo: 1, Digest: []byte("A")},
+			&msgs.EpochChange_SetEntry{Epoch: 2, SeqNo: 1, Digest: []byte("B")},
+		)).To(BeTrue())
+		Expect(preferSetEntry(
+			&msgs.EpochChange_SetEntry{Epoch: 2, SeqNo: 1, Digest: []byte("B")},
+			&msgs.EpochChange_SetEntry{Epoch: 2, SeqNo: 1, Digest: []byte("A")},
+		)).To(BeFalse())
+		Expect(preferSetEntry(
+			&msgs.EpochChange_SetEntry{Epoch: 1, SeqNo: 1, Digest: []byte("A")},
+			&msgs.EpochChange_SetEntry{Epoch: 2, SeqNo: 1, Digest: []byte("B")},
+		)).To(BeFalse())
+	})
+})
+
+var _ = DescribeTable("MaxFaultTolerance",
+	func(numNodes, f int) {
+		Expect(MaxFaultTolerance(numNodes)).To(Equal(f))
+	},
+	Entry("a single node", 1, 0),
+	Entry("4 nodes", 4, 1),
+	Entry("7 nodes", 7, 2),
+	Entry("10 nodes", 10, 3),
+	Entry("one node short of tolerating another fault", 9, 2),
+	Entry("no nodes", 0, 0),
+)
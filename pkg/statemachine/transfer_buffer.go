@@ -0,0 +1,95 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statemachine
+
+import (
+	"sort"
+
+	"github.com/hyperledger-labs/mirbft/pkg/logger"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+)
+
+// transferBuffer holds the ordering messages received while this node
+// transfers state.  Those for sequences at or below the transfer target are
+// dropped, as the node jumps past them, while those above it are buffered,
+// subject to the node buffer limits, until the transfer completes.  If
+// DropDuringStateTransfer is set, every ordering message is dropped.
+type transferBuffer struct {
+	myConfig    *state.EventInitialParameters
+	nodeBuffers *nodeBuffers
+	logger      logger.Logger
+	msgBuffers  map[nodeID]*msgBuffer
+}
+
+func newTransferBuffer(nodeBuffers *nodeBuffers, myConfig *state.EventInitialParameters, logger logger.Logger) *transferBuffer {
+	return &transferBuffer{
+		myConfig:    myConfig,
+		nodeBuffers: nodeBuffers,
+		logger:      logger,
+		msgBuffers:  map[nodeID]*msgBuffer{},
+	}
+}
+
+// orderingSeqNo returns the sequence of a preprepare, prepare or commit, and
+// false for any other message.
+func orderingSeqNo(msg *msgs.Msg) (uint64, bool) {
+	switch innerMsg := msg.Type.(type) {
+	case *msgs.Msg_Preprepare:
+		return innerMsg.Preprepare.SeqNo, true
+	case *msgs.Msg_Prepare:
+		return innerMsg.Prepare.SeqNo, true
+	case *msgs.Msg_Commit:
+		return innerMsg.Commit.SeqNo, true
+	default:
+		return 0, false
+	}
+}
+
+// hold takes charge of msg if it is an ordering message received during a
+// transfer to targetSeqNo, buffering or dropping it, and returns whether it
+// did so.
+func (tb *transferBuffer) hold(source nodeID, msg *msgs.Msg, targetSeqNo uint64) bool {
+	seqNo, ok := orderingSeqNo(msg)
+	if !ok {
+		return false
+	}
+
+	if tb.myConfig.DropDuringStateTransfer || seqNo <= targetSeqNo {
+		tb.logger.Log(logger.LevelDebug, "dropping message received during state transfer", "source", source, "seq_no", seqNo, "target_seq_no", targetSeqNo)
+		return true
+	}
+
+	mb, ok := tb.msgBuffers[source]
+	if !ok {
+		mb = newMsgBuffer("state-transfer", tb.nodeBuffers.nodeBuffer(source))
+		tb.msgBuffers[source] = mb
+	}
+	mb.store(msg)
+	return true
+}
+
+// drain removes every buffered message, passing each to apply, node by
+// node in order of node ID, and in the order received from each node.
+func (tb *transferBuffer) drain(apply func(source nodeID, msg *msgs.Msg)) {
+	sources := make([]nodeID, 0, len(tb.msgBuffers))
+	for source := range tb.msgBuffers {
+		sources = append(sources, source)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i] < sources[j]
+	})
+
+	for _, source := range sources {
+		tb.msgBuffers[source].iterate(
+			func(nodeID, *msgs.Msg) applyable { return current },
+			apply,
+		)
+	}
+
+	tb.msgBuffers = map[nodeID]*msgBuffer{}
+}
@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 )
 
@@ -72,14 +73,74 @@ const (
 )
 
 type StateMachine struct {
-	NodeID        uint64           `json:"node_id"`
-	LowWatermark  uint64           `json:"low_watermark"`
-	HighWatermark uint64           `json:"high_watermark"`
-	EpochTracker  *EpochTracker    `json:"epoch_tracker"`
-	NodeBuffers   []*NodeBuffer    `json:"node_buffers"`
-	Buckets       []*Bucket        `json:"buckets"`
-	Checkpoints   []*Checkpoint    `json:"checkpoints"`
+	NodeID        uint64 `json:"node_id"`
+	LowWatermark  uint64 `json:"low_watermark"`
+	HighWatermark uint64 `json:"high_watermark"`
+	// HighestPrepared is the highest sequence for which the node holds a
+	// prepared certificate, as reported in its epoch change messages.
+	HighestPrepared uint64 `json:"highest_prepared"`
+	// CaughtUp is set while the node has committed every sequence through
+	// the highest checkpoint f+1 nodes agree on, and is not transferring state.
+	CaughtUp bool `json:"caught_up"`
+	// ProgressQuorum is the number of responsive nodes required for the
+	// current phase (ordering or epoch change) to make progress.
+	ProgressQuorum int           `json:"progress_quorum"`
+	EpochTracker   *EpochTracker `json:"epoch_tracker"`
+	NodeBuffers    []*NodeBuffer `json:"node_buffers"`
+	Buckets        []*Bucket     `json:"buckets"`
+	Checkpoints    []*Checkpoint `json:"checkpoints"`
+	// ClientWindows list every client of the network, with the watermarks
+	// bounding the request numbers it may currently submit, revealing the
+	// clients whose requests are stuck, or which exhaust their windows.
 	ClientWindows []*ClientTracker `json:"client_tracker"`
+	// PendingCheckpoints are the checkpoints which have not yet become
+	// stable, revealing the nodes whose checkpoint messages are missing.
+	PendingCheckpoints []*CheckpointState `json:"pending_checkpoints"`
+	// Followers report the responsiveness of the other nodes to the
+	// sequences this node leads in the active epoch, if it leads any.
+	Followers []*Follower `json:"followers,omitempty"`
+	// Learners report how far each learner of the network has caught up,
+	// so that a learner is only promoted to a node once it has.
+	Learners []*Learner `json:"learners,omitempty"`
+	// MessagesReceived and MessagesSent count the messages the node has
+	// received and sent since it started, by message type.  A message sent
+	// to several nodes is counted once per node.
+	MessagesReceived map[string]uint64 `json:"messages_received"`
+	MessagesSent     map[string]uint64 `json:"messages_sent"`
+	// Queues are the depths of the queues in front of and behind the state
+	// machine, if it is driven through a serializer which reports them.
+	Queues *QueueDepths `json:"queues,omitempty"`
+}
+
+// QueueDepths are the depths of the queues of a serializer driving a state
+// machine.  Depths which remain high indicate that the consumer of the
+// actions, or the network, cannot keep up.
+type QueueDepths struct {
+	// Proposals is the number of requests awaiting application.
+	Proposals int `json:"proposals"`
+	// Steps is the number of messages awaiting application.
+	Steps int `json:"steps"`
+	// PendingActions is the number of results produced by the state
+	// machine which the consumer has yet to process.
+	PendingActions int `json:"pending_actions"`
+}
+
+// Follower is the responsiveness of a node to the sequences led by another.
+type Follower struct {
+	ID uint64 `json:"id"`
+	// Missing is the number of sequences within the watermarks which have
+	// committed without a commit from this node.
+	Missing int `json:"missing"`
+	// Lagging is set once Missing reaches the lagging follower threshold.
+	Lagging bool `json:"lagging"`
+}
+
+// Learner is the progress of a node being added to the network.
+type Learner struct {
+	ID uint64 `json:"id"`
+	// CaughtUpTo is the highest checkpoint the learner reported having
+	// caught up to, or zero if it has reported none.
+	CaughtUpTo uint64 `json:"caught_up_to"`
 }
 
 type Bucket struct {
@@ -95,8 +156,19 @@ type Checkpoint struct {
 	LocalDecision bool   `json:"local_decision"`
 }
 
+type CheckpointState struct {
+	SeqNo uint64 `json:"seq_no"`
+	// Values is the number of checkpoint messages collected.
+	Values  int      `json:"values"`
+	Sources []uint64 `json:"sources"`
+}
+
 type EpochTracker struct {
 	ActiveEpoch *EpochTarget `json:"last_active_epoch"`
+
+	// Unstable is set while the epoch changes begun within the configured
+	// window reach the epoch change alarm threshold.
+	Unstable bool `json:"unstable"`
 }
 
 type EpochTarget struct {
@@ -107,6 +179,10 @@ type EpochTarget struct {
 	Readies      []uint64         `json:"readies"`
 	Suspicions   []uint64         `json:"suspicions"`
 	Leaders      []uint64         `json:"leaders"`
+
+	// Fresh is set while the epoch is in progress but has yet to commit a
+	// sequence, as is the case right after an epoch change.
+	Fresh bool `json:"fresh"`
 }
 
 type EpochChange struct {
@@ -165,7 +241,7 @@ type ClientTracker struct {
 func (s *StateMachine) Pretty() string {
 	var buffer bytes.Buffer
 	fmt.Fprintf(&buffer, "===========================================\n")
-	fmt.Fprintf(&buffer, "NodeID=%d, LowWatermark=%d, HighWatermark=%d, Epoch=%d\n", s.NodeID, s.LowWatermark, s.HighWatermark, s.EpochTracker.ActiveEpoch.Number)
+	fmt.Fprintf(&buffer, "NodeID=%d, LowWatermark=%d, HighWatermark=%d, Epoch=%d, ProgressQuorum=%d\n", s.NodeID, s.LowWatermark, s.HighWatermark, s.EpochTracker.ActiveEpoch.Number, s.ProgressQuorum)
 	fmt.Fprintf(&buffer, "===========================================\n\n")
 
 	fmt.Fprintf(&buffer, "=== Epoch Number %d ===\n", s.EpochTracker.ActiveEpoch.Number)
@@ -279,6 +355,14 @@ func (s *StateMachine) Pretty() string {
 	hRule()
 	fmt.Fprintf(&buffer, "-\n")
 
+	for _, pending := range s.PendingCheckpoints {
+		fmt.Fprintf(&buffer, "Pending checkpoint SeqNo=%d Values=%d Sources=%v\n", pending.SeqNo, pending.Values, pending.Sources)
+	}
+
+	for _, follower := range s.Followers {
+		fmt.Fprintf(&buffer, "Follower %d Missing=%d Lagging=%t\n", follower.ID, follower.Missing, follower.Lagging)
+	}
+
 	fmt.Fprintf(&buffer, "\n\n Request Windows\n")
 	hRule()
 	for _, rws := range s.ClientWindows {
@@ -297,6 +381,29 @@ func (s *StateMachine) Pretty() string {
 		}
 	}
 
+	if s.Queues != nil {
+		fmt.Fprintf(&buffer, "\n\n Queues\n")
+		hRule()
+		fmt.Fprintf(&buffer, "\n  Proposals=%d Steps=%d PendingActions=%d\n", s.Queues.Proposals, s.Queues.Steps, s.Queues.PendingActions)
+	}
+
+	fmt.Fprintf(&buffer, "\n\n Message Counts\n")
+	hRule()
+
+	msgTypes := []string{}
+	for msgType := range s.MessagesReceived {
+		msgTypes = append(msgTypes, msgType)
+	}
+	for msgType := range s.MessagesSent {
+		if _, ok := s.MessagesReceived[msgType]; !ok {
+			msgTypes = append(msgTypes, msgType)
+		}
+	}
+	sort.Strings(msgTypes)
+	for _, msgType := range msgTypes {
+		fmt.Fprintf(&buffer, "  %-20s Received=%-8d Sent=%-8d\n", msgType, s.MessagesReceived[msgType], s.MessagesSent[msgType])
+	}
+
 	fmt.Fprintf(&buffer, "\n\nDone\n")
 
 	return buffer.String()
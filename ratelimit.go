@@ -0,0 +1,94 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	"sync"
+	"time"
+)
+
+// clientRateLimiter admits requests according to a token bucket kept per client.
+// Each client's bucket holds up to burst tokens and is refilled at rate tokens per second.
+// Every admitted request consumes one token.  A full bucket is no different from
+// a new one, so buckets are evicted once they refill, see evictFull.
+type clientRateLimiter struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mutex     sync.Mutex
+	buckets   map[uint64]*tokenBucket
+	lastEvict time.Time
+}
+
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newClientRateLimiter(rate float64, burst int, now func() time.Time) *clientRateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &clientRateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		now:     now,
+		buckets: map[uint64]*tokenBucket{},
+	}
+}
+
+// allow consumes a token from the bucket of clientID and returns true,
+// or returns false if the bucket is empty.
+func (rl *clientRateLimiter) allow(clientID uint64) bool {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	now := rl.now()
+	rl.evictFull(now)
+
+	bucket, ok := rl.buckets[clientID]
+	if !ok {
+		bucket = &tokenBucket{
+			tokens:     rl.burst,
+			lastRefill: now,
+		}
+		rl.buckets[clientID] = bucket
+	}
+
+	if elapsed := now.Sub(bucket.lastRefill); elapsed > 0 {
+		bucket.tokens += elapsed.Seconds() * rl.rate
+		if bucket.tokens > rl.burst {
+			bucket.tokens = rl.burst
+		}
+		bucket.lastRefill = now
+	}
+
+	if bucket.tokens < 1 {
+		return false
+	}
+
+	bucket.tokens--
+	return true
+}
+
+// evictFull removes the buckets which have refilled by now.  As every bucket
+// refills within burst/rate seconds of its last use, the buckets are only
+// scanned once per such period, keeping allow constant time on average.
+func (rl *clientRateLimiter) evictFull(now time.Time) {
+	if now.Sub(rl.lastEvict).Seconds()*rl.rate < rl.burst {
+		return
+	}
+
+	for clientID, bucket := range rl.buckets {
+		if bucket.tokens+now.Sub(bucket.lastRefill).Seconds()*rl.rate >= rl.burst {
+			delete(rl.buckets, clientID)
+		}
+	}
+	rl.lastEvict = now
+}
@@ -0,0 +1,44 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("clientRateLimiter", func() {
+	var (
+		now time.Time
+		rl  *clientRateLimiter
+	)
+
+	BeforeEach(func() {
+		now = time.Unix(0, 0)
+		rl = newClientRateLimiter(1, 2, func() time.Time { return now })
+	})
+
+	It("evicts the buckets of clients which have refilled", func() {
+		Expect(rl.allow(0)).To(BeTrue())
+
+		now = now.Add(time.Second)
+		Expect(rl.allow(1)).To(BeTrue())
+		Expect(rl.allow(1)).To(BeTrue())
+		Expect(rl.allow(1)).To(BeFalse())
+		Expect(rl.buckets).To(HaveLen(2))
+
+		// Client 0 has refilled and is evicted, client 1 has not.
+		now = now.Add(time.Second)
+		Expect(rl.allow(2)).To(BeTrue())
+		Expect(rl.buckets).To(HaveLen(2))
+		Expect(rl.buckets).NotTo(HaveKey(uint64(0)))
+		Expect(rl.allow(1)).To(BeTrue())
+		Expect(rl.allow(1)).To(BeFalse())
+	})
+})
@@ -0,0 +1,339 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	"context"
+
+	"github.com/hyperledger-labs/mirbft/pkg/modules"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+	"github.com/hyperledger-labs/mirbft/pkg/statemachine"
+	"github.com/hyperledger-labs/mirbft/pkg/status"
+)
+
+// Ready is a batch of work produced by the state machine.
+// The consumer must process all of its events before calling ReadyNode.Advance.
+type Ready struct {
+	Events *statemachine.EventList
+}
+
+// ReadyNode serializes access to a state machine in the style of the Ready/Advance
+// pattern of etcd raft. Events are submitted with Apply, the work resulting from
+// them is delivered on the channel returned by Ready, and no further work is
+// delivered until the consumer calls Advance. This makes explicit when it is safe
+// for the state machine to proceed, and prevents the consumer from silently falling behind.
+// Events submitted while a Ready is outstanding are buffered, and applied after Advance.
+type ReadyNode struct {
+	stateMachine modules.StateMachine
+	interceptor  modules.EventInterceptor
+
+	eventsC  chan *statemachine.EventList
+	readyC   chan *Ready
+	advanceC chan struct{}
+	statusC  chan chan readyNodeStatus
+	queryC   chan func()
+	doneC    chan struct{}
+
+	audits *auditWaiters
+}
+
+type readyNodeStatus struct {
+	status *status.StateMachine
+	err    error
+}
+
+// NewReadyNode creates a ReadyNode serializing access to stateMachine.
+// If interceptor is not nil, it is invoked for every event applied.
+func NewReadyNode(stateMachine modules.StateMachine, interceptor modules.EventInterceptor) *ReadyNode {
+	return &ReadyNode{
+		stateMachine: stateMachine,
+		interceptor:  interceptor,
+		eventsC:      make(chan *statemachine.EventList),
+		readyC:       make(chan *Ready),
+		advanceC:     make(chan struct{}),
+		statusC:      make(chan chan readyNodeStatus),
+		queryC:       make(chan func()),
+		doneC:        make(chan struct{}),
+		audits:       newAuditWaiters(),
+	}
+}
+
+// Apply submits events to be applied to the state machine.
+func (rn *ReadyNode) Apply(ctx context.Context, events *statemachine.EventList) error {
+	select {
+	case rn.eventsC <- events:
+		return nil
+	case <-rn.doneC:
+		return ErrStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Ready returns the channel on which the next batch of work is delivered.
+func (rn *ReadyNode) Ready() <-chan *Ready {
+	return rn.readyC
+}
+
+// Advance notifies the ReadyNode that the consumer has processed the last Ready,
+// allowing the next one to be produced. It must be called exactly once per Ready.
+func (rn *ReadyNode) Advance() {
+	select {
+	case rn.advanceC <- struct{}{}:
+	case <-rn.doneC:
+	}
+}
+
+// Status returns the status of the state machine, along with the depths of
+// the queues of the ReadyNode.  It returns ErrStopped once Run has returned.
+func (rn *ReadyNode) Status(ctx context.Context) (*status.StateMachine, error) {
+	statusC := make(chan readyNodeStatus, 1)
+	select {
+	case rn.statusC <- statusC:
+	case <-rn.doneC:
+		return nil, ErrStopped
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case s := <-statusC:
+		return s.status, s.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// LeadershipView returns the leaders of the active epoch of the state machine,
+// along with the leader of each bucket.  As it is served between the
+// application of events, the view never mixes two epochs.  It returns
+// ErrNoLeadershipView if the state machine does not implement
+// modules.LeadershipViewer, and ErrStopped once Run has returned.
+func (rn *ReadyNode) LeadershipView(ctx context.Context) (*statemachine.LeadershipView, error) {
+	viewer, ok := rn.stateMachine.(modules.LeadershipViewer)
+	if !ok {
+		return nil, ErrNoLeadershipView
+	}
+
+	var (
+		view    *statemachine.LeadershipView
+		viewErr error
+	)
+	if err := rn.query(ctx, func() {
+		view, viewErr = viewer.LeadershipView()
+	}); err != nil {
+		return nil, err
+	}
+	return view, viewErr
+}
+
+// MisbehaviorCounts returns the number of misbehaviors the state machine
+// flagged for each node, as served between the application of events.  It
+// returns ErrUnsupportedQuery if the state machine does not implement
+// modules.MisbehaviorCounter, and ErrStopped once Run has returned.
+func (rn *ReadyNode) MisbehaviorCounts(ctx context.Context) (map[uint64]int, error) {
+	counter, ok := rn.stateMachine.(modules.MisbehaviorCounter)
+	if !ok {
+		return nil, ErrUnsupportedQuery
+	}
+
+	var counts map[uint64]int
+	if err := rn.query(ctx, func() {
+		counts = counter.MisbehaviorCounts()
+	}); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// QuorumSources returns the ids of the nodes whose prepares and commits for
+// sequence seqNo the state machine counted, as served between the application
+// of events.  It returns ErrUnsupportedQuery if the state machine does not
+// implement modules.QuorumSourcer, and ErrStopped once Run has returned.
+func (rn *ReadyNode) QuorumSources(ctx context.Context, seqNo uint64) (prepares, commits []uint64, err error) {
+	sourcer, ok := rn.stateMachine.(modules.QuorumSourcer)
+	if !ok {
+		return nil, nil, ErrUnsupportedQuery
+	}
+
+	var sourcesErr error
+	if err := rn.query(ctx, func() {
+		prepares, commits, sourcesErr = sourcer.QuorumSources(seqNo)
+	}); err != nil {
+		return nil, nil, err
+	}
+	return prepares, commits, sourcesErr
+}
+
+// CommittedSince returns the batches the state machine committed, in order,
+// from seqNo through the highest sequence committed so far.  The batches are
+// collected between the application of events, so they never span a
+// checkpoint the state machine garbage collected meanwhile.  It returns
+// ErrUnsupportedQuery if the state machine does not implement
+// modules.CommitReader, and ErrStopped once Run has returned.
+func (rn *ReadyNode) CommittedSince(ctx context.Context, seqNo uint64) ([]*msgs.QEntry, error) {
+	reader, ok := rn.stateMachine.(modules.CommitReader)
+	if !ok {
+		return nil, ErrUnsupportedQuery
+	}
+
+	var (
+		entries []*msgs.QEntry
+		readErr error
+	)
+	if err := rn.query(ctx, func() {
+		var iter *statemachine.CommitIterator
+		iter, readErr = reader.CommittedSince(seqNo)
+		if readErr != nil {
+			return
+		}
+		for entry := iter.Next(); entry != nil; entry = iter.Next() {
+			entries = append(entries, entry)
+		}
+	}); err != nil {
+		return nil, err
+	}
+	return entries, readErr
+}
+
+// ResetMisbehavior clears the misbehavior count of node, e.g. once an
+// operator has investigated and repaired it.  The reset is applied to the
+// state machine like any other event.
+func (rn *ReadyNode) ResetMisbehavior(ctx context.Context, node uint64) error {
+	return rn.Apply(ctx, (&statemachine.EventList{}).ResetMisbehavior(node))
+}
+
+// query runs fn from Run, between the application of events, so that fn may
+// read the state machine.  Once query returns an error, fn may still run, so
+// the caller must not read its results.
+func (rn *ReadyNode) query(ctx context.Context, fn func()) error {
+	doneC := make(chan struct{})
+	select {
+	case rn.queryC <- func() {
+		fn()
+		close(doneC)
+	}:
+	case <-rn.doneC:
+		return ErrStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-doneC:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// AuditDigest returns the digest of the application state at the already
+// committed sequence number seqNo.  The request is applied to the state
+// machine like any other event, and the consumer, once it has handled the
+// resulting AuditDigest action, reports the digest with AuditDigestResult.
+// It returns ErrStopped once Run has returned.
+func (rn *ReadyNode) AuditDigest(ctx context.Context, seqNo uint64) ([]byte, error) {
+	resultC := rn.audits.wait(seqNo)
+	defer rn.audits.cancel(seqNo, resultC)
+
+	if err := rn.Apply(ctx, (&statemachine.EventList{}).AuditDigest(seqNo)); err != nil {
+		return nil, err
+	}
+
+	select {
+	case result := <-resultC:
+		return result.digest, result.err
+	case <-rn.doneC:
+		return nil, ErrStopped
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// AuditDigestResult reports the digest of the application state at seqNo,
+// as asked for by an AuditDigest action, to the callers of AuditDigest
+// awaiting it.  If err is not nil, it is returned to them instead, e.g.
+// ErrUncommittedSeqNo for an action flagged as uncommitted.
+func (rn *ReadyNode) AuditDigestResult(seqNo uint64, digest []byte, err error) {
+	rn.audits.deliver(seqNo, digest, err)
+}
+
+// queueDepths returns the depths of the queues, given the events awaiting
+// application and the Ready not yet advanced, if any.
+func queueDepths(pending *statemachine.EventList, outstanding *Ready) *status.QueueDepths {
+	depths := &status.QueueDepths{}
+
+	iter := pending.Iterator()
+	for event := iter.Next(); event != nil; event = iter.Next() {
+		switch event.Type.(type) {
+		case *state.Event_Request, *state.Event_RequestPersisted:
+			depths.Proposals++
+		case *state.Event_Step:
+			depths.Steps++
+		}
+	}
+
+	if outstanding != nil {
+		depths.PendingActions = outstanding.Events.Len()
+	}
+
+	return depths
+}
+
+// Run applies submitted events to the state machine and delivers the resulting work,
+// until exitC is closed, in which case it returns ErrStopped, or the state machine fails.
+func (rn *ReadyNode) Run(exitC <-chan struct{}) error {
+	defer close(rn.doneC)
+
+	var (
+		pending     = &statemachine.EventList{}
+		ready       *Ready
+		readyC      chan<- *Ready
+		outstanding *Ready // The last Ready produced, until it is advanced
+		advanced    = true
+	)
+
+	for {
+		// Only produce the next Ready once the previous one has been advanced.
+		if advanced && ready == nil && pending.Len() > 0 {
+			eventsOut, err := processStateMachineEvents(rn.stateMachine, rn.interceptor, pending)
+			if err != nil {
+				return err
+			}
+			pending = &statemachine.EventList{}
+
+			if eventsOut.Len() > 0 {
+				ready = &Ready{Events: eventsOut}
+				readyC = rn.readyC
+				outstanding = ready
+			}
+		}
+
+		select {
+		case events := <-rn.eventsC:
+			pending.PushBackList(events)
+		case readyC <- ready:
+			ready = nil
+			readyC = nil
+			advanced = false
+		case <-rn.advanceC:
+			advanced = true
+			outstanding = nil
+		case statusC := <-rn.statusC:
+			s, err := rn.stateMachine.Status()
+			if err == nil {
+				s.Queues = queueDepths(pending, outstanding)
+			}
+			statusC <- readyNodeStatus{status: s, err: err}
+		case query := <-rn.queryC:
+			query()
+		case <-exitC:
+			return ErrStopped
+		}
+	}
+}
@@ -0,0 +1,284 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger-labs/mirbft"
+	"github.com/hyperledger-labs/mirbft/pkg/modules"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/msgs"
+	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
+	"github.com/hyperledger-labs/mirbft/pkg/statemachine"
+	"github.com/hyperledger-labs/mirbft/pkg/status"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// echoSM is a state machine which outputs every event applied to it.
+type echoSM struct{}
+
+func (echoSM) ApplyEvent(event *state.Event) *statemachine.EventList {
+	el := &statemachine.EventList{}
+	el.PushBack(event)
+	return el
+}
+
+func (echoSM) Status() (*status.StateMachine, error) {
+	return &status.StateMachine{
+		HighestPrepared: 7,
+	}, nil
+}
+
+func (echoSM) LeadershipView() (*statemachine.LeadershipView, error) {
+	return &statemachine.LeadershipView{
+		Epoch:         1,
+		Leaders:       []uint64{0, 1},
+		BucketLeaders: map[uint64]uint64{0: 1, 1: 0},
+	}, nil
+}
+
+func (echoSM) QuorumSources(seqNo uint64) (prepares, commits []uint64, err error) {
+	if seqNo != 5 {
+		return nil, nil, fmt.Errorf("seq_no=%d is outside the watermarks", seqNo)
+	}
+	return []uint64{0, 1, 2}, []uint64{1, 2, 3}, nil
+}
+
+func (echoSM) CommittedSince(seqNo uint64) (*statemachine.CommitIterator, error) {
+	if seqNo < 3 {
+		return nil, fmt.Errorf("seq_no=%d is below the low watermark", seqNo)
+	}
+	entries := []*msgs.QEntry{}
+	for i := seqNo; i <= 5; i++ {
+		entries = append(entries, &msgs.QEntry{SeqNo: i})
+	}
+	return statemachine.NewCommitIterator(entries), nil
+}
+
+// misbehaviorSM is a state machine which counts misbehaviors until reset.
+type misbehaviorSM struct {
+	statusSM
+	counts map[uint64]int
+}
+
+func (msm *misbehaviorSM) ApplyEvent(event *state.Event) *statemachine.EventList {
+	if reset, ok := event.Type.(*state.Event_ResetMisbehavior); ok {
+		delete(msm.counts, reset.ResetMisbehavior.NodeId)
+	}
+	return &statemachine.EventList{}
+}
+
+func (msm *misbehaviorSM) MisbehaviorCounts() map[uint64]int {
+	counts := map[uint64]int{}
+	for node, count := range msm.counts {
+		counts[node] = count
+	}
+	return counts
+}
+
+// statusSM is a state machine which serves no leadership view.
+type statusSM struct{}
+
+func (statusSM) ApplyEvent(event *state.Event) *statemachine.EventList {
+	return &statemachine.EventList{}
+}
+
+func (statusSM) Status() (*status.StateMachine, error) {
+	return &status.StateMachine{}, nil
+}
+
+var _ = Describe("ReadyNode", func() {
+	var (
+		sm        modules.StateMachine
+		readyNode *mirbft.ReadyNode
+		stopC     chan struct{}
+		wg        sync.WaitGroup
+		ctx       context.Context
+		cancel    context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		sm = echoSM{}
+		ctx, cancel = context.WithTimeout(context.Background(), testTimeout)
+	})
+
+	JustBeforeEach(func() {
+		readyNode = mirbft.NewReadyNode(sm, nil)
+
+		stopC = make(chan struct{})
+		wg.Add(1)
+		go func() {
+			defer GinkgoRecover()
+			defer wg.Done()
+			Expect(readyNode.Run(stopC)).To(Equal(mirbft.ErrStopped))
+		}()
+	})
+
+	AfterEach(func() {
+		cancel()
+		close(stopC)
+		wg.Wait()
+	})
+
+	It("produces no new Ready until Advance is called", func() {
+		request := func(reqNo uint64) *statemachine.EventList {
+			return (&statemachine.EventList{}).ClientRequest(0, reqNo, []byte("data"), nil)
+		}
+
+		// queues returns the queue depths, served by Run between the
+		// application of events, so the events submitted before are
+		// either queued or applied by then.
+		queues := func() *status.QueueDepths {
+			s, err := readyNode.Status(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			return s.Queues
+		}
+
+		Expect(readyNode.Apply(ctx, (&statemachine.EventList{}).TickElapsed())).To(Succeed())
+
+		var ready *mirbft.Ready
+		Eventually(readyNode.Ready()).Should(Receive(&ready))
+		Expect(ready.Events.Len()).To(Equal(1))
+
+		Expect(readyNode.Apply(ctx, request(0))).To(Succeed())
+		Expect(readyNode.Apply(ctx, request(1))).To(Succeed())
+		Expect(queues()).To(Equal(&status.QueueDepths{Proposals: 2, PendingActions: 1}))
+		Expect(readyNode.Ready()).NotTo(Receive())
+
+		readyNode.Advance()
+		Eventually(readyNode.Ready()).Should(Receive(&ready))
+		Expect(ready.Events.Len()).To(Equal(2))
+
+		Expect(readyNode.Apply(ctx, request(2))).To(Succeed())
+		Expect(queues()).To(Equal(&status.QueueDepths{Proposals: 1, PendingActions: 2}))
+		Expect(readyNode.Ready()).NotTo(Receive())
+	})
+
+	It("reports the depths of its queues in the status", func() {
+		Expect(readyNode.Apply(ctx, (&statemachine.EventList{}).TickElapsed())).To(Succeed())
+		Eventually(readyNode.Ready()).Should(Receive())
+
+		// Without advancing, proposals and messages back up.
+		for reqNo := uint64(0); reqNo < 3; reqNo++ {
+			Expect(readyNode.Apply(ctx, (&statemachine.EventList{}).ClientRequest(0, reqNo, []byte("data"), nil))).To(Succeed())
+		}
+		Expect(readyNode.Apply(ctx, (&statemachine.EventList{}).Step(1, &msgs.Msg{}))).To(Succeed())
+
+		s, err := readyNode.Status(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Queues).To(Equal(&status.QueueDepths{
+			Proposals:      3,
+			Steps:          1,
+			PendingActions: 1,
+		}))
+
+		readyNode.Advance()
+		Eventually(func() *status.QueueDepths {
+			s, err := readyNode.Status(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			return s.Queues
+		}).Should(Equal(&status.QueueDepths{
+			PendingActions: 4,
+		}))
+	})
+
+	It("returns the audit digest reported by the consumer", func() {
+		digestC := make(chan []byte, 1)
+		go func() {
+			defer GinkgoRecover()
+			digest, err := readyNode.AuditDigest(ctx, 4)
+			Expect(err).NotTo(HaveOccurred())
+			digestC <- digest
+		}()
+
+		var ready *mirbft.Ready
+		Eventually(readyNode.Ready()).Should(Receive(&ready))
+		audit := ready.Events.Iterator().Next().GetAuditDigest()
+		Expect(audit.SeqNo).To(Equal(uint64(4)))
+
+		readyNode.AuditDigestResult(audit.SeqNo, []byte("digest"), nil)
+		readyNode.Advance()
+		Eventually(digestC).Should(Receive(Equal([]byte("digest"))))
+	})
+
+	It("serves the highest prepared sequence of the state machine in the status", func() {
+		s, err := readyNode.Status(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.HighestPrepared).To(Equal(uint64(7)))
+	})
+
+	It("serves the leadership view of the state machine", func() {
+		view, err := readyNode.LeadershipView(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(view.Epoch).To(Equal(uint64(1)))
+		Expect(view.BucketLeaders).To(Equal(map[uint64]uint64{0: 1, 1: 0}))
+	})
+
+	It("reports a state machine which serves no leadership view", func() {
+		_, err := mirbft.NewReadyNode(statusSM{}, nil).LeadershipView(ctx)
+		Expect(err).To(MatchError(mirbft.ErrNoLeadershipView))
+	})
+
+	It("serves the quorum sources of a sequence", func() {
+		prepares, commits, err := readyNode.QuorumSources(ctx, 5)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prepares).To(Equal([]uint64{0, 1, 2}))
+		Expect(commits).To(Equal([]uint64{1, 2, 3}))
+
+		_, _, err = readyNode.QuorumSources(ctx, 9)
+		Expect(err).To(MatchError("seq_no=9 is outside the watermarks"))
+	})
+
+	It("reports a state machine which retains no quorum sources", func() {
+		_, _, err := mirbft.NewReadyNode(statusSM{}, nil).QuorumSources(ctx, 5)
+		Expect(err).To(MatchError(mirbft.ErrUnsupportedQuery))
+	})
+
+	It("serves the batches committed since a sequence", func() {
+		entries, err := readyNode.CommittedSince(ctx, 4)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(Equal([]*msgs.QEntry{{SeqNo: 4}, {SeqNo: 5}}))
+
+		_, err = readyNode.CommittedSince(ctx, 2)
+		Expect(err).To(MatchError("seq_no=2 is below the low watermark"))
+	})
+
+	It("reports a state machine which retains no committed batches", func() {
+		_, err := mirbft.NewReadyNode(statusSM{}, nil).CommittedSince(ctx, 4)
+		Expect(err).To(MatchError(mirbft.ErrUnsupportedQuery))
+	})
+
+	It("reports a state machine which counts no misbehaviors", func() {
+		_, err := readyNode.MisbehaviorCounts(ctx)
+		Expect(err).To(MatchError(mirbft.ErrUnsupportedQuery))
+	})
+
+	When("the state machine counts misbehaviors", func() {
+		BeforeEach(func() {
+			sm = &misbehaviorSM{
+				counts: map[uint64]int{0: 2, 1: 1},
+			}
+		})
+
+		It("serves the counts, and resets them through the state machine", func() {
+			counts, err := readyNode.MisbehaviorCounts(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(counts).To(Equal(map[uint64]int{0: 2, 1: 1}))
+
+			Expect(readyNode.ResetMisbehavior(ctx, 0)).To(Succeed())
+			Eventually(func() map[uint64]int {
+				counts, err := readyNode.MisbehaviorCounts(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				return counts
+			}).Should(Equal(map[uint64]int{1: 1}))
+		})
+	})
+})
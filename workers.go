@@ -9,6 +9,8 @@ Refactored: 1
 package mirbft
 
 import (
+	"sync/atomic"
+
 	"github.com/hyperledger-labs/mirbft/pkg/clients"
 	"github.com/hyperledger-labs/mirbft/pkg/modules"
 	"github.com/hyperledger-labs/mirbft/pkg/pb/state"
@@ -131,10 +133,18 @@ func (n *Node) doClientWork(exitC <-chan struct{}) error {
 
 	// Process events.
 	outputEvents, err := processClientEvents(n.clientTracker, inputEvents)
+	atomic.AddInt64(&n.pendingPreprocess, -int64(inputEvents.Len()))
 	if err != nil {
 		return errors.WithMessage(err, "could not process client events")
 	}
 
+	iter := inputEvents.Iterator()
+	for event := iter.Next(); event != nil; event = iter.Next() {
+		if request, ok := event.Type.(*state.Event_Request); ok {
+			n.preprocessLatency.stop(request.Request.ClientId, request.Request.ReqNo)
+		}
+	}
+
 	// Return if no output was generated.
 	if outputEvents.Len() == 0 {
 		return nil
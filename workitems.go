@@ -50,6 +50,18 @@ func (wi *WorkItems) AddEvents(events *statemachine.EventList) {
 		case *state.Event_TickElapsed:
 			wi.StateMachine().PushBack(event)
 			// TODO: Should the TickElapsed event also go elsewhere?
+		case *state.Event_Step:
+			wi.StateMachine().PushBack(event)
+		case *state.Event_AuditDigest:
+			wi.StateMachine().PushBack(event)
+		case *state.Event_StepDown:
+			wi.StateMachine().PushBack(event)
+		case *state.Event_Compact:
+			wi.StateMachine().PushBack(event)
+		case *state.Event_ImportClientState:
+			wi.StateMachine().PushBack(event)
+		case *state.Event_RequestCheckpoint:
+			wi.StateMachine().PushBack(event)
 		default:
 			panic(fmt.Sprintf("unknown event type %T", t))
 		}